@@ -6,8 +6,12 @@ import (
 )
 
 type Config struct {
-	Server Server `yaml:"server"`
-	MySQL  MySQL  `yaml:"mysql"`
+	Server      Server      `yaml:"server"`
+	MySQL       MySQL       `yaml:"mysql"`
+	Reservation Reservation `yaml:"reservation"`
+	Idempotency Idempotency `yaml:"idempotency"`
+	Location    Location    `yaml:"location"`
+	Notifier    Notifier    `yaml:"notifier"`
 }
 
 type Server struct {
@@ -15,6 +19,29 @@ type Server struct {
 	Timeout time.Duration `yaml:"timeout"` // second
 }
 
+type Reservation struct {
+	SweepInterval time.Duration `yaml:"sweep_interval"` // second
+}
+
+type Idempotency struct {
+	SweepInterval time.Duration `yaml:"sweep_interval"` // second
+}
+
+// Location AllocationStrategy is one of most_stock, location_order,
+// the stock is taken from the location which has the most stock when it is empty
+type Location struct {
+	AllocationStrategy string `yaml:"allocation_strategy"`
+}
+
+// Notifier Type is one of log, webhook, file, the low stock alerts are written to the log when it is empty.
+// WebhookURL is required by the webhook notifier and FilePath is required by the file notifier
+type Notifier struct {
+	Type           string        `yaml:"type"`
+	WebhookURL     string        `yaml:"webhook_url"`
+	WebhookTimeout time.Duration `yaml:"webhook_timeout"` // second
+	FilePath       string        `yaml:"file_path"`
+}
+
 type MySQL struct {
 	Host         string `yaml:"host"`
 	User         string `yaml:"user"`
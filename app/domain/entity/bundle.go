@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// Bundle a kit of several items, it has no stock of its own and buying it takes the stock of its components
+type Bundle struct {
+	ID         valueobject.BundleID
+	CreatedAt  time.Time
+	Name       string
+	Components []BundleComponent
+}
+
+// BundleComponent the quantity of the item in one bundle, an item is at most one component of a bundle
+type BundleComponent struct {
+	BundleID valueobject.BundleID
+	ItemID   valueobject.ItemID
+	Quantity uint64
+}
+
+// AvailableQuantity the number of bundles which can be made from the current stock of the component items,
+// items are the component items by id, the bundle is unavailable when a component is not found or archived,
+// or when the backorders of a component are queued since their stock is held for the queue
+func (b Bundle) AvailableQuantity(items map[valueobject.ItemID]Item) uint64 {
+	var available uint64
+	for i, component := range b.Components {
+		item, ok := items[component.ItemID]
+		if !ok || item.IsArchived() || item.BackordersQueued() {
+			return 0
+		}
+
+		quantity := item.CurrentStockValue / component.Quantity
+		if i == 0 || quantity < available {
+			available = quantity
+		}
+	}
+
+	return available
+}
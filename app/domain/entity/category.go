@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// Category a node of the taxonomy of items, a root category has no parent
+type Category struct {
+	ID        valueobject.CategoryID
+	CreatedAt time.Time
+	Name      string
+	ParentID  *valueobject.CategoryID
+}
+
+// ItemCategory the assignment of an item to a category, an item can be in many categories
+type ItemCategory struct {
+	ItemID     valueobject.ItemID
+	CategoryID valueobject.CategoryID
+}
+
+type Categories []Category
+
+// Find get the category by id from the categories
+func (cs Categories) Find(categoryID valueobject.CategoryID) (Category, bool) {
+	for _, category := range cs {
+		if category.ID == categoryID {
+			return category, true
+		}
+	}
+
+	return Category{}, false
+}
+
+// DescendantIDs the id of category followed by the ids of all its descendants in breadth first order,
+// nil is returned when the category is not one of the categories
+func (cs Categories) DescendantIDs(categoryID valueobject.CategoryID) []valueobject.CategoryID {
+	if _, ok := cs.Find(categoryID); !ok {
+		return nil
+	}
+
+	children := make(map[valueobject.CategoryID][]valueobject.CategoryID)
+	for _, category := range cs {
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category.ID)
+		}
+	}
+
+	ids := []valueobject.CategoryID{categoryID}
+	visited := map[valueobject.CategoryID]bool{categoryID: true}
+	for i := 0; i < len(ids); i++ {
+		for _, childID := range children[ids[i]] {
+			if visited[childID] {
+				continue
+			}
+
+			visited[childID] = true
+			ids = append(ids, childID)
+		}
+	}
+
+	return ids
+}
+
+// HasChildren check some categories are under the category
+func (cs Categories) HasChildren(categoryID valueobject.CategoryID) bool {
+	for _, category := range cs {
+		if category.ParentID != nil && *category.ParentID == categoryID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// IdempotencyRecord the response of a request sent with an idempotency key,
+// ResponseHeaders is the JSON of the response headers
+type IdempotencyRecord struct {
+	ID              valueobject.IdempotencyRecordID
+	CreatedAt       time.Time
+	Key             string
+	RequestHash     string
+	ResponseStatus  int
+	ResponseHeaders string
+	ResponseBody    string
+	ExpiresAt       time.Time
+}
+
+// IsCompleted check the response of request has been stored
+func (r IdempotencyRecord) IsCompleted() bool {
+	return r.ResponseStatus != 0
+}
+
+// IsExpired the key can be taken by another request at the time
+func (r IdempotencyRecord) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
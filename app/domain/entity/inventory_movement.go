@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// InventoryMovement a record of the ledger of item stock,
+// Delta is the change of the current stock value of item.
+// ReasonCode, Actor and Note are only set for the manual adjustments
+type InventoryMovement struct {
+	ID         valueobject.InventoryMovementID
+	CreatedAt  time.Time
+	ItemID     valueobject.ItemID
+	Reason     valueobject.MovementReason
+	Delta      int64
+	ReasonCode valueobject.AdjustmentReasonCode
+	Actor      string
+	Note       string
+}
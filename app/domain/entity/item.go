@@ -9,9 +9,54 @@ import (
 )
 
 type Item struct {
-	ID                valueobject.ItemID
-	CreatedAt         time.Time
+	ID        valueobject.ItemID
+	CreatedAt time.Time
+	// ProductID the parent product of the variant item, it is nil when the item is not a variant
+	ProductID *valueobject.ProductID
+	// SKU the stock keeping unit of item, it is unique among all the items including the archived ones
+	SKU               string
+	Name              string
+	Description       string
 	TotalStockValue   uint64
 	CurrentStockValue uint64
-	SellingPrice      decimal.Decimal
+	// ReservedStockValue the quantity is held by reservations, it is not in the current stock
+	ReservedStockValue uint64
+	// LocatedStockValue the part of the current stock which is kept at locations,
+	// it equals the sum of the location stocks of item
+	LocatedStockValue uint64
+	// InTransitStockValue the quantity is moved between locations by transfers, it is not in the current stock
+	InTransitStockValue uint64
+	// BackorderedStockValue the quantity of the backordered purchases which wait for the stock,
+	// it is not in the current stock
+	BackorderedStockValue uint64
+	// Backorderable the item can be bought when it is out of stock, the purchase is backordered until a restock
+	Backorderable bool
+	// ReorderThreshold a low stock alert is sent when the current stock drops below it, 0 disables the alert
+	ReorderThreshold uint64
+	SellingPrice     decimal.Decimal
+	DeletedAt        *time.Time
+	Version          uint64
+}
+
+// IsArchived check the item has been soft deleted
+func (i Item) IsArchived() bool {
+	return i.DeletedAt != nil
+}
+
+// BackordersQueued check the backorders of item are waiting for its stock, they are fulfilled in FIFO order
+// so a new purchase cannot take the current stock of item until the queue is empty
+func (i Item) BackordersQueued() bool {
+	return i.BackorderedStockValue > 0
+}
+
+// UnassignedStockValue the part of the current stock which is not kept at any location
+func (i Item) UnassignedStockValue() uint64 {
+	return i.CurrentStockValue - i.LocatedStockValue
+}
+
+// CrossesReorderThreshold check the current stock drops below the reorder threshold when it changes to currentStockValue
+func (i Item) CrossesReorderThreshold(currentStockValue uint64) bool {
+	return i.ReorderThreshold > 0 &&
+		i.CurrentStockValue >= i.ReorderThreshold &&
+		currentStockValue < i.ReorderThreshold
 }
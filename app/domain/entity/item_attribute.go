@@ -0,0 +1,16 @@
+package entity
+
+import "github.com/tuanna7593/gosample/app/domain/valueobject"
+
+// ItemTag a free-form label of an item
+type ItemTag struct {
+	ItemID valueobject.ItemID
+	Tag    string
+}
+
+// ItemAttribute a key/value property of an item like color or size, an item has one value for a key
+type ItemAttribute struct {
+	ItemID valueobject.ItemID
+	Key    string
+	Value  string
+}
@@ -0,0 +1,7 @@
+package entity
+
+// ItemSearchHit an item matched the search query, the more relevant item has the higher score
+type ItemSearchHit struct {
+	Item  Item
+	Score float64
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// Location a warehouse or a store where the stock of items is kept
+type Location struct {
+	ID        valueobject.LocationID
+	CreatedAt time.Time
+	Code      string
+	Name      string
+}
+
+// LocationStock the current stock of an item at a location
+type LocationStock struct {
+	ID                valueobject.LocationStockID
+	ItemID            valueobject.ItemID
+	LocationID        valueobject.LocationID
+	CurrentStockValue uint64
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type Order struct {
+	ID         valueobject.OrderID
+	CreatedAt  time.Time
+	TotalPrice decimal.Decimal
+	Lines      []OrderLine
+}
+
+// OrderLine each line is recorded as a purchase of its item
+type OrderLine struct {
+	ID         valueobject.OrderLineID
+	OrderID    valueobject.OrderID
+	ItemID     valueobject.ItemID
+	PurchaseID valueobject.PurchaseID
+	Quantity   uint64
+	UnitPrice  decimal.Decimal
+}
+
+// LineTotal the price of the line
+func (l OrderLine) LineTotal() decimal.Decimal {
+	return l.UnitPrice.Mul(decimal.NewFromInt(int64(l.Quantity)))
+}
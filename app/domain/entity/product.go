@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// Product the parent of the variant items like the sizes and colors of a T-shirt,
+// the stock and selling price are kept on each variant
+type Product struct {
+	ID          valueobject.ProductID
+	CreatedAt   time.Time
+	Name        string
+	Description string
+}
+
+// ProductStock the aggregate stock of the variants of a product
+type ProductStock struct {
+	TotalStockValue       uint64
+	CurrentStockValue     uint64
+	ReservedStockValue    uint64
+	BackorderedStockValue uint64
+}
+
+// NewProductStock sum the stock of the variants, the archived variants are not counted
+func NewProductStock(variants []Item) ProductStock {
+	var stock ProductStock
+	for _, variant := range variants {
+		if variant.IsArchived() {
+			continue
+		}
+
+		stock.TotalStockValue += variant.TotalStockValue
+		stock.CurrentStockValue += variant.CurrentStockValue
+		stock.ReservedStockValue += variant.ReservedStockValue
+		stock.BackorderedStockValue += variant.BackorderedStockValue
+	}
+
+	return stock
+}
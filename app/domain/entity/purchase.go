@@ -7,8 +7,23 @@ import (
 )
 
 type Purchase struct {
-	ID        valueobject.PurchaseID
-	CreatedAt time.Time
-	ItemID    valueobject.ItemID
-	Quantity  uint64
+	ID               valueobject.PurchaseID
+	CreatedAt        time.Time
+	ItemID           valueobject.ItemID
+	Quantity         uint64
+	RefundedQuantity uint64
+	RefundedAt       *time.Time
+	Status           valueobject.PurchaseStatus
+	// FulfilledAt the time a backordered purchase is fulfilled, it is nil for the other purchases
+	FulfilledAt *time.Time
+}
+
+// IsBackordered the purchase is waiting for the stock of item
+func (p Purchase) IsBackordered() bool {
+	return p.Status == valueobject.PurchaseStatusBackordered
+}
+
+// RefundableQuantity the quantity can still be refunded
+func (p Purchase) RefundableQuantity() uint64 {
+	return p.Quantity - p.RefundedQuantity
 }
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// Reservation hold the quantity of an item until it is confirmed or expired
+type Reservation struct {
+	ID         valueobject.ReservationID
+	CreatedAt  time.Time
+	ItemID     valueobject.ItemID
+	Quantity   uint64
+	Status     valueobject.ReservationStatus
+	ExpiresAt  time.Time
+	PurchaseID *valueobject.PurchaseID
+}
+
+// IsHeld the reservation is still holding the stock
+func (r Reservation) IsHeld() bool {
+	return r.Status == valueobject.ReservationStatusHeld
+}
+
+// IsExpired the hold is over at the time
+func (r Reservation) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
@@ -0,0 +1,10 @@
+package entity
+
+import "github.com/tuanna7593/gosample/app/domain/valueobject"
+
+// LowStockAlert the current stock of an item has dropped below its reorder threshold
+type LowStockAlert struct {
+	ItemID            valueobject.ItemID
+	CurrentStockValue uint64
+	ReorderThreshold  uint64
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// Transfer move the quantity of an item from a location to another,
+// the quantity is in transit until the transfer is received
+type Transfer struct {
+	ID             valueobject.TransferID
+	CreatedAt      time.Time
+	ItemID         valueobject.ItemID
+	FromLocationID valueobject.LocationID
+	ToLocationID   valueobject.LocationID
+	Quantity       uint64
+	Status         valueobject.TransferStatus
+	ReceivedAt     *time.Time
+}
+
+// IsInTransit the transfer has not been received
+func (t Transfer) IsInTransit() bool {
+	return t.Status == valueobject.TransferStatusInTransit
+}
@@ -0,0 +1,16 @@
+package repository
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+import (
+	"context"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type BundleRepository interface {
+	// Create create the bundle with its components
+	Create(ctx context.Context, bundle *entity.Bundle) error
+	GetByID(ctx context.Context, bundleID valueobject.BundleID) (entity.Bundle, error)
+}
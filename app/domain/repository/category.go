@@ -0,0 +1,23 @@
+package repository
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+import (
+	"context"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type CategoryRepository interface {
+	AssignTx(txm TransactionManager)
+	Create(ctx context.Context, category *entity.Category) error
+	Updates(ctx context.Context, category *entity.Category, values map[string]interface{}) error
+	// Delete delete the category and its assignments to items
+	Delete(ctx context.Context, category *entity.Category) error
+	GetByID(ctx context.Context, categoryID valueobject.CategoryID) (entity.Category, error)
+	// List get all the categories, the taxonomy is small so it is walked in memory
+	List(ctx context.Context) ([]entity.Category, error)
+	// ListForUpdate get all the categories with SELECT ... FOR UPDATE, it must be called in a transaction
+	ListForUpdate(ctx context.Context) ([]entity.Category, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+)
+
+type IdempotencyRepository interface {
+	AssignTx(txm TransactionManager)
+	// CreateIfNotExists false is returned when the key has been used
+	CreateIfNotExists(ctx context.Context, record *entity.IdempotencyRecord) (bool, error)
+	GetByKey(ctx context.Context, key string) (entity.IdempotencyRecord, error)
+	// TakeOverExpired replace the expired record of the key, false is returned when the record is not expired
+	// or another request has taken it over
+	TakeOverExpired(ctx context.Context, record *entity.IdempotencyRecord, now time.Time) (bool, error)
+	Updates(ctx context.Context, record *entity.IdempotencyRecord, values map[string]interface{}) error
+	Delete(ctx context.Context, record *entity.IdempotencyRecord) error
+	// DeleteExpired remove the records which are expired at the time, the number of removed records is returned
+	DeleteExpired(ctx context.Context, now time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+import (
+	"context"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type InventoryMovementRepository interface {
+	AssignTx(txm TransactionManager)
+	Create(ctx context.Context, movement *entity.InventoryMovement) error
+	// ListByItem get the movements of an item, the newest movement first
+	ListByItem(ctx context.Context, itemID valueobject.ItemID, pagination valueobject.PaginationRequest) ([]entity.InventoryMovement, error)
+	// CountByItem count the movements of an item
+	CountByItem(ctx context.Context, itemID valueobject.ItemID) (int64, error)
+}
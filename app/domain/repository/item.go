@@ -4,15 +4,28 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/tuanna7593/gosample/app/domain/entity"
 	"github.com/tuanna7593/gosample/app/domain/valueobject"
 )
 
+// ErrSKUExists the sku of item has been used by another item
+var ErrSKUExists = errors.New("the sku has been used")
+
 type ItemRepository interface {
 	AssignTx(txm TransactionManager)
+	// Create create an item, ErrSKUExists is returned when the sku has been used
 	Create(ctx context.Context, item *entity.Item) error
 	Updates(ctx context.Context, item *entity.Item, values map[string]interface{}) error
-	List(ctx context.Context, pagination valueobject.PaginationRequest) ([]entity.Item, error)
+	List(ctx context.Context, filter valueobject.ItemFilter, pagination valueobject.PaginationRequest) ([]entity.Item, error)
+	// Count count the items matched the filter, the sorts of filter are ignored
+	Count(ctx context.Context, filter valueobject.ItemFilter) (int64, error)
 	GetByID(ctx context.Context, itemID valueobject.ItemID) (entity.Item, error)
+	// GetByIDForUpdate lock the item row until the transaction ends
+	GetByIDForUpdate(ctx context.Context, itemID valueobject.ItemID) (entity.Item, error)
+	GetBySKU(ctx context.Context, sku string) (entity.Item, error)
+	// ListByProduct get the variants of the product which are not archived
+	ListByProduct(ctx context.Context, productID valueobject.ProductID) ([]entity.Item, error)
+	Archive(ctx context.Context, item *entity.Item) error
 }
@@ -0,0 +1,19 @@
+package repository
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+import (
+	"context"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type ItemAttributeRepository interface {
+	// Set set the value of the attribute key of item, the previous value of the key is replaced
+	Set(ctx context.Context, attribute *entity.ItemAttribute) error
+	// Remove remove the attribute key from the item, removing a key which the item doesn't have does nothing
+	Remove(ctx context.Context, itemID valueobject.ItemID, key string) error
+	// ListByItem get the attributes of item ordered by key
+	ListByItem(ctx context.Context, itemID valueobject.ItemID) ([]entity.ItemAttribute, error)
+}
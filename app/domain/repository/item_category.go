@@ -0,0 +1,18 @@
+package repository
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+import (
+	"context"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type ItemCategoryRepository interface {
+	AssignTx(txm TransactionManager)
+	// ReplaceByItem replace the categories of item with categoryIDs
+	ReplaceByItem(ctx context.Context, itemID valueobject.ItemID, categoryIDs []valueobject.CategoryID) error
+	// ListCategoriesByItem get the categories assigned to item ordered by id
+	ListCategoriesByItem(ctx context.Context, itemID valueobject.ItemID) ([]entity.Category, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+import (
+	"context"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type ItemSearchRepository interface {
+	// Search get the items which name or description has a word starting with every term of query,
+	// the items are ordered by relevance then by id and archived items are excluded
+	Search(ctx context.Context, query valueobject.ItemSearchQuery, pagination valueobject.PaginationRequest) ([]entity.ItemSearchHit, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+import (
+	"context"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type ItemTagRepository interface {
+	// Add add the tag to the item, adding a tag which the item has does nothing
+	Add(ctx context.Context, tag *entity.ItemTag) error
+	// Remove remove the tag from the item, removing a tag which the item doesn't have does nothing
+	Remove(ctx context.Context, itemID valueobject.ItemID, tag string) error
+	// ListByItem get the tags of item ordered by tag
+	ListByItem(ctx context.Context, itemID valueobject.ItemID) ([]entity.ItemTag, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+import (
+	"context"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type LocationRepository interface {
+	Create(ctx context.Context, location *entity.Location) error
+	GetByID(ctx context.Context, locationID valueobject.LocationID) (entity.Location, error)
+	GetByCode(ctx context.Context, code string) (entity.Location, error)
+	List(ctx context.Context) ([]entity.Location, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+import (
+	"context"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type LocationStockRepository interface {
+	AssignTx(txm TransactionManager)
+	Create(ctx context.Context, stock *entity.LocationStock) error
+	Updates(ctx context.Context, stock *entity.LocationStock, values map[string]interface{}) error
+	ListByItem(ctx context.Context, itemID valueobject.ItemID) ([]entity.LocationStock, error)
+	ListByItems(ctx context.Context, itemIDs []valueobject.ItemID) ([]entity.LocationStock, error)
+	// ListByItemForUpdate get the location stocks of item with SELECT ... FOR UPDATE,
+	// it must be called in a transaction
+	ListByItemForUpdate(ctx context.Context, itemID valueobject.ItemID) ([]entity.LocationStock, error)
+	// GetForUpdate get the stock of item at a location with SELECT ... FOR UPDATE,
+	// it must be called in a transaction
+	GetForUpdate(ctx context.Context, itemID valueobject.ItemID, locationID valueobject.LocationID) (entity.LocationStock, error)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: bundle.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tuanna7593/gosample/app/domain/entity"
+	valueobject "github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// MockBundleRepository is a mock of BundleRepository interface.
+type MockBundleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBundleRepositoryMockRecorder
+}
+
+// MockBundleRepositoryMockRecorder is the mock recorder for MockBundleRepository.
+type MockBundleRepositoryMockRecorder struct {
+	mock *MockBundleRepository
+}
+
+// NewMockBundleRepository creates a new mock instance.
+func NewMockBundleRepository(ctrl *gomock.Controller) *MockBundleRepository {
+	mock := &MockBundleRepository{ctrl: ctrl}
+	mock.recorder = &MockBundleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBundleRepository) EXPECT() *MockBundleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBundleRepository) Create(ctx context.Context, bundle *entity.Bundle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, bundle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBundleRepositoryMockRecorder) Create(ctx, bundle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBundleRepository)(nil).Create), ctx, bundle)
+}
+
+// GetByID mocks base method.
+func (m *MockBundleRepository) GetByID(ctx context.Context, bundleID valueobject.BundleID) (entity.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, bundleID)
+	ret0, _ := ret[0].(entity.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockBundleRepositoryMockRecorder) GetByID(ctx, bundleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBundleRepository)(nil).GetByID), ctx, bundleID)
+}
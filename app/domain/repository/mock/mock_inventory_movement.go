@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: inventory_movement.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tuanna7593/gosample/app/domain/entity"
+	repository "github.com/tuanna7593/gosample/app/domain/repository"
+	valueobject "github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// MockInventoryMovementRepository is a mock of InventoryMovementRepository interface.
+type MockInventoryMovementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryMovementRepositoryMockRecorder
+}
+
+// MockInventoryMovementRepositoryMockRecorder is the mock recorder for MockInventoryMovementRepository.
+type MockInventoryMovementRepositoryMockRecorder struct {
+	mock *MockInventoryMovementRepository
+}
+
+// NewMockInventoryMovementRepository creates a new mock instance.
+func NewMockInventoryMovementRepository(ctrl *gomock.Controller) *MockInventoryMovementRepository {
+	mock := &MockInventoryMovementRepository{ctrl: ctrl}
+	mock.recorder = &MockInventoryMovementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryMovementRepository) EXPECT() *MockInventoryMovementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AssignTx mocks base method.
+func (m *MockInventoryMovementRepository) AssignTx(txm repository.TransactionManager) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AssignTx", txm)
+}
+
+// AssignTx indicates an expected call of AssignTx.
+func (mr *MockInventoryMovementRepositoryMockRecorder) AssignTx(txm interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTx", reflect.TypeOf((*MockInventoryMovementRepository)(nil).AssignTx), txm)
+}
+
+// CountByItem mocks base method.
+func (m *MockInventoryMovementRepository) CountByItem(ctx context.Context, itemID valueobject.ItemID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByItem", ctx, itemID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByItem indicates an expected call of CountByItem.
+func (mr *MockInventoryMovementRepositoryMockRecorder) CountByItem(ctx, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByItem", reflect.TypeOf((*MockInventoryMovementRepository)(nil).CountByItem), ctx, itemID)
+}
+
+// Create mocks base method.
+func (m *MockInventoryMovementRepository) Create(ctx context.Context, movement *entity.InventoryMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, movement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInventoryMovementRepositoryMockRecorder) Create(ctx, movement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInventoryMovementRepository)(nil).Create), ctx, movement)
+}
+
+// ListByItem mocks base method.
+func (m *MockInventoryMovementRepository) ListByItem(ctx context.Context, itemID valueobject.ItemID, pagination valueobject.PaginationRequest) ([]entity.InventoryMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByItem", ctx, itemID, pagination)
+	ret0, _ := ret[0].([]entity.InventoryMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByItem indicates an expected call of ListByItem.
+func (mr *MockInventoryMovementRepositoryMockRecorder) ListByItem(ctx, itemID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByItem", reflect.TypeOf((*MockInventoryMovementRepository)(nil).ListByItem), ctx, itemID, pagination)
+}
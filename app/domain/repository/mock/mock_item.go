@@ -37,6 +37,20 @@ func (m *MockItemRepository) EXPECT() *MockItemRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Archive mocks base method.
+func (m *MockItemRepository) Archive(ctx context.Context, item *entity.Item) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockItemRepositoryMockRecorder) Archive(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockItemRepository)(nil).Archive), ctx, item)
+}
+
 // AssignTx mocks base method.
 func (m *MockItemRepository) AssignTx(txm repository.TransactionManager) {
 	m.ctrl.T.Helper()
@@ -49,6 +63,21 @@ func (mr *MockItemRepositoryMockRecorder) AssignTx(txm interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTx", reflect.TypeOf((*MockItemRepository)(nil).AssignTx), txm)
 }
 
+// Count mocks base method.
+func (m *MockItemRepository) Count(ctx context.Context, filter valueobject.ItemFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockItemRepositoryMockRecorder) Count(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockItemRepository)(nil).Count), ctx, filter)
+}
+
 // Create mocks base method.
 func (m *MockItemRepository) Create(ctx context.Context, item *entity.Item) error {
 	m.ctrl.T.Helper()
@@ -78,19 +107,64 @@ func (mr *MockItemRepositoryMockRecorder) GetByID(ctx, itemID interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockItemRepository)(nil).GetByID), ctx, itemID)
 }
 
+// GetByIDForUpdate mocks base method.
+func (m *MockItemRepository) GetByIDForUpdate(ctx context.Context, itemID valueobject.ItemID) (entity.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, itemID)
+	ret0, _ := ret[0].(entity.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockItemRepositoryMockRecorder) GetByIDForUpdate(ctx, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockItemRepository)(nil).GetByIDForUpdate), ctx, itemID)
+}
+
+// GetBySKU mocks base method.
+func (m *MockItemRepository) GetBySKU(ctx context.Context, sku string) (entity.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySKU", ctx, sku)
+	ret0, _ := ret[0].(entity.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySKU indicates an expected call of GetBySKU.
+func (mr *MockItemRepositoryMockRecorder) GetBySKU(ctx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySKU", reflect.TypeOf((*MockItemRepository)(nil).GetBySKU), ctx, sku)
+}
+
 // List mocks base method.
-func (m *MockItemRepository) List(ctx context.Context, pagination valueobject.PaginationRequest) ([]entity.Item, error) {
+func (m *MockItemRepository) List(ctx context.Context, filter valueobject.ItemFilter, pagination valueobject.PaginationRequest) ([]entity.Item, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, pagination)
+	ret := m.ctrl.Call(m, "List", ctx, filter, pagination)
 	ret0, _ := ret[0].([]entity.Item)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockItemRepositoryMockRecorder) List(ctx, pagination interface{}) *gomock.Call {
+func (mr *MockItemRepositoryMockRecorder) List(ctx, filter, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockItemRepository)(nil).List), ctx, filter, pagination)
+}
+
+// ListByProduct mocks base method.
+func (m *MockItemRepository) ListByProduct(ctx context.Context, productID valueobject.ProductID) ([]entity.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByProduct", ctx, productID)
+	ret0, _ := ret[0].([]entity.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByProduct indicates an expected call of ListByProduct.
+func (mr *MockItemRepositoryMockRecorder) ListByProduct(ctx, productID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockItemRepository)(nil).List), ctx, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByProduct", reflect.TypeOf((*MockItemRepository)(nil).ListByProduct), ctx, productID)
 }
 
 // Updates mocks base method.
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: item_attribute.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tuanna7593/gosample/app/domain/entity"
+	valueobject "github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// MockItemAttributeRepository is a mock of ItemAttributeRepository interface.
+type MockItemAttributeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemAttributeRepositoryMockRecorder
+}
+
+// MockItemAttributeRepositoryMockRecorder is the mock recorder for MockItemAttributeRepository.
+type MockItemAttributeRepositoryMockRecorder struct {
+	mock *MockItemAttributeRepository
+}
+
+// NewMockItemAttributeRepository creates a new mock instance.
+func NewMockItemAttributeRepository(ctrl *gomock.Controller) *MockItemAttributeRepository {
+	mock := &MockItemAttributeRepository{ctrl: ctrl}
+	mock.recorder = &MockItemAttributeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemAttributeRepository) EXPECT() *MockItemAttributeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListByItem mocks base method.
+func (m *MockItemAttributeRepository) ListByItem(ctx context.Context, itemID valueobject.ItemID) ([]entity.ItemAttribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByItem", ctx, itemID)
+	ret0, _ := ret[0].([]entity.ItemAttribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByItem indicates an expected call of ListByItem.
+func (mr *MockItemAttributeRepositoryMockRecorder) ListByItem(ctx, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByItem", reflect.TypeOf((*MockItemAttributeRepository)(nil).ListByItem), ctx, itemID)
+}
+
+// Remove mocks base method.
+func (m *MockItemAttributeRepository) Remove(ctx context.Context, itemID valueobject.ItemID, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, itemID, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockItemAttributeRepositoryMockRecorder) Remove(ctx, itemID, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockItemAttributeRepository)(nil).Remove), ctx, itemID, key)
+}
+
+// Set mocks base method.
+func (m *MockItemAttributeRepository) Set(ctx context.Context, attribute *entity.ItemAttribute) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, attribute)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockItemAttributeRepositoryMockRecorder) Set(ctx, attribute interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockItemAttributeRepository)(nil).Set), ctx, attribute)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: item_category.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tuanna7593/gosample/app/domain/entity"
+	repository "github.com/tuanna7593/gosample/app/domain/repository"
+	valueobject "github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// MockItemCategoryRepository is a mock of ItemCategoryRepository interface.
+type MockItemCategoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemCategoryRepositoryMockRecorder
+}
+
+// MockItemCategoryRepositoryMockRecorder is the mock recorder for MockItemCategoryRepository.
+type MockItemCategoryRepositoryMockRecorder struct {
+	mock *MockItemCategoryRepository
+}
+
+// NewMockItemCategoryRepository creates a new mock instance.
+func NewMockItemCategoryRepository(ctrl *gomock.Controller) *MockItemCategoryRepository {
+	mock := &MockItemCategoryRepository{ctrl: ctrl}
+	mock.recorder = &MockItemCategoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemCategoryRepository) EXPECT() *MockItemCategoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AssignTx mocks base method.
+func (m *MockItemCategoryRepository) AssignTx(txm repository.TransactionManager) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AssignTx", txm)
+}
+
+// AssignTx indicates an expected call of AssignTx.
+func (mr *MockItemCategoryRepositoryMockRecorder) AssignTx(txm interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTx", reflect.TypeOf((*MockItemCategoryRepository)(nil).AssignTx), txm)
+}
+
+// ListCategoriesByItem mocks base method.
+func (m *MockItemCategoryRepository) ListCategoriesByItem(ctx context.Context, itemID valueobject.ItemID) ([]entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategoriesByItem", ctx, itemID)
+	ret0, _ := ret[0].([]entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategoriesByItem indicates an expected call of ListCategoriesByItem.
+func (mr *MockItemCategoryRepositoryMockRecorder) ListCategoriesByItem(ctx, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoriesByItem", reflect.TypeOf((*MockItemCategoryRepository)(nil).ListCategoriesByItem), ctx, itemID)
+}
+
+// ReplaceByItem mocks base method.
+func (m *MockItemCategoryRepository) ReplaceByItem(ctx context.Context, itemID valueobject.ItemID, categoryIDs []valueobject.CategoryID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceByItem", ctx, itemID, categoryIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceByItem indicates an expected call of ReplaceByItem.
+func (mr *MockItemCategoryRepositoryMockRecorder) ReplaceByItem(ctx, itemID, categoryIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceByItem", reflect.TypeOf((*MockItemCategoryRepository)(nil).ReplaceByItem), ctx, itemID, categoryIDs)
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: item_search.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tuanna7593/gosample/app/domain/entity"
+	valueobject "github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// MockItemSearchRepository is a mock of ItemSearchRepository interface.
+type MockItemSearchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemSearchRepositoryMockRecorder
+}
+
+// MockItemSearchRepositoryMockRecorder is the mock recorder for MockItemSearchRepository.
+type MockItemSearchRepositoryMockRecorder struct {
+	mock *MockItemSearchRepository
+}
+
+// NewMockItemSearchRepository creates a new mock instance.
+func NewMockItemSearchRepository(ctrl *gomock.Controller) *MockItemSearchRepository {
+	mock := &MockItemSearchRepository{ctrl: ctrl}
+	mock.recorder = &MockItemSearchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemSearchRepository) EXPECT() *MockItemSearchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockItemSearchRepository) Search(ctx context.Context, query valueobject.ItemSearchQuery, pagination valueobject.PaginationRequest) ([]entity.ItemSearchHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, pagination)
+	ret0, _ := ret[0].([]entity.ItemSearchHit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockItemSearchRepositoryMockRecorder) Search(ctx, query, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockItemSearchRepository)(nil).Search), ctx, query, pagination)
+}
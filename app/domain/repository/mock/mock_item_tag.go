@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: item_tag.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tuanna7593/gosample/app/domain/entity"
+	valueobject "github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// MockItemTagRepository is a mock of ItemTagRepository interface.
+type MockItemTagRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemTagRepositoryMockRecorder
+}
+
+// MockItemTagRepositoryMockRecorder is the mock recorder for MockItemTagRepository.
+type MockItemTagRepositoryMockRecorder struct {
+	mock *MockItemTagRepository
+}
+
+// NewMockItemTagRepository creates a new mock instance.
+func NewMockItemTagRepository(ctrl *gomock.Controller) *MockItemTagRepository {
+	mock := &MockItemTagRepository{ctrl: ctrl}
+	mock.recorder = &MockItemTagRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemTagRepository) EXPECT() *MockItemTagRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockItemTagRepository) Add(ctx context.Context, tag *entity.ItemTag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockItemTagRepositoryMockRecorder) Add(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockItemTagRepository)(nil).Add), ctx, tag)
+}
+
+// ListByItem mocks base method.
+func (m *MockItemTagRepository) ListByItem(ctx context.Context, itemID valueobject.ItemID) ([]entity.ItemTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByItem", ctx, itemID)
+	ret0, _ := ret[0].([]entity.ItemTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByItem indicates an expected call of ListByItem.
+func (mr *MockItemTagRepositoryMockRecorder) ListByItem(ctx, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByItem", reflect.TypeOf((*MockItemTagRepository)(nil).ListByItem), ctx, itemID)
+}
+
+// Remove mocks base method.
+func (m *MockItemTagRepository) Remove(ctx context.Context, itemID valueobject.ItemID, tag string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, itemID, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockItemTagRepositoryMockRecorder) Remove(ctx, itemID, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockItemTagRepository)(nil).Remove), ctx, itemID, tag)
+}
@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: location_stock.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tuanna7593/gosample/app/domain/entity"
+	repository "github.com/tuanna7593/gosample/app/domain/repository"
+	valueobject "github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// MockLocationStockRepository is a mock of LocationStockRepository interface.
+type MockLocationStockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocationStockRepositoryMockRecorder
+}
+
+// MockLocationStockRepositoryMockRecorder is the mock recorder for MockLocationStockRepository.
+type MockLocationStockRepositoryMockRecorder struct {
+	mock *MockLocationStockRepository
+}
+
+// NewMockLocationStockRepository creates a new mock instance.
+func NewMockLocationStockRepository(ctrl *gomock.Controller) *MockLocationStockRepository {
+	mock := &MockLocationStockRepository{ctrl: ctrl}
+	mock.recorder = &MockLocationStockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocationStockRepository) EXPECT() *MockLocationStockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AssignTx mocks base method.
+func (m *MockLocationStockRepository) AssignTx(txm repository.TransactionManager) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AssignTx", txm)
+}
+
+// AssignTx indicates an expected call of AssignTx.
+func (mr *MockLocationStockRepositoryMockRecorder) AssignTx(txm interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTx", reflect.TypeOf((*MockLocationStockRepository)(nil).AssignTx), txm)
+}
+
+// Create mocks base method.
+func (m *MockLocationStockRepository) Create(ctx context.Context, stock *entity.LocationStock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, stock)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLocationStockRepositoryMockRecorder) Create(ctx, stock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLocationStockRepository)(nil).Create), ctx, stock)
+}
+
+// GetForUpdate mocks base method.
+func (m *MockLocationStockRepository) GetForUpdate(ctx context.Context, itemID valueobject.ItemID, locationID valueobject.LocationID) (entity.LocationStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForUpdate", ctx, itemID, locationID)
+	ret0, _ := ret[0].(entity.LocationStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForUpdate indicates an expected call of GetForUpdate.
+func (mr *MockLocationStockRepositoryMockRecorder) GetForUpdate(ctx, itemID, locationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForUpdate", reflect.TypeOf((*MockLocationStockRepository)(nil).GetForUpdate), ctx, itemID, locationID)
+}
+
+// ListByItem mocks base method.
+func (m *MockLocationStockRepository) ListByItem(ctx context.Context, itemID valueobject.ItemID) ([]entity.LocationStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByItem", ctx, itemID)
+	ret0, _ := ret[0].([]entity.LocationStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByItem indicates an expected call of ListByItem.
+func (mr *MockLocationStockRepositoryMockRecorder) ListByItem(ctx, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByItem", reflect.TypeOf((*MockLocationStockRepository)(nil).ListByItem), ctx, itemID)
+}
+
+// ListByItemForUpdate mocks base method.
+func (m *MockLocationStockRepository) ListByItemForUpdate(ctx context.Context, itemID valueobject.ItemID) ([]entity.LocationStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByItemForUpdate", ctx, itemID)
+	ret0, _ := ret[0].([]entity.LocationStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByItemForUpdate indicates an expected call of ListByItemForUpdate.
+func (mr *MockLocationStockRepositoryMockRecorder) ListByItemForUpdate(ctx, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByItemForUpdate", reflect.TypeOf((*MockLocationStockRepository)(nil).ListByItemForUpdate), ctx, itemID)
+}
+
+// ListByItems mocks base method.
+func (m *MockLocationStockRepository) ListByItems(ctx context.Context, itemIDs []valueobject.ItemID) ([]entity.LocationStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByItems", ctx, itemIDs)
+	ret0, _ := ret[0].([]entity.LocationStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByItems indicates an expected call of ListByItems.
+func (mr *MockLocationStockRepositoryMockRecorder) ListByItems(ctx, itemIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByItems", reflect.TypeOf((*MockLocationStockRepository)(nil).ListByItems), ctx, itemIDs)
+}
+
+// Updates mocks base method.
+func (m *MockLocationStockRepository) Updates(ctx context.Context, stock *entity.LocationStock, values map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Updates", ctx, stock, values)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Updates indicates an expected call of Updates.
+func (mr *MockLocationStockRepositoryMockRecorder) Updates(ctx, stock, values interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Updates", reflect.TypeOf((*MockLocationStockRepository)(nil).Updates), ctx, stock, values)
+}
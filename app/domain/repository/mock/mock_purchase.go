@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	entity "github.com/tuanna7593/gosample/app/domain/entity"
 	repository "github.com/tuanna7593/gosample/app/domain/repository"
+	valueobject "github.com/tuanna7593/gosample/app/domain/valueobject"
 )
 
 // MockPurchaseRepository is a mock of PurchaseRepository interface.
@@ -48,6 +49,21 @@ func (mr *MockPurchaseRepositoryMockRecorder) AssignTx(txm interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTx", reflect.TypeOf((*MockPurchaseRepository)(nil).AssignTx), txm)
 }
 
+// Count mocks base method.
+func (m *MockPurchaseRepository) Count(ctx context.Context, filter valueobject.PurchaseFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockPurchaseRepositoryMockRecorder) Count(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockPurchaseRepository)(nil).Count), ctx, filter)
+}
+
 // Create mocks base method.
 func (m *MockPurchaseRepository) Create(ctx context.Context, purchase *entity.Purchase) error {
 	m.ctrl.T.Helper()
@@ -61,3 +77,77 @@ func (mr *MockPurchaseRepositoryMockRecorder) Create(ctx, purchase interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPurchaseRepository)(nil).Create), ctx, purchase)
 }
+
+// GetByID mocks base method.
+func (m *MockPurchaseRepository) GetByID(ctx context.Context, purchaseID valueobject.PurchaseID) (entity.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, purchaseID)
+	ret0, _ := ret[0].(entity.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPurchaseRepositoryMockRecorder) GetByID(ctx, purchaseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPurchaseRepository)(nil).GetByID), ctx, purchaseID)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockPurchaseRepository) GetByIDForUpdate(ctx context.Context, purchaseID valueobject.PurchaseID) (entity.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, purchaseID)
+	ret0, _ := ret[0].(entity.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockPurchaseRepositoryMockRecorder) GetByIDForUpdate(ctx, purchaseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockPurchaseRepository)(nil).GetByIDForUpdate), ctx, purchaseID)
+}
+
+// List mocks base method.
+func (m *MockPurchaseRepository) List(ctx context.Context, filter valueobject.PurchaseFilter, pagination valueobject.PaginationRequest) ([]entity.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, pagination)
+	ret0, _ := ret[0].([]entity.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPurchaseRepositoryMockRecorder) List(ctx, filter, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPurchaseRepository)(nil).List), ctx, filter, pagination)
+}
+
+// ListBackorderedByItemForUpdate mocks base method.
+func (m *MockPurchaseRepository) ListBackorderedByItemForUpdate(ctx context.Context, itemID valueobject.ItemID) ([]entity.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBackorderedByItemForUpdate", ctx, itemID)
+	ret0, _ := ret[0].([]entity.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBackorderedByItemForUpdate indicates an expected call of ListBackorderedByItemForUpdate.
+func (mr *MockPurchaseRepositoryMockRecorder) ListBackorderedByItemForUpdate(ctx, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBackorderedByItemForUpdate", reflect.TypeOf((*MockPurchaseRepository)(nil).ListBackorderedByItemForUpdate), ctx, itemID)
+}
+
+// Updates mocks base method.
+func (m *MockPurchaseRepository) Updates(ctx context.Context, purchase *entity.Purchase, values map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Updates", ctx, purchase, values)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Updates indicates an expected call of Updates.
+func (mr *MockPurchaseRepositoryMockRecorder) Updates(ctx, purchase, values interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Updates", reflect.TypeOf((*MockPurchaseRepository)(nil).Updates), ctx, purchase, values)
+}
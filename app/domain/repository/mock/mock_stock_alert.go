@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stock_alert.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/tuanna7593/gosample/app/domain/entity"
+)
+
+// MockStockAlertNotifier is a mock of StockAlertNotifier interface.
+type MockStockAlertNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockAlertNotifierMockRecorder
+}
+
+// MockStockAlertNotifierMockRecorder is the mock recorder for MockStockAlertNotifier.
+type MockStockAlertNotifierMockRecorder struct {
+	mock *MockStockAlertNotifier
+}
+
+// NewMockStockAlertNotifier creates a new mock instance.
+func NewMockStockAlertNotifier(ctrl *gomock.Controller) *MockStockAlertNotifier {
+	mock := &MockStockAlertNotifier{ctrl: ctrl}
+	mock.recorder = &MockStockAlertNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockAlertNotifier) EXPECT() *MockStockAlertNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyLowStock mocks base method.
+func (m *MockStockAlertNotifier) NotifyLowStock(ctx context.Context, alert entity.LowStockAlert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyLowStock", ctx, alert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyLowStock indicates an expected call of NotifyLowStock.
+func (mr *MockStockAlertNotifierMockRecorder) NotifyLowStock(ctx, alert interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyLowStock", reflect.TypeOf((*MockStockAlertNotifier)(nil).NotifyLowStock), ctx, alert)
+}
@@ -0,0 +1,17 @@
+package repository
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+import (
+	"context"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type OrderRepository interface {
+	AssignTx(txm TransactionManager)
+	// Create create the order with its lines
+	Create(ctx context.Context, order *entity.Order) error
+	GetByID(ctx context.Context, orderID valueobject.OrderID) (entity.Order, error)
+}
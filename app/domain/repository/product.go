@@ -0,0 +1,15 @@
+package repository
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+import (
+	"context"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type ProductRepository interface {
+	Create(ctx context.Context, product *entity.Product) error
+	GetByID(ctx context.Context, productID valueobject.ProductID) (entity.Product, error)
+}
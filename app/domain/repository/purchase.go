@@ -6,9 +6,19 @@ import (
 	"context"
 
 	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
 )
 
 type PurchaseRepository interface {
 	AssignTx(txm TransactionManager)
 	Create(ctx context.Context, purchase *entity.Purchase) error
+	Updates(ctx context.Context, purchase *entity.Purchase, values map[string]interface{}) error
+	List(ctx context.Context, filter valueobject.PurchaseFilter, pagination valueobject.PaginationRequest) ([]entity.Purchase, error)
+	Count(ctx context.Context, filter valueobject.PurchaseFilter) (int64, error)
+	GetByID(ctx context.Context, purchaseID valueobject.PurchaseID) (entity.Purchase, error)
+	// GetByIDForUpdate lock the purchase row until the transaction ends
+	GetByIDForUpdate(ctx context.Context, purchaseID valueobject.PurchaseID) (entity.Purchase, error)
+	// ListBackorderedByItemForUpdate get the backordered purchases of item from the oldest
+	// with SELECT ... FOR UPDATE, it must be called in a transaction
+	ListBackorderedByItemForUpdate(ctx context.Context, itemID valueobject.ItemID) ([]entity.Purchase, error)
 }
@@ -0,0 +1,21 @@
+package repository
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type ReservationRepository interface {
+	AssignTx(txm TransactionManager)
+	Create(ctx context.Context, reservation *entity.Reservation) error
+	Updates(ctx context.Context, reservation *entity.Reservation, values map[string]interface{}) error
+	GetByID(ctx context.Context, reservationID valueobject.ReservationID) (entity.Reservation, error)
+	GetByIDForUpdate(ctx context.Context, reservationID valueobject.ReservationID) (entity.Reservation, error)
+	// ListExpired get the held reservations which are expired at the time
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]entity.Reservation, error)
+}
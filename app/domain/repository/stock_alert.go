@@ -0,0 +1,14 @@
+package repository
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+import (
+	"context"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+)
+
+// StockAlertNotifier deliver the stock alerts to the purchasing team
+type StockAlertNotifier interface {
+	NotifyLowStock(ctx context.Context, alert entity.LowStockAlert) error
+}
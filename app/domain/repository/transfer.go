@@ -0,0 +1,18 @@
+package repository
+
+//go:generate mockgen -destination=./mock/mock_$GOFILE -source=$GOFILE -package=mock
+
+import (
+	"context"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type TransferRepository interface {
+	AssignTx(txm TransactionManager)
+	Create(ctx context.Context, transfer *entity.Transfer) error
+	Updates(ctx context.Context, transfer *entity.Transfer, values map[string]interface{}) error
+	GetByID(ctx context.Context, transferID valueobject.TransferID) (entity.Transfer, error)
+	GetByIDForUpdate(ctx context.Context, transferID valueobject.TransferID) (entity.Transfer, error)
+}
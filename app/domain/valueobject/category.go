@@ -0,0 +1,3 @@
+package valueobject
+
+type CategoryID uint64
@@ -0,0 +1,3 @@
+package valueobject
+
+type IdempotencyRecordID uint64
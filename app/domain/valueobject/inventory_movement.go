@@ -0,0 +1,45 @@
+package valueobject
+
+type InventoryMovementID uint64
+
+// MovementReason the reason of a change of stock
+type MovementReason string
+
+const (
+	MovementReasonCreate     MovementReason = "create"
+	MovementReasonPurchase   MovementReason = "purchase"
+	MovementReasonRestock    MovementReason = "restock"
+	MovementReasonRefund     MovementReason = "refund"
+	MovementReasonAdjustment MovementReason = "adjustment"
+	MovementReasonReserve    MovementReason = "reserve"
+	MovementReasonRelease    MovementReason = "release"
+	// MovementReasonTransferOut and MovementReasonTransferIn the quantity leaves the current stock
+	// when a transfer is sent and comes back when the transfer is received
+	MovementReasonTransferOut MovementReason = "transfer_out"
+	MovementReasonTransferIn  MovementReason = "transfer_in"
+)
+
+// AdjustmentReasonCode the reason of a manual adjustment of stock
+type AdjustmentReasonCode string
+
+const (
+	AdjustmentReasonCodeDamaged AdjustmentReasonCode = "damaged"
+	AdjustmentReasonCodeLost    AdjustmentReasonCode = "lost"
+	AdjustmentReasonCodeFound   AdjustmentReasonCode = "found"
+	AdjustmentReasonCodeRecount AdjustmentReasonCode = "recount"
+	AdjustmentReasonCodeOther   AdjustmentReasonCode = "other"
+)
+
+// IsValid check the reason code is one of the known codes
+func (c AdjustmentReasonCode) IsValid() bool {
+	switch c {
+	case AdjustmentReasonCodeDamaged,
+		AdjustmentReasonCodeLost,
+		AdjustmentReasonCodeFound,
+		AdjustmentReasonCodeRecount,
+		AdjustmentReasonCodeOther:
+		return true
+	}
+
+	return false
+}
@@ -1,3 +1,53 @@
 package valueobject
 
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
 type ItemID uint64
+
+// ItemSortField the field which items can be sorted by
+type ItemSortField string
+
+const (
+	ItemSortFieldSellingPrice      ItemSortField = "selling_price"
+	ItemSortFieldCreatedAt         ItemSortField = "created_at"
+	ItemSortFieldCurrentStockValue ItemSortField = "current_stock_value"
+)
+
+// IsValid check the field is one of the sortable fields
+func (f ItemSortField) IsValid() bool {
+	switch f {
+	case ItemSortFieldSellingPrice, ItemSortFieldCreatedAt, ItemSortFieldCurrentStockValue:
+		return true
+	}
+
+	return false
+}
+
+type ItemSort struct {
+	Field      ItemSortField
+	Descending bool
+}
+
+// ItemFilter zero values are ignored, items are sorted by Sorts in order then by id
+type ItemFilter struct {
+	// MinSellingPrice and MaxSellingPrice are ignored when they are nil, 0 is a bound
+	MinSellingPrice *decimal.Decimal
+	MaxSellingPrice *decimal.Decimal
+	// InStockOnly only the items which current stock is greater than 0
+	InStockOnly bool
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	// ProductID only the variants of the product
+	ProductID ProductID
+	// CategoryIDs only the items which are assigned to one of the categories
+	CategoryIDs []CategoryID
+	// Tags only the items which have all the tags
+	Tags []string
+	// Attributes only the items which have all the attribute values
+	Attributes []ItemAttribute
+	Sorts      []ItemSort
+}
@@ -0,0 +1,7 @@
+package valueobject
+
+// ItemAttribute the value of an attribute key of items
+type ItemAttribute struct {
+	Key   string
+	Value string
+}
@@ -0,0 +1,41 @@
+package valueobject
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ItemSearchQuery the items which have a word starting with every term are matched
+type ItemSearchQuery struct {
+	Terms []string
+}
+
+// NewItemSearchQuery split q into the distinct lower case terms
+func NewItemSearchQuery(q string) ItemSearchQuery {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, word := range SplitSearchWords(q) {
+		if seen[word] {
+			continue
+		}
+
+		seen[word] = true
+		terms = append(terms, word)
+	}
+
+	return ItemSearchQuery{
+		Terms: terms,
+	}
+}
+
+// SplitSearchWords split text into the lower case words
+func SplitSearchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !IsSearchWordRune(r)
+	})
+}
+
+// IsSearchWordRune letters and digits make the words, the other characters separate them
+func IsSearchWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
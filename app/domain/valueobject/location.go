@@ -0,0 +1,27 @@
+package valueobject
+
+type LocationID uint64
+
+type LocationStockID uint64
+
+// AllocationStrategy the way to pick the locations which stock is taken from
+// when the location is not requested
+type AllocationStrategy string
+
+const (
+	// AllocationStrategyMostStock take from the location which has the most stock first,
+	// so the quantity is taken from as few locations as possible. It is the default strategy
+	AllocationStrategyMostStock AllocationStrategy = "most_stock"
+	// AllocationStrategyLocationOrder take from the locations in the order they were created
+	AllocationStrategyLocationOrder AllocationStrategy = "location_order"
+)
+
+// IsValid check the strategy is one of the known strategies
+func (s AllocationStrategy) IsValid() bool {
+	switch s {
+	case AllocationStrategyMostStock, AllocationStrategyLocationOrder:
+		return true
+	}
+
+	return false
+}
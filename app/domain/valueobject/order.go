@@ -0,0 +1,5 @@
+package valueobject
+
+type OrderID uint64
+
+type OrderLineID uint64
@@ -1,6 +1,22 @@
 package valueobject
 
+import "time"
+
+// PaginationRequest the rows are paged by the limit and offset of Page,
+// or by the (created_at, id) keyset after Cursor if it is set
 type PaginationRequest struct {
-	Page  int64
-	Limit int64
+	Page   int64
+	Limit  int64
+	Cursor *Cursor
+}
+
+// Cursor the keyset of the last row of the previous page, the zero cursor starts from the first row
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uint64
+}
+
+// IsZero check the cursor starts from the first row
+func (c Cursor) IsZero() bool {
+	return c.CreatedAt.IsZero() && c.ID == 0
 }
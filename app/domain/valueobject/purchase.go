@@ -1,3 +1,20 @@
 package valueobject
 
+import "time"
+
 type PurchaseID uint64
+
+// PurchaseStatus a backordered purchase waits for the restock of item, it is fulfilled by the restock
+type PurchaseStatus string
+
+const (
+	PurchaseStatusFulfilled   PurchaseStatus = "fulfilled"
+	PurchaseStatusBackordered PurchaseStatus = "backordered"
+)
+
+// PurchaseFilter zero values are ignored
+type PurchaseFilter struct {
+	ItemID      ItemID
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
@@ -0,0 +1,11 @@
+package valueobject
+
+type ReservationID uint64
+
+type ReservationStatus string
+
+const (
+	ReservationStatusHeld      ReservationStatus = "held"
+	ReservationStatusConfirmed ReservationStatus = "confirmed"
+	ReservationStatusReleased  ReservationStatus = "released"
+)
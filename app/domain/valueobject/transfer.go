@@ -0,0 +1,10 @@
+package valueobject
+
+type TransferID uint64
+
+type TransferStatus string
+
+const (
+	TransferStatusInTransit TransferStatus = "in_transit"
+	TransferStatusReceived  TransferStatus = "received"
+)
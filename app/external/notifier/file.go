@@ -0,0 +1,44 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+)
+
+// FileNotifierImpl append the stock alerts to a file, one JSON object per line
+type FileNotifierImpl struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileNotifierImpl(path string) repository.StockAlertNotifier {
+	return &FileNotifierImpl{
+		path: path,
+	}
+}
+
+func (n *FileNotifierImpl) NotifyLowStock(ctx context.Context, alert entity.LowStockAlert) error {
+	line, err := json.Marshal(newLowStockMessage(alert, time.Now()))
+	if err != nil {
+		return err
+	}
+
+	// the notifier is shared by the handlers, the lines of concurrent alerts must not be interleaved
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,62 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+func TestFileNotifierImpl_NotifyLowStock(t *testing.T) {
+	t.Run("#1: Append one line for each alert", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "alerts.log")
+		n := NewFileNotifierImpl(path)
+
+		for _, itemID := range []valueobject.ItemID{1, 2} {
+			err := n.NotifyLowStock(context.Background(), entity.LowStockAlert{
+				ItemID:            itemID,
+				CurrentStockValue: 2,
+				ReorderThreshold:  3,
+			})
+			if err != nil {
+				t.Errorf("n.NotifyLowStock() return an error:%v - want:nil", err)
+				return
+			}
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			t.Errorf("failed to open alert file:%v", err)
+			return
+		}
+		defer f.Close()
+
+		var got []lowStockMessage
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var msg lowStockMessage
+			if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+				t.Errorf("failed to decode alert line:%v", err)
+				return
+			}
+			got = append(got, msg)
+		}
+
+		want := []lowStockMessage{
+			{Event: "low_stock", ItemID: 1, CurrentStockValue: 2, ReorderThreshold: 3},
+			{Event: "low_stock", ItemID: 2, CurrentStockValue: 2, ReorderThreshold: 3},
+		}
+		if diff := cmp.Diff(got, want, cmpopts.IgnoreFields(lowStockMessage{}, "AlertedAt")); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
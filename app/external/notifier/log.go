@@ -0,0 +1,24 @@
+package notifier
+
+import (
+	"context"
+	"log"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+)
+
+// LogNotifierImpl write the stock alerts to the server log
+type LogNotifierImpl struct{}
+
+func NewLogNotifierImpl() repository.StockAlertNotifier {
+	return &LogNotifierImpl{}
+}
+
+func (n *LogNotifierImpl) NotifyLowStock(ctx context.Context, alert entity.LowStockAlert) error {
+	log.Printf(
+		"low stock alert - item:%d - current quantity:%d - reorder threshold:%d\n",
+		alert.ItemID, alert.CurrentStockValue, alert.ReorderThreshold,
+	)
+	return nil
+}
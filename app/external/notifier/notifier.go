@@ -0,0 +1,52 @@
+package notifier
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tuanna7593/gosample/app/config"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+)
+
+const (
+	TypeLog     = "log"
+	TypeWebhook = "webhook"
+	TypeFile    = "file"
+)
+
+// defaultWebhookTimeout used when the webhook timeout is not configured
+const defaultWebhookTimeout = 5 * time.Second
+
+// NewStockAlertNotifier create the notifier of the configured type,
+// the alerts are written to the log when the type is empty
+func NewStockAlertNotifier(cfg config.Notifier) (repository.StockAlertNotifier, error) {
+	switch cfg.Type {
+	case "", TypeLog:
+		return NewLogNotifierImpl(), nil
+	case TypeWebhook:
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("webhook_url is required by the %s notifier", TypeWebhook)
+		}
+		timeout := cfg.WebhookTimeout * time.Second
+		if timeout <= 0 {
+			timeout = defaultWebhookTimeout
+		}
+		return NewWebhookNotifierImpl(cfg.WebhookURL, timeout), nil
+	case TypeFile:
+		if cfg.FilePath == "" {
+			return nil, fmt.Errorf("file_path is required by the %s notifier", TypeFile)
+		}
+		return NewFileNotifierImpl(cfg.FilePath), nil
+	}
+
+	return nil, fmt.Errorf("unknown notifier type: %s", cfg.Type)
+}
+
+// lowStockMessage the body of a low stock alert which is sent by the webhook and file notifiers
+type lowStockMessage struct {
+	Event             string `json:"event"`
+	ItemID            uint64 `json:"item_id"`
+	CurrentStockValue uint64 `json:"current_stock_value"`
+	ReorderThreshold  uint64 `json:"reorder_threshold"`
+	AlertedAt         int64  `json:"alerted_at"`
+}
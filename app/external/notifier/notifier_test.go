@@ -0,0 +1,51 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/tuanna7593/gosample/app/config"
+)
+
+func TestNewStockAlertNotifier(t *testing.T) {
+	t.Run("#1: Log notifier by default", func(t *testing.T) {
+		t.Parallel()
+		got, err := NewStockAlertNotifier(config.Notifier{})
+		if err != nil {
+			t.Errorf("NewStockAlertNotifier() return an error:%v - want:nil", err)
+			return
+		}
+
+		if _, ok := got.(*LogNotifierImpl); !ok {
+			t.Errorf("NewStockAlertNotifier() return %T - want:*LogNotifierImpl", got)
+		}
+	})
+
+	t.Run("#2: Webhook notifier without url", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewStockAlertNotifier(config.Notifier{Type: TypeWebhook})
+		if err == nil {
+			t.Error("NewStockAlertNotifier() return nil - want an error")
+		}
+	})
+
+	t.Run("#3: File notifier", func(t *testing.T) {
+		t.Parallel()
+		got, err := NewStockAlertNotifier(config.Notifier{Type: TypeFile, FilePath: "alerts.log"})
+		if err != nil {
+			t.Errorf("NewStockAlertNotifier() return an error:%v - want:nil", err)
+			return
+		}
+
+		if _, ok := got.(*FileNotifierImpl); !ok {
+			t.Errorf("NewStockAlertNotifier() return %T - want:*FileNotifierImpl", got)
+		}
+	})
+
+	t.Run("#4: Unknown notifier", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewStockAlertNotifier(config.Notifier{Type: "email"})
+		if err == nil {
+			t.Error("NewStockAlertNotifier() return nil - want an error")
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+)
+
+// WebhookNotifierImpl post the stock alerts as JSON to a webhook url
+type WebhookNotifierImpl struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifierImpl(url string, timeout time.Duration) repository.StockAlertNotifier {
+	return &WebhookNotifierImpl{
+		url: url,
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+func (n *WebhookNotifierImpl) NotifyLowStock(ctx context.Context, alert entity.LowStockAlert) error {
+	body, err := json.Marshal(newLowStockMessage(alert, time.Now()))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post low stock alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("the webhook responded low stock alert with status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func newLowStockMessage(alert entity.LowStockAlert, alertedAt time.Time) lowStockMessage {
+	return lowStockMessage{
+		Event:             "low_stock",
+		ItemID:            uint64(alert.ItemID),
+		CurrentStockValue: alert.CurrentStockValue,
+		ReorderThreshold:  alert.ReorderThreshold,
+		AlertedAt:         alertedAt.Unix(),
+	}
+}
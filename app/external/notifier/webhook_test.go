@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+func TestWebhookNotifierImpl_NotifyLowStock(t *testing.T) {
+	alert := entity.LowStockAlert{
+		ItemID:            valueobject.ItemID(1),
+		CurrentStockValue: 2,
+		ReorderThreshold:  3,
+	}
+
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		var got lowStockMessage
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Errorf("failed to decode webhook body:%v", err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		n := NewWebhookNotifierImpl(server.URL, time.Second)
+		err := n.NotifyLowStock(context.Background(), alert)
+		if err != nil {
+			t.Errorf("n.NotifyLowStock() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := lowStockMessage{
+			Event:             "low_stock",
+			ItemID:            1,
+			CurrentStockValue: 2,
+			ReorderThreshold:  3,
+		}
+		if diff := cmp.Diff(got, want, cmpopts.IgnoreFields(lowStockMessage{}, "AlertedAt")); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Webhook responds an error status", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		n := NewWebhookNotifierImpl(server.URL, time.Second)
+		err := n.NotifyLowStock(context.Background(), alert)
+		if err == nil {
+			t.Error("n.NotifyLowStock() return nil - want an error")
+		}
+	})
+}
@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+const (
+	// the words of name are more relevant than the words of description
+	nameWordWeight        = 2
+	descriptionWordWeight = 1
+)
+
+// ItemSearchIndex an in-process inverted index of items which implements the item search repository,
+// it ranks the items like the MySQL FULLTEXT search without a database so it's used in tests
+type ItemSearchIndex struct {
+	mu    sync.RWMutex
+	items map[valueobject.ItemID]entity.Item
+	// postings the weight of every word in the items which contain it
+	postings map[string]map[valueobject.ItemID]float64
+	// words the sorted words of postings, the words starting with a term are next to each other
+	words []string
+}
+
+func NewItemSearchIndex() *ItemSearchIndex {
+	return &ItemSearchIndex{
+		items:    make(map[valueobject.ItemID]entity.Item),
+		postings: make(map[string]map[valueobject.ItemID]float64),
+	}
+}
+
+// Index add the item to the index, the previous version of item is replaced
+func (idx *ItemSearchIndex) Index(item entity.Item) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(item.ID)
+	idx.items[item.ID] = item
+	idx.addWords(item.ID, item.Name, nameWordWeight)
+	idx.addWords(item.ID, item.Description, descriptionWordWeight)
+}
+
+// Remove remove the item from the index
+func (idx *ItemSearchIndex) Remove(itemID valueobject.ItemID) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(itemID)
+}
+
+// Search score the items by the weights of the words starting with the terms,
+// the cursor of pagination is ignored like the MySQL implementation
+func (idx *ItemSearchIndex) Search(
+	ctx context.Context,
+	query valueobject.ItemSearchQuery,
+	pagination valueobject.PaginationRequest,
+) ([]entity.ItemSearchHit, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	if len(query.Terms) == 0 {
+		return nil, nil
+	}
+
+	// the items must match every term
+	var scores map[valueobject.ItemID]float64
+	for _, term := range query.Terms {
+		termScores := idx.matchPrefix(term)
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+
+		for itemID, score := range scores {
+			termScore, ok := termScores[itemID]
+			if !ok {
+				delete(scores, itemID)
+				continue
+			}
+			scores[itemID] = score + termScore
+		}
+	}
+
+	hits := make([]entity.ItemSearchHit, 0, len(scores))
+	for itemID, score := range scores {
+		item := idx.items[itemID]
+		if item.IsArchived() {
+			continue
+		}
+
+		hits = append(hits, entity.ItemSearchHit{
+			Item:  item,
+			Score: score,
+		})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Item.ID < hits[j].Item.ID
+	})
+
+	if pagination.Page < 1 || pagination.Limit < 1 {
+		return hits, nil
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	if offset >= int64(len(hits)) {
+		return []entity.ItemSearchHit{}, nil
+	}
+	end := offset + pagination.Limit
+	if end > int64(len(hits)) {
+		end = int64(len(hits))
+	}
+
+	return hits[offset:end], nil
+}
+
+// matchPrefix sum the weights of the words starting with term by item
+func (idx *ItemSearchIndex) matchPrefix(term string) map[valueobject.ItemID]float64 {
+	scores := make(map[valueobject.ItemID]float64)
+	for i := sort.SearchStrings(idx.words, term); i < len(idx.words); i++ {
+		if !strings.HasPrefix(idx.words[i], term) {
+			break
+		}
+
+		for itemID, weight := range idx.postings[idx.words[i]] {
+			scores[itemID] += weight
+		}
+	}
+
+	return scores
+}
+
+func (idx *ItemSearchIndex) addWords(itemID valueobject.ItemID, text string, weight float64) {
+	for _, word := range valueobject.SplitSearchWords(text) {
+		posting, ok := idx.postings[word]
+		if !ok {
+			posting = make(map[valueobject.ItemID]float64)
+			idx.postings[word] = posting
+
+			i := sort.SearchStrings(idx.words, word)
+			idx.words = append(idx.words, "")
+			copy(idx.words[i+1:], idx.words[i:])
+			idx.words[i] = word
+		}
+		posting[itemID] += weight
+	}
+}
+
+func (idx *ItemSearchIndex) remove(itemID valueobject.ItemID) {
+	item, ok := idx.items[itemID]
+	if !ok {
+		return
+	}
+
+	delete(idx.items, itemID)
+	for _, word := range valueobject.SplitSearchWords(item.Name + " " + item.Description) {
+		posting, ok := idx.postings[word]
+		if !ok {
+			continue
+		}
+
+		delete(posting, itemID)
+		if len(posting) > 0 {
+			continue
+		}
+
+		delete(idx.postings, word)
+		i := sort.SearchStrings(idx.words, word)
+		idx.words = append(idx.words[:i], idx.words[i+1:]...)
+	}
+}
@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+func newTestItemSearchIndex() *ItemSearchIndex {
+	deletedAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+	idx := NewItemSearchIndex()
+	idx.Index(entity.Item{ID: 1, Name: "T-shirt", Description: "Cotton shirt"})
+	idx.Index(entity.Item{ID: 2, Name: "Cotton socks", Description: "Warm socks"})
+	idx.Index(entity.Item{ID: 3, Name: "Shirt", Description: "Linen"})
+	idx.Index(entity.Item{ID: 4, Name: "Shirt", Description: "Archived", DeletedAt: &deletedAt})
+	return idx
+}
+
+func hitIDs(hits []entity.ItemSearchHit) []valueobject.ItemID {
+	ids := make([]valueobject.ItemID, len(hits))
+	for i := range hits {
+		ids[i] = hits[i].Item.ID
+	}
+	return ids
+}
+
+func TestItemSearchIndex_Search(t *testing.T) {
+	t.Run("#1: Rank the items by the weights of the matched words", func(t *testing.T) {
+		t.Parallel()
+		idx := newTestItemSearchIndex()
+		got, err := idx.Search(
+			context.Background(),
+			valueobject.NewItemSearchQuery("shirt"),
+			valueobject.PaginationRequest{Page: 1, Limit: 10},
+		)
+		if err != nil {
+			t.Errorf("idx.Search() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := []entity.ItemSearchHit{
+			{Item: entity.Item{ID: 1, Name: "T-shirt", Description: "Cotton shirt"}, Score: 3},
+			{Item: entity.Item{ID: 3, Name: "Shirt", Description: "Linen"}, Score: 2},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Every term is required as a prefix", func(t *testing.T) {
+		t.Parallel()
+		idx := newTestItemSearchIndex()
+		got, err := idx.Search(
+			context.Background(),
+			valueobject.NewItemSearchQuery("COT sh"),
+			valueobject.PaginationRequest{Page: 1, Limit: 10},
+		)
+		if err != nil {
+			t.Errorf("idx.Search() return an error:%v - want:nil", err)
+			return
+		}
+
+		if diff := cmp.Diff(hitIDs(got), []valueobject.ItemID{1}); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#3: Page the items", func(t *testing.T) {
+		t.Parallel()
+		idx := newTestItemSearchIndex()
+		got, err := idx.Search(
+			context.Background(),
+			valueobject.NewItemSearchQuery("s"),
+			valueobject.PaginationRequest{Page: 2, Limit: 2},
+		)
+		if err != nil {
+			t.Errorf("idx.Search() return an error:%v - want:nil", err)
+			return
+		}
+
+		if diff := cmp.Diff(hitIDs(got), []valueobject.ItemID{3}); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#4: Removed and reindexed items", func(t *testing.T) {
+		t.Parallel()
+		idx := newTestItemSearchIndex()
+		idx.Remove(1)
+		idx.Index(entity.Item{ID: 3, Name: "Sweater", Description: "Linen"})
+		got, err := idx.Search(
+			context.Background(),
+			valueobject.NewItemSearchQuery("shirt"),
+			valueobject.PaginationRequest{Page: 1, Limit: 10},
+		)
+		if err != nil {
+			t.Errorf("idx.Search() return an error:%v - want:nil", err)
+			return
+		}
+
+		if len(got) != 0 {
+			t.Errorf("idx.Search() return the items:%v - want none", hitIDs(got))
+		}
+	})
+}
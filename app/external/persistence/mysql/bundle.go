@@ -0,0 +1,42 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// BundleRepositoryImpl bundle repository implementation
+type BundleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewBundleRepositoryImpl() repository.BundleRepository {
+	return &BundleRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *BundleRepositoryImpl) Create(ctx context.Context, bundle *entity.Bundle) error {
+	return r.db.Create(bundle).Error
+}
+
+func (r *BundleRepositoryImpl) GetByID(ctx context.Context, bundleID valueobject.BundleID) (entity.Bundle, error) {
+	var bundle entity.Bundle
+	err := r.db.Preload("Components", func(db *gorm.DB) *gorm.DB {
+		return db.Order("`bundle_components`.item_id")
+	}).Take(&bundle, "`bundles`.id = ?", bundleID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Bundle{}, nil
+		}
+		return entity.Bundle{}, err
+	}
+
+	return bundle, nil
+}
@@ -0,0 +1,131 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/utils/testsupport"
+)
+
+func TestBundleRepositoryImpl_Create(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		bundle := entity.Bundle{
+			Name: "Starter kit",
+			Components: []entity.BundleComponent{
+				{ItemID: valueobject.ItemID(2), Quantity: 1},
+				{ItemID: valueobject.ItemID(3), Quantity: 2},
+			},
+		}
+
+		insertBundleQuery := regexp.QuoteMeta("INSERT INTO `bundles` (`created_at`,`name`) VALUES (?,?)")
+		insertComponentQuery := regexp.QuoteMeta("INSERT INTO `bundle_components` (`bundle_id`,`item_id`,`quantity`) VALUES (?,?,?),(?,?,?)")
+		mock.ExpectBegin()
+		mock.ExpectExec(insertBundleQuery).WillReturnResult(
+			sqlmock.NewResult(1, 1),
+		)
+		mock.ExpectExec(insertComponentQuery).
+			WithArgs(uint64(1), uint64(2), uint64(1), uint64(1), uint64(3), uint64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		repo := BundleRepositoryImpl{
+			db: db,
+		}
+
+		err = repo.Create(context.Background(), &bundle)
+		if err != nil {
+			t.Errorf("repo.Create() return an error:%v - want:nil", err)
+			return
+		}
+
+		for _, component := range bundle.Components {
+			if component.BundleID != bundle.ID {
+				t.Errorf("BundleID of component:%d - want:%d", component.BundleID, bundle.ID)
+			}
+		}
+	})
+}
+
+func TestBundleRepositoryImpl_GetByID(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		createdAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		bundleQuery := regexp.QuoteMeta("SELECT * FROM `bundles` WHERE `bundles`.id = ? LIMIT 1")
+		mock.ExpectQuery(bundleQuery).WithArgs(uint64(1)).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "name"}).
+				AddRow(1, createdAt, "Starter kit"),
+		)
+		componentQuery := regexp.QuoteMeta("SELECT * FROM `bundle_components` WHERE `bundle_components`.`bundle_id` = ? ORDER BY `bundle_components`.item_id")
+		mock.ExpectQuery(componentQuery).WithArgs(uint64(1)).WillReturnRows(
+			sqlmock.NewRows([]string{"bundle_id", "item_id", "quantity"}).
+				AddRow(1, 2, 1).
+				AddRow(1, 3, 2),
+		)
+
+		repo := BundleRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByID(context.Background(), valueobject.BundleID(1))
+		if err != nil {
+			t.Errorf("repo.GetByID() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := entity.Bundle{
+			ID:        1,
+			CreatedAt: createdAt,
+			Name:      "Starter kit",
+			Components: []entity.BundleComponent{
+				{BundleID: 1, ItemID: 2, Quantity: 1},
+				{BundleID: 1, ItemID: 3, Quantity: 2},
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Not found bundle", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `bundles` WHERE `bundles`.id = ? LIMIT 1")
+		mock.ExpectQuery(query).WithArgs(uint64(1)).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "name"}),
+		)
+
+		repo := BundleRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByID(context.Background(), valueobject.BundleID(1))
+		if err != nil {
+			t.Errorf("repo.GetByID() return an error:%v - want:nil", err)
+			return
+		}
+
+		if diff := cmp.Diff(got, entity.Bundle{}); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
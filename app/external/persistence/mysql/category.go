@@ -0,0 +1,69 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// CategoryRepositoryImpl category repository implementation
+type CategoryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepositoryImpl() repository.CategoryRepository {
+	return &CategoryRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *CategoryRepositoryImpl) AssignTx(txm repository.TransactionManager) {
+	tx := txm.GetTx().(*gorm.DB)
+	r.db = tx
+}
+
+func (r *CategoryRepositoryImpl) Create(ctx context.Context, category *entity.Category) error {
+	return r.db.Create(category).Error
+}
+
+func (r *CategoryRepositoryImpl) Updates(ctx context.Context, category *entity.Category, values map[string]interface{}) error {
+	return r.db.Model(category).Updates(values).Error
+}
+
+// Delete the assignments of category are deleted by the foreign key on cascade
+func (r *CategoryRepositoryImpl) Delete(ctx context.Context, category *entity.Category) error {
+	return r.db.Delete(category).Error
+}
+
+func (r *CategoryRepositoryImpl) GetByID(ctx context.Context, categoryID valueobject.CategoryID) (entity.Category, error) {
+	var category entity.Category
+	err := r.db.Take(&category, "`categories`.id = ?", categoryID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Category{}, nil
+		}
+		return entity.Category{}, err
+	}
+
+	return category, nil
+}
+
+func (r *CategoryRepositoryImpl) List(ctx context.Context) ([]entity.Category, error) {
+	var categories []entity.Category
+	err := r.db.Order("`categories`.id").Find(&categories).Error
+	return categories, err
+}
+
+// ListForUpdate lock all the categories until the transaction ends,
+// so the concurrent moves are checked against the taxonomy one by one
+func (r *CategoryRepositoryImpl) ListForUpdate(ctx context.Context) ([]entity.Category, error) {
+	var categories []entity.Category
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Order("`categories`.id").Find(&categories).Error
+	return categories, err
+}
@@ -0,0 +1,103 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/utils/testsupport"
+)
+
+func TestCategoryRepositoryImpl_List(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		createdAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		query := regexp.QuoteMeta("SELECT * FROM `categories` ORDER BY `categories`.id")
+		mock.ExpectQuery(query).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "name", "parent_id"}).
+				AddRow(1, createdAt, "Clothes", nil).
+				AddRow(2, createdAt, "Shirts", 1),
+		)
+
+		repo := CategoryRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.List(context.Background())
+		if err != nil {
+			t.Errorf("repo.List() return an error:%v - want:nil", err)
+			return
+		}
+
+		parentID := valueobject.CategoryID(1)
+		want := []entity.Category{
+			{ID: 1, CreatedAt: createdAt, Name: "Clothes"},
+			{ID: 2, CreatedAt: createdAt, Name: "Shirts", ParentID: &parentID},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestCategoryRepositoryImpl_ListForUpdate(t *testing.T) {
+	t.Run("#1: Lock the categories inside the assigned transaction", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		// the row locks are held until the transaction ends so the query must be sent between begin and commit
+		createdAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		query := regexp.QuoteMeta("SELECT * FROM `categories` ORDER BY `categories`.id FOR UPDATE")
+		mock.ExpectBegin()
+		mock.ExpectQuery(query).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "name", "parent_id"}).
+				AddRow(1, createdAt, "Clothes", nil).
+				AddRow(2, createdAt, "Shirts", 1),
+		)
+		mock.ExpectCommit()
+
+		txm := &TransactionManagerImpl{
+			db: db.Begin(),
+		}
+		repo := CategoryRepositoryImpl{
+			db: db,
+		}
+		repo.AssignTx(txm)
+		got, err := repo.ListForUpdate(context.Background())
+		if err != nil {
+			t.Errorf("repo.ListForUpdate() return an error:%v - want:nil", err)
+			return
+		}
+
+		if err := txm.Commit(); err != nil {
+			t.Errorf("txm.Commit() return an error:%v - want:nil", err)
+			return
+		}
+
+		parentID := valueobject.CategoryID(1)
+		want := []entity.Category{
+			{ID: 1, CreatedAt: createdAt, Name: "Clothes"},
+			{ID: 2, CreatedAt: createdAt, Name: "Shirts", ParentID: &parentID},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
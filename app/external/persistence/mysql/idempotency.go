@@ -0,0 +1,91 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+)
+
+// IdempotencyRepositoryImpl idempotency repository implementation
+type IdempotencyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepositoryImpl() repository.IdempotencyRepository {
+	return &IdempotencyRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *IdempotencyRepositoryImpl) AssignTx(txm repository.TransactionManager) {
+	tx := txm.GetTx().(*gorm.DB)
+	r.db = tx
+}
+
+// CreateIfNotExists rely on the unique index of key, so only one of the concurrent requests can create the record
+func (r *IdempotencyRepositoryImpl) CreateIfNotExists(ctx context.Context, record *entity.IdempotencyRecord) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *IdempotencyRepositoryImpl) GetByKey(ctx context.Context, key string) (entity.IdempotencyRecord, error) {
+	var record entity.IdempotencyRecord
+	err := r.db.Take(&record, "`idempotency_records`.`key` = ?", key).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.IdempotencyRecord{}, nil
+		}
+		return entity.IdempotencyRecord{}, err
+	}
+
+	return record, nil
+}
+
+// TakeOverExpired the condition of expires_at is checked by the update, so only one of the concurrent requests can take it over
+func (r *IdempotencyRepositoryImpl) TakeOverExpired(
+	ctx context.Context,
+	record *entity.IdempotencyRecord,
+	now time.Time,
+) (bool, error) {
+	result := r.db.Model(&entity.IdempotencyRecord{}).
+		Where("`idempotency_records`.`key` = ? AND `idempotency_records`.expires_at <= ?", record.Key, now).
+		Updates(map[string]interface{}{
+			"created_at":       now,
+			"request_hash":     record.RequestHash,
+			"response_status":  0,
+			"response_headers": "",
+			"response_body":    "",
+			"expires_at":       record.ExpiresAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *IdempotencyRepositoryImpl) Updates(ctx context.Context, record *entity.IdempotencyRecord, values map[string]interface{}) error {
+	return r.db.Model(record).Updates(values).Error
+}
+
+func (r *IdempotencyRepositoryImpl) Delete(ctx context.Context, record *entity.IdempotencyRecord) error {
+	return r.db.Delete(record).Error
+}
+
+func (r *IdempotencyRepositoryImpl) DeleteExpired(ctx context.Context, now time.Time, limit int) (int64, error) {
+	result := r.db.
+		Where("`idempotency_records`.expires_at <= ?", now).
+		Limit(limit).
+		Delete(&entity.IdempotencyRecord{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,304 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"gorm.io/gorm"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/utils/testsupport"
+)
+
+func TestIdempotencyRepositoryImpl_CreateIfNotExists(t *testing.T) {
+	t.Run("#1: Created", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		record := entity.IdempotencyRecord{
+			Key:         "key-1",
+			RequestHash: "hash",
+		}
+
+		insertQuery := regexp.QuoteMeta(
+			"INSERT INTO `idempotency_records` (`created_at`,`key`,`request_hash`,`response_status`,`response_headers`,`response_body`,`expires_at`) " +
+				"VALUES (?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `id`=`id`",
+		)
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		repo := IdempotencyRepositoryImpl{
+			db: db,
+		}
+		created, err := repo.CreateIfNotExists(context.Background(), &record)
+		if err != nil {
+			t.Errorf("repo.CreateIfNotExists() return an error:%v - want:nil", err)
+			return
+		}
+
+		if !created {
+			t.Error("repo.CreateIfNotExists() return false - want:true")
+		}
+	})
+
+	t.Run("#2: Key has been used", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		record := entity.IdempotencyRecord{
+			Key:         "key-1",
+			RequestHash: "hash",
+		}
+
+		insertQuery := regexp.QuoteMeta("INSERT INTO `idempotency_records`")
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		repo := IdempotencyRepositoryImpl{
+			db: db,
+		}
+		created, err := repo.CreateIfNotExists(context.Background(), &record)
+		if err != nil {
+			t.Errorf("repo.CreateIfNotExists() return an error:%v - want:nil", err)
+			return
+		}
+
+		if created {
+			t.Error("repo.CreateIfNotExists() return true - want:false")
+		}
+	})
+
+	t.Run("#3: Failed to create", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		record := entity.IdempotencyRecord{
+			Key:         "key-1",
+			RequestHash: "hash",
+		}
+
+		wannaErr := errors.New("cannot connect db")
+		insertQuery := regexp.QuoteMeta("INSERT INTO `idempotency_records`")
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).WillReturnError(wannaErr)
+		mock.ExpectRollback()
+
+		repo := IdempotencyRepositoryImpl{
+			db: db,
+		}
+		_, err = repo.CreateIfNotExists(context.Background(), &record)
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("repo.CreateIfNotExists() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+}
+
+func TestIdempotencyRepositoryImpl_GetByKey(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `idempotency_records` WHERE `idempotency_records`.`key` = ?")
+		mock.ExpectQuery(query).WithArgs("key-1").WillReturnRows(
+			sqlmock.NewRows([]string{
+				"id", "created_at", "key", "request_hash", "response_status", "response_headers", "response_body", "expires_at",
+			}).AddRow(
+				1, time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local), "key-1", "hash", 201,
+				`{"Etag":["\"1\""]}`, `{"id":1}`, time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local),
+			),
+		)
+
+		repo := IdempotencyRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByKey(context.Background(), "key-1")
+		if err != nil {
+			t.Errorf("repo.GetByKey() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := entity.IdempotencyRecord{
+			ID:              valueobject.IdempotencyRecordID(1),
+			CreatedAt:       time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			Key:             "key-1",
+			RequestHash:     "hash",
+			ResponseStatus:  201,
+			ResponseHeaders: `{"Etag":["\"1\""]}`,
+			ResponseBody:    `{"id":1}`,
+			ExpiresAt:       time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local),
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Not found record", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `idempotency_records` WHERE `idempotency_records`.`key` = ?")
+		mock.ExpectQuery(query).WillReturnError(gorm.ErrRecordNotFound)
+
+		repo := IdempotencyRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByKey(context.Background(), "key-1")
+		if err != nil {
+			t.Errorf("repo.GetByKey() return an error:%v - want:nil", err)
+			return
+		}
+
+		var want entity.IdempotencyRecord
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestIdempotencyRepositoryImpl_TakeOverExpired(t *testing.T) {
+	now := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+	record := entity.IdempotencyRecord{
+		Key:         "key-1",
+		RequestHash: "hash",
+		ExpiresAt:   now.Add(5 * time.Minute),
+	}
+	updateQuery := regexp.QuoteMeta(
+		"UPDATE `idempotency_records` SET `created_at`=?,`expires_at`=?,`request_hash`=?," +
+			"`response_body`=?,`response_headers`=?,`response_status`=? " +
+			"WHERE `idempotency_records`.`key` = ? AND `idempotency_records`.expires_at <= ?",
+	)
+
+	t.Run("#1: Taken over", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateQuery).
+			WithArgs(now, record.ExpiresAt, "hash", "", "", 0, "key-1", now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		repo := IdempotencyRepositoryImpl{
+			db: db,
+		}
+		takenOver, err := repo.TakeOverExpired(context.Background(), &record, now)
+		if err != nil {
+			t.Errorf("repo.TakeOverExpired() return an error:%v - want:nil", err)
+			return
+		}
+
+		if !takenOver {
+			t.Error("repo.TakeOverExpired() return false - want:true")
+		}
+	})
+
+	t.Run("#2: Record is not expired", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		repo := IdempotencyRepositoryImpl{
+			db: db,
+		}
+		takenOver, err := repo.TakeOverExpired(context.Background(), &record, now)
+		if err != nil {
+			t.Errorf("repo.TakeOverExpired() return an error:%v - want:nil", err)
+			return
+		}
+
+		if takenOver {
+			t.Error("repo.TakeOverExpired() return true - want:false")
+		}
+	})
+}
+
+func TestIdempotencyRepositoryImpl_DeleteExpired(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		now := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		deleteQuery := regexp.QuoteMeta(
+			"DELETE FROM `idempotency_records` WHERE `idempotency_records`.expires_at <= ? LIMIT 100",
+		)
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteQuery).WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		repo := IdempotencyRepositoryImpl{
+			db: db,
+		}
+		deleted, err := repo.DeleteExpired(context.Background(), now, 100)
+		if err != nil {
+			t.Errorf("repo.DeleteExpired() return an error:%v - want:nil", err)
+			return
+		}
+
+		if deleted != 2 {
+			t.Errorf("repo.DeleteExpired() return:%d - want:2", deleted)
+		}
+	})
+}
+
+func TestIdempotencyRepositoryImpl_Delete(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		record := entity.IdempotencyRecord{
+			ID:  valueobject.IdempotencyRecordID(1),
+			Key: "key-1",
+		}
+
+		deleteQuery := regexp.QuoteMeta("DELETE FROM `idempotency_records` WHERE `idempotency_records`.`id` = ?")
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteQuery).WithArgs(uint64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		repo := IdempotencyRepositoryImpl{
+			db: db,
+		}
+		err = repo.Delete(context.Background(), &record)
+		if err != nil {
+			t.Errorf("repo.Delete() return an error:%v - want:nil", err)
+		}
+	})
+}
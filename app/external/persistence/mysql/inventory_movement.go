@@ -0,0 +1,53 @@
+package mysql
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// InventoryMovementRepositoryImpl inventory movement repository implementation
+type InventoryMovementRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewInventoryMovementRepositoryImpl() repository.InventoryMovementRepository {
+	return &InventoryMovementRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *InventoryMovementRepositoryImpl) AssignTx(txm repository.TransactionManager) {
+	tx := txm.GetTx().(*gorm.DB)
+	r.db = tx
+}
+
+func (r *InventoryMovementRepositoryImpl) Create(ctx context.Context, movement *entity.InventoryMovement) error {
+	return r.db.Create(movement).Error
+}
+
+func (r *InventoryMovementRepositoryImpl) ListByItem(
+	ctx context.Context,
+	itemID valueobject.ItemID,
+	pagination valueobject.PaginationRequest,
+) ([]entity.InventoryMovement, error) {
+	var movements []entity.InventoryMovement
+	err := r.db.
+		Scopes(Paginate(pagination)).
+		Where("`inventory_movements`.item_id = ?", itemID).
+		Order("`inventory_movements`.created_at DESC, `inventory_movements`.id DESC").
+		Find(&movements).Error
+	return movements, err
+}
+
+func (r *InventoryMovementRepositoryImpl) CountByItem(ctx context.Context, itemID valueobject.ItemID) (int64, error) {
+	var total int64
+	err := r.db.Model(&entity.InventoryMovement{}).
+		Where("`inventory_movements`.item_id = ?", itemID).
+		Count(&total).Error
+	return total, err
+}
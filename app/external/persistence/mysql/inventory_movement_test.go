@@ -0,0 +1,117 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/utils/testsupport"
+)
+
+func TestInventoryMovementRepositoryImpl_Create(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		movement := entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonPurchase,
+			Delta:  -2,
+		}
+
+		insertQuery := regexp.QuoteMeta("INSERT INTO `inventory_movements` (`created_at`,`item_id`,`reason`,`delta`,`reason_code`,`actor`,`note`) VALUES (?,?,?,?,?,?,?)")
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).WithArgs(sqlmock.AnyArg(), uint64(1), "purchase", int64(-2), "", "", "").WillReturnResult(
+			sqlmock.NewResult(1, 1),
+		)
+		mock.ExpectCommit()
+
+		repo := InventoryMovementRepositoryImpl{
+			db: db,
+		}
+
+		err = repo.Create(context.Background(), &movement)
+		if err != nil {
+			t.Errorf("repo.Create() return an error:%v - want:nil", err)
+			return
+		}
+
+		if movement.ID == 0 {
+			t.Errorf("ID of a new InventoryMovement must be different zero:%d", movement.ID)
+		}
+	})
+}
+
+func TestInventoryMovementRepositoryImpl_ListByItem(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		movedAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		query := regexp.QuoteMeta("SELECT * FROM `inventory_movements` WHERE `inventory_movements`.item_id = ? ORDER BY `inventory_movements`.created_at DESC, `inventory_movements`.id DESC LIMIT 5 OFFSET 5")
+		mock.ExpectQuery(query).WithArgs(uint64(1)).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "item_id", "reason", "delta"}).
+				AddRow(2, movedAt, 1, "restock", 5),
+		)
+
+		repo := InventoryMovementRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.ListByItem(context.Background(), valueobject.ItemID(1), valueobject.PaginationRequest{Page: 2, Limit: 5})
+		if err != nil {
+			t.Errorf("repo.ListByItem() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := []entity.InventoryMovement{
+			{
+				ID:        2,
+				CreatedAt: movedAt,
+				ItemID:    1,
+				Reason:    valueobject.MovementReasonRestock,
+				Delta:     5,
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestInventoryMovementRepositoryImpl_CountByItem(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT count(*) FROM `inventory_movements` WHERE `inventory_movements`.item_id = ?")
+		mock.ExpectQuery(query).WithArgs(uint64(1)).WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(7))
+
+		repo := InventoryMovementRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.CountByItem(context.Background(), valueobject.ItemID(1))
+		if err != nil {
+			t.Errorf("repo.CountByItem() return an error:%v - want:nil", err)
+			return
+		}
+
+		if got != 7 {
+			t.Errorf("repo.CountByItem() return:%d - want:7", got)
+		}
+	})
+}
@@ -3,8 +3,12 @@ package mysql
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/tuanna7593/gosample/app/domain/entity"
 	"github.com/tuanna7593/gosample/app/domain/repository"
@@ -27,23 +31,186 @@ func (r *ItemRepositoryImpl) AssignTx(txm repository.TransactionManager) {
 	r.db = tx
 }
 
+// Create create an item, the unique index of sku is checked by the insert
+// so the sku which is used by a concurrent request is also reported as ErrSKUExists
 func (r *ItemRepositoryImpl) Create(ctx context.Context, item *entity.Item) error {
-	return r.db.Create(item).Error
+	err := r.db.Create(item).Error
+	if isDuplicateEntry(err, "uq_items_sku") {
+		return repository.ErrSKUExists
+	}
+
+	return err
 }
 
+// Updates update the item and increase its version
 func (r *ItemRepositoryImpl) Updates(ctx context.Context, item *entity.Item, values map[string]interface{}) error {
-	return r.db.Model(item).Updates(values).Error
+	versionedValues := make(map[string]interface{}, len(values)+1)
+	for k, v := range values {
+		versionedValues[k] = v
+	}
+	versionedValues["version"] = gorm.Expr("`items`.version + 1")
+
+	err := r.db.Model(item).Updates(versionedValues).Error
+	if err != nil {
+		return err
+	}
+
+	item.Version++
+	return nil
+}
+
+// itemSortColumns the whitelist of the columns which items can be sorted by
+var itemSortColumns = map[valueobject.ItemSortField]string{
+	valueobject.ItemSortFieldSellingPrice:      "`items`.selling_price",
+	valueobject.ItemSortFieldCreatedAt:         "`items`.created_at",
+	valueobject.ItemSortFieldCurrentStockValue: "`items`.current_stock_value",
 }
 
-func (r *ItemRepositoryImpl) List(ctx context.Context, pagination valueobject.PaginationRequest) ([]entity.Item, error) {
+// List get the items matched the filter, archived items are excluded.
+// The sorts of filter are ignored when the items are paged by the cursor
+func (r *ItemRepositoryImpl) List(
+	ctx context.Context,
+	filter valueobject.ItemFilter,
+	pagination valueobject.PaginationRequest,
+) ([]entity.Item, error) {
+	db := r.db.Scopes(filterItem(filter))
+	if pagination.Cursor != nil {
+		db = db.Scopes(PaginateByCursor("items", false, pagination))
+	} else {
+		db = db.Scopes(sortItem(filter.Sorts), Paginate(pagination))
+	}
+
 	var items []entity.Item
-	err := r.db.Scopes(Paginate(pagination)).Find(&items).Error
+	err := db.Where("`items`.deleted_at IS NULL").Find(&items).Error
 	return items, err
 }
 
+// Count count the items matched the filter, archived items are excluded
+func (r *ItemRepositoryImpl) Count(ctx context.Context, filter valueobject.ItemFilter) (int64, error) {
+	var total int64
+	err := r.db.Model(&entity.Item{}).
+		Scopes(filterItem(filter)).
+		Where("`items`.deleted_at IS NULL").
+		Count(&total).Error
+	return total, err
+}
+
+// Archive soft delete the item, purchases still reference to it
+func (r *ItemRepositoryImpl) Archive(ctx context.Context, item *entity.Item) error {
+	return r.Updates(ctx, item, map[string]interface{}{
+		"deleted_at": time.Now(),
+	})
+}
+
+// GetByID get an item by id, archived item is also returned
 func (r *ItemRepositoryImpl) GetByID(ctx context.Context, itemID valueobject.ItemID) (entity.Item, error) {
+	return getItemByID(r.db, itemID)
+}
+
+// GetByIDForUpdate get an item by id with SELECT ... FOR UPDATE,
+// it must be called in a transaction to serialize the concurrent writes of stock
+func (r *ItemRepositoryImpl) GetByIDForUpdate(ctx context.Context, itemID valueobject.ItemID) (entity.Item, error) {
+	return getItemByID(r.db.Clauses(clause.Locking{Strength: "UPDATE"}), itemID)
+}
+
+// GetBySKU get an item by sku, archived item is also returned
+func (r *ItemRepositoryImpl) GetBySKU(ctx context.Context, sku string) (entity.Item, error) {
+	var item entity.Item
+	err := r.db.Take(&item, "`items`.sku = ?", sku).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Item{}, nil
+		}
+		return entity.Item{}, err
+	}
+
+	return item, nil
+}
+
+func (r *ItemRepositoryImpl) ListByProduct(ctx context.Context, productID valueobject.ProductID) ([]entity.Item, error) {
+	var items []entity.Item
+	err := r.db.Where("`items`.product_id = ? AND `items`.deleted_at IS NULL", productID).
+		Order("`items`.id").
+		Find(&items).Error
+	return items, err
+}
+
+// filterItem apply the conditions of item filter
+func filterItem(filter valueobject.ItemFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.MinSellingPrice != nil {
+			db = db.Where("`items`.selling_price >= ?", *filter.MinSellingPrice)
+		}
+
+		if filter.MaxSellingPrice != nil {
+			db = db.Where("`items`.selling_price <= ?", *filter.MaxSellingPrice)
+		}
+
+		if filter.InStockOnly {
+			db = db.Where("`items`.current_stock_value > 0")
+		}
+
+		if !filter.CreatedFrom.IsZero() {
+			db = db.Where("`items`.created_at >= ?", filter.CreatedFrom)
+		}
+
+		if !filter.CreatedTo.IsZero() {
+			db = db.Where("`items`.created_at <= ?", filter.CreatedTo)
+		}
+
+		if filter.ProductID != 0 {
+			db = db.Where("`items`.product_id = ?", filter.ProductID)
+		}
+
+		if len(filter.CategoryIDs) > 0 {
+			db = db.Where(
+				"`items`.id IN (SELECT `item_categories`.item_id FROM `item_categories` WHERE `item_categories`.category_id IN ?)",
+				filter.CategoryIDs,
+			)
+		}
+
+		for _, tag := range filter.Tags {
+			db = db.Where(
+				"`items`.id IN (SELECT `item_tags`.item_id FROM `item_tags` WHERE `item_tags`.tag = ?)",
+				tag,
+			)
+		}
+
+		for _, attribute := range filter.Attributes {
+			db = db.Where(
+				"`items`.id IN (SELECT `item_attributes`.item_id FROM `item_attributes` "+
+					"WHERE `item_attributes`.`key` = ? AND `item_attributes`.value = ?)",
+				attribute.Key, attribute.Value,
+			)
+		}
+
+		return db
+	}
+}
+
+// sortItem order items by the whitelisted columns, the unknown fields are ignored
+// and the id breaks the ties so the pages are stable
+func sortItem(sorts []valueobject.ItemSort) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, sort := range sorts {
+			column, ok := itemSortColumns[sort.Field]
+			if !ok {
+				continue
+			}
+
+			if sort.Descending {
+				column += " DESC"
+			}
+			db = db.Order(column)
+		}
+
+		return db.Order("`items`.id")
+	}
+}
+
+func getItemByID(db *gorm.DB, itemID valueobject.ItemID) (entity.Item, error) {
 	var item entity.Item
-	err := r.db.Take(&item, "`items`.id = ?", itemID).Error
+	err := db.Take(&item, "`items`.id = ?", itemID).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return entity.Item{}, nil
@@ -53,3 +220,14 @@ func (r *ItemRepositoryImpl) GetByID(ctx context.Context, itemID valueobject.Ite
 
 	return item, nil
 }
+
+// mysqlErrDuplicateEntry the error number of MySQL when a unique index is violated
+const mysqlErrDuplicateEntry = 1062
+
+// isDuplicateEntry check the error is the violation of the unique index
+func isDuplicateEntry(err error, index string) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	return errors.As(err, &mysqlErr) &&
+		mysqlErr.Number == mysqlErrDuplicateEntry &&
+		strings.Contains(mysqlErr.Message, index)
+}
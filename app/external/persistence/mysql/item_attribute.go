@@ -0,0 +1,44 @@
+package mysql
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// ItemAttributeRepositoryImpl item attribute repository implementation
+type ItemAttributeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewItemAttributeRepositoryImpl() repository.ItemAttributeRepository {
+	return &ItemAttributeRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *ItemAttributeRepositoryImpl) Set(ctx context.Context, attribute *entity.ItemAttribute) error {
+	return r.db.Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"value"}),
+	}).Create(attribute).Error
+}
+
+func (r *ItemAttributeRepositoryImpl) Remove(ctx context.Context, itemID valueobject.ItemID, key string) error {
+	return r.db.
+		Where("`item_attributes`.item_id = ? AND `item_attributes`.`key` = ?", itemID, key).
+		Delete(&entity.ItemAttribute{}).Error
+}
+
+func (r *ItemAttributeRepositoryImpl) ListByItem(ctx context.Context, itemID valueobject.ItemID) ([]entity.ItemAttribute, error) {
+	var attributes []entity.ItemAttribute
+	err := r.db.
+		Where("`item_attributes`.item_id = ?", itemID).
+		Order("`item_attributes`.`key`").
+		Find(&attributes).Error
+	return attributes, err
+}
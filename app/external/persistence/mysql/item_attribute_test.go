@@ -0,0 +1,74 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/utils/testsupport"
+)
+
+func TestItemAttributeRepositoryImpl_Set(t *testing.T) {
+	t.Run("#1: Replace the value of the key", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		insertQuery := regexp.QuoteMeta("INSERT INTO `item_attributes` (`item_id`,`key`,`value`) VALUES (?,?,?) " +
+			"ON DUPLICATE KEY UPDATE `value`=VALUES(`value`)")
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).WithArgs(1, "color", "red").WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		repo := ItemAttributeRepositoryImpl{
+			db: db,
+		}
+		err = repo.Set(context.Background(), &entity.ItemAttribute{
+			ItemID: valueobject.ItemID(1),
+			Key:    "color",
+			Value:  "red",
+		})
+		if err != nil {
+			t.Errorf("repo.Set() return an error:%v - want:nil", err)
+			return
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestItemAttributeRepositoryImpl_Remove(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		deleteQuery := regexp.QuoteMeta("DELETE FROM `item_attributes` WHERE `item_attributes`.item_id = ? AND `item_attributes`.`key` = ?")
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteQuery).WithArgs(1, "color").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		repo := ItemAttributeRepositoryImpl{
+			db: db,
+		}
+		err = repo.Remove(context.Background(), valueobject.ItemID(1), "color")
+		if err != nil {
+			t.Errorf("repo.Remove() return an error:%v - want:nil", err)
+			return
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
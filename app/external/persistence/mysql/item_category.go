@@ -0,0 +1,64 @@
+package mysql
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// ItemCategoryRepositoryImpl item category repository implementation
+type ItemCategoryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewItemCategoryRepositoryImpl() repository.ItemCategoryRepository {
+	return &ItemCategoryRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *ItemCategoryRepositoryImpl) AssignTx(txm repository.TransactionManager) {
+	tx := txm.GetTx().(*gorm.DB)
+	r.db = tx
+}
+
+// ReplaceByItem delete the assignments of item then assign it to the categories,
+// it should be called in a transaction
+func (r *ItemCategoryRepositoryImpl) ReplaceByItem(
+	ctx context.Context,
+	itemID valueobject.ItemID,
+	categoryIDs []valueobject.CategoryID,
+) error {
+	err := r.db.Where("`item_categories`.item_id = ?", itemID).Delete(&entity.ItemCategory{}).Error
+	if err != nil {
+		return err
+	}
+
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+
+	itemCategories := make([]entity.ItemCategory, len(categoryIDs))
+	for i := range categoryIDs {
+		itemCategories[i] = entity.ItemCategory{
+			ItemID:     itemID,
+			CategoryID: categoryIDs[i],
+		}
+	}
+
+	return r.db.Create(&itemCategories).Error
+}
+
+func (r *ItemCategoryRepositoryImpl) ListCategoriesByItem(ctx context.Context, itemID valueobject.ItemID) ([]entity.Category, error) {
+	var categories []entity.Category
+	err := r.db.
+		Joins("JOIN `item_categories` ON `item_categories`.category_id = `categories`.id").
+		Where("`item_categories`.item_id = ?", itemID).
+		Order("`categories`.id").
+		Find(&categories).Error
+	return categories, err
+}
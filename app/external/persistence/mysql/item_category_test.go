@@ -0,0 +1,113 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/utils/testsupport"
+)
+
+func TestItemCategoryRepositoryImpl_ReplaceByItem(t *testing.T) {
+	deleteQuery := regexp.QuoteMeta("DELETE FROM `item_categories` WHERE `item_categories`.item_id = ?")
+
+	t.Run("#1: Unassign the item from all the categories", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteQuery).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		repo := ItemCategoryRepositoryImpl{
+			db: db,
+		}
+		err = repo.ReplaceByItem(context.Background(), valueobject.ItemID(1), nil)
+		if err != nil {
+			t.Errorf("repo.ReplaceByItem() return an error:%v - want:nil", err)
+			return
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("#2: Replace the categories of item", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		insertQuery := regexp.QuoteMeta("INSERT INTO `item_categories` (`item_id`,`category_id`) VALUES (?,?),(?,?)")
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteQuery).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).WithArgs(1, 2, 1, 3).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		repo := ItemCategoryRepositoryImpl{
+			db: db,
+		}
+		err = repo.ReplaceByItem(
+			context.Background(),
+			valueobject.ItemID(1),
+			[]valueobject.CategoryID{2, 3},
+		)
+		if err != nil {
+			t.Errorf("repo.ReplaceByItem() return an error:%v - want:nil", err)
+			return
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestItemCategoryRepositoryImpl_ListCategoriesByItem(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		createdAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		query := regexp.QuoteMeta("SELECT `categories`.`id`,`categories`.`created_at`,`categories`.`name`,`categories`.`parent_id` " +
+			"FROM `categories` JOIN `item_categories` ON `item_categories`.category_id = `categories`.id " +
+			"WHERE `item_categories`.item_id = ? ORDER BY `categories`.id")
+		mock.ExpectQuery(query).WithArgs(1).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "name", "parent_id"}).
+				AddRow(2, createdAt, "Shirts", 1),
+		)
+
+		repo := ItemCategoryRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.ListCategoriesByItem(context.Background(), valueobject.ItemID(1))
+		if err != nil {
+			t.Errorf("repo.ListCategoriesByItem() return an error:%v - want:nil", err)
+			return
+		}
+
+		parentID := valueobject.CategoryID(1)
+		want := []entity.Category{
+			{ID: 2, CreatedAt: createdAt, Name: "Shirts", ParentID: &parentID},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// itemSearchMatch the FULLTEXT index ft_items_name_description is used to match the items
+const itemSearchMatch = "MATCH(`items`.name, `items`.description) AGAINST (? IN BOOLEAN MODE)"
+
+// itemSearchRow the columns of item are scanned into the embedded item next to the score of the row
+type itemSearchRow struct {
+	Item  entity.Item `gorm:"embedded"`
+	Score float64
+}
+
+// ItemSearchRepositoryImpl item search repository implementation backed by the MySQL FULLTEXT index
+type ItemSearchRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewItemSearchRepositoryImpl() repository.ItemSearchRepository {
+	return &ItemSearchRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+// Search rank the items by the relevance of MySQL boolean full-text search,
+// the cursor of pagination is ignored since the items are not ordered by (created_at, id)
+func (r *ItemSearchRepositoryImpl) Search(
+	ctx context.Context,
+	query valueobject.ItemSearchQuery,
+	pagination valueobject.PaginationRequest,
+) ([]entity.ItemSearchHit, error) {
+	if len(query.Terms) == 0 {
+		return nil, nil
+	}
+
+	against := booleanModeQuery(query)
+	var rows []itemSearchRow
+	err := r.db.Table("items").
+		Select("`items`.*, "+itemSearchMatch+" AS score", against).
+		Where(itemSearchMatch, against).
+		Where("`items`.deleted_at IS NULL").
+		Order("score DESC, `items`.id").
+		Scopes(Paginate(pagination)).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]entity.ItemSearchHit, len(rows))
+	for i := range rows {
+		hits[i] = entity.ItemSearchHit{
+			Item:  rows[i].Item,
+			Score: rows[i].Score,
+		}
+	}
+
+	return hits, nil
+}
+
+// booleanModeQuery every term is required and matched as the prefix of a word,
+// the terms only have letters and digits so they never contain the boolean operators
+func booleanModeQuery(query valueobject.ItemSearchQuery) string {
+	words := make([]string, len(query.Terms))
+	for i, term := range query.Terms {
+		words[i] = "+" + term + "*"
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,112 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/shopspring/decimal"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/utils/testsupport"
+)
+
+func TestItemSearchRepositoryImpl_Search(t *testing.T) {
+	searchQuery := regexp.QuoteMeta("SELECT `items`.*, " +
+		"MATCH(`items`.name, `items`.description) AGAINST (? IN BOOLEAN MODE) AS score FROM `items` " +
+		"WHERE MATCH(`items`.name, `items`.description) AGAINST (? IN BOOLEAN MODE) " +
+		"AND `items`.deleted_at IS NULL ORDER BY score DESC, `items`.id LIMIT 10")
+
+	t.Run("#1: Failed to search items", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		mock.ExpectQuery(searchQuery).WillReturnError(errors.New("failed to search"))
+
+		repo := ItemSearchRepositoryImpl{
+			db: db,
+		}
+		_, err = repo.Search(
+			context.Background(),
+			valueobject.ItemSearchQuery{Terms: []string{"shirt"}},
+			valueobject.PaginationRequest{Page: 1, Limit: 10},
+		)
+		if err == nil {
+			t.Errorf("repo.Search() return nil error - want an error")
+		}
+	})
+
+	t.Run("#2: Every term is required as a prefix", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		mock.ExpectQuery(searchQuery).
+			WithArgs("+cot* +shirt*", "+cot* +shirt*").
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "created_at", "sku", "name", "description", "selling_price", "score"}).
+					AddRow(1, time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local), "TS-001", "T-shirt", "Cotton", decimal.NewFromFloat(1.55), 1.5),
+			)
+
+		repo := ItemSearchRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.Search(
+			context.Background(),
+			valueobject.ItemSearchQuery{Terms: []string{"cot", "shirt"}},
+			valueobject.PaginationRequest{Page: 1, Limit: 10},
+		)
+		if err != nil {
+			t.Errorf("repo.Search() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := []entity.ItemSearchHit{
+			{
+				Item: entity.Item{
+					ID:           1,
+					CreatedAt:    time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+					SKU:          "TS-001",
+					Name:         "T-shirt",
+					Description:  "Cotton",
+					SellingPrice: decimal.NewFromFloat(1.55),
+				},
+				Score: 1.5,
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#3: No term matches no item", func(t *testing.T) {
+		t.Parallel()
+		db, _, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		repo := ItemSearchRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.Search(context.Background(), valueobject.ItemSearchQuery{}, valueobject.PaginationRequest{})
+		if err != nil {
+			t.Errorf("repo.Search() return an error:%v - want:nil", err)
+			return
+		}
+
+		if len(got) != 0 {
+			t.Errorf("repo.Search() return %d items - want none", len(got))
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package mysql
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// ItemTagRepositoryImpl item tag repository implementation
+type ItemTagRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewItemTagRepositoryImpl() repository.ItemTagRepository {
+	return &ItemTagRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *ItemTagRepositoryImpl) Add(ctx context.Context, tag *entity.ItemTag) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(tag).Error
+}
+
+func (r *ItemTagRepositoryImpl) Remove(ctx context.Context, itemID valueobject.ItemID, tag string) error {
+	return r.db.
+		Where("`item_tags`.item_id = ? AND `item_tags`.tag = ?", itemID, tag).
+		Delete(&entity.ItemTag{}).Error
+}
+
+func (r *ItemTagRepositoryImpl) ListByItem(ctx context.Context, itemID valueobject.ItemID) ([]entity.ItemTag, error) {
+	var tags []entity.ItemTag
+	err := r.db.
+		Where("`item_tags`.item_id = ?", itemID).
+		Order("`item_tags`.tag").
+		Find(&tags).Error
+	return tags, err
+}
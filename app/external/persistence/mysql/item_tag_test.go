@@ -0,0 +1,77 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/utils/testsupport"
+)
+
+func TestItemTagRepositoryImpl_Add(t *testing.T) {
+	t.Run("#1: Adding an existing tag does nothing", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		insertQuery := regexp.QuoteMeta("INSERT INTO `item_tags` (`item_id`,`tag`) VALUES (?,?) " +
+			"ON DUPLICATE KEY UPDATE `item_id`=`item_id`")
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).WithArgs(1, "sale").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		repo := ItemTagRepositoryImpl{
+			db: db,
+		}
+		err = repo.Add(context.Background(), &entity.ItemTag{ItemID: valueobject.ItemID(1), Tag: "sale"})
+		if err != nil {
+			t.Errorf("repo.Add() return an error:%v - want:nil", err)
+			return
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestItemTagRepositoryImpl_ListByItem(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `item_tags` WHERE `item_tags`.item_id = ? ORDER BY `item_tags`.tag")
+		mock.ExpectQuery(query).WithArgs(1).WillReturnRows(
+			sqlmock.NewRows([]string{"item_id", "tag"}).
+				AddRow(1, "new").
+				AddRow(1, "sale"),
+		)
+
+		repo := ItemTagRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.ListByItem(context.Background(), valueobject.ItemID(1))
+		if err != nil {
+			t.Errorf("repo.ListByItem() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := []entity.ItemTag{
+			{ItemID: valueobject.ItemID(1), Tag: "new"},
+			{ItemID: valueobject.ItemID(1), Tag: "sale"},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
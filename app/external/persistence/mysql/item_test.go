@@ -8,12 +8,14 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 
 	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
 	"github.com/tuanna7593/gosample/app/domain/valueobject"
 	"github.com/tuanna7593/gosample/app/utils/testsupport"
 )
@@ -27,12 +29,14 @@ func TestItemRepositoryImpl_Create(t *testing.T) {
 		}
 
 		item := entity.Item{
+			SKU:               "TS-001",
+			Name:              "T-shirt",
 			TotalStockValue:   1,
 			CurrentStockValue: 1,
 			SellingPrice:      decimal.NewFromFloat32(1.5),
 		}
 
-		insertQuery := regexp.QuoteMeta("INSERT INTO `items` (`created_at`,`total_stock_value`,`current_stock_value`,`selling_price`) VALUES (?,?,?,?)")
+		insertQuery := regexp.QuoteMeta("INSERT INTO `items` (`created_at`,`product_id`,`sku`,`name`,`description`,`total_stock_value`,`current_stock_value`,`reserved_stock_value`,`located_stock_value`,`in_transit_stock_value`,`backordered_stock_value`,`backorderable`,`reorder_threshold`,`selling_price`,`deleted_at`,`version`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)")
 		mock.ExpectBegin()
 		mock.ExpectExec(insertQuery).WillReturnResult(
 			sqlmock.NewResult(1, 1),
@@ -60,6 +64,8 @@ func TestItemRepositoryImpl_Create(t *testing.T) {
 		}
 
 		want := entity.Item{
+			SKU:               "TS-001",
+			Name:              "T-shirt",
 			TotalStockValue:   1,
 			CurrentStockValue: 1,
 			SellingPrice:      decimal.NewFromFloat32(1.5),
@@ -87,7 +93,7 @@ func TestItemRepositoryImpl_Create(t *testing.T) {
 
 		wannaErr := errors.New("cannot conntect db")
 
-		insertQuery := regexp.QuoteMeta("INSERT INTO `items` (`created_at`,`total_stock_value`,`current_stock_value`,`selling_price`) VALUES (?,?,?,?)")
+		insertQuery := regexp.QuoteMeta("INSERT INTO `items` (`created_at`,`product_id`,`sku`,`name`,`description`,`total_stock_value`,`current_stock_value`,`reserved_stock_value`,`located_stock_value`,`in_transit_stock_value`,`backordered_stock_value`,`backorderable`,`reorder_threshold`,`selling_price`,`deleted_at`,`version`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)")
 		mock.ExpectBegin()
 		mock.ExpectExec(insertQuery).WithArgs().WillReturnError(wannaErr)
 		mock.ExpectRollback()
@@ -101,6 +107,41 @@ func TestItemRepositoryImpl_Create(t *testing.T) {
 			t.Errorf("repo.Create() return an error:%v - want:%v", err, wannaErr)
 		}
 	})
+
+	t.Run("#3: SKU has been used by another item", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		item := entity.Item{
+			SKU:               "TS-001",
+			Name:              "T-shirt",
+			TotalStockValue:   1,
+			CurrentStockValue: 1,
+			SellingPrice:      decimal.NewFromFloat32(1.5),
+		}
+
+		duplicateErr := &mysqldriver.MySQLError{
+			Number:  1062,
+			Message: "Duplicate entry 'TS-001' for key 'uq_items_sku'",
+		}
+
+		insertQuery := regexp.QuoteMeta("INSERT INTO `items` (`created_at`,`product_id`,`sku`,`name`,`description`,`total_stock_value`,`current_stock_value`,`reserved_stock_value`,`located_stock_value`,`in_transit_stock_value`,`backordered_stock_value`,`backorderable`,`reorder_threshold`,`selling_price`,`deleted_at`,`version`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)")
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).WillReturnError(duplicateErr)
+		mock.ExpectRollback()
+
+		repo := ItemRepositoryImpl{
+			db: db,
+		}
+
+		err = repo.Create(context.Background(), &item)
+		if !errors.Is(err, repository.ErrSKUExists) {
+			t.Errorf("repo.Create() return an error:%v - want:%v", err, repository.ErrSKUExists)
+		}
+	})
 }
 
 func TestItemRepositoryImpl_List(t *testing.T) {
@@ -114,7 +155,7 @@ func TestItemRepositoryImpl_List(t *testing.T) {
 			db: db,
 		}
 
-		selectQuery := regexp.QuoteMeta("SELECT * FROM `items`")
+		selectQuery := regexp.QuoteMeta("SELECT * FROM `items` WHERE `items`.deleted_at IS NULL ORDER BY `items`.id")
 		mock.ExpectQuery(selectQuery).WillReturnRows(
 			sqlmock.NewRows([]string{
 				"id", "created_at", "total_stock_value",
@@ -123,7 +164,7 @@ func TestItemRepositoryImpl_List(t *testing.T) {
 				AddRow(2, time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local), 1, 1, decimal.NewFromFloat(2.55)),
 		)
 
-		got, err := repo.List(context.Background(), valueobject.PaginationRequest{})
+		got, err := repo.List(context.Background(), valueobject.ItemFilter{}, valueobject.PaginationRequest{})
 		if err != nil {
 			t.Errorf("repo.List() return an error:%v - want: nil", err)
 			return
@@ -161,7 +202,7 @@ func TestItemRepositoryImpl_List(t *testing.T) {
 			db: db,
 		}
 
-		selectQuery := regexp.QuoteMeta("SELECT * FROM `items`")
+		selectQuery := regexp.QuoteMeta("SELECT * FROM `items` WHERE `items`.deleted_at IS NULL ORDER BY `items`.id")
 		mock.ExpectQuery(selectQuery).WillReturnRows(
 			sqlmock.NewRows([]string{
 				"id", "created_at", "total_stock_value",
@@ -174,7 +215,7 @@ func TestItemRepositoryImpl_List(t *testing.T) {
 			Page:  -1,
 			Limit: 1,
 		}
-		got, err := repo.List(context.Background(), pagination)
+		got, err := repo.List(context.Background(), valueobject.ItemFilter{}, pagination)
 		if err != nil {
 			t.Errorf("repo.List() return an error:%v - want: nil", err)
 			return
@@ -212,7 +253,7 @@ func TestItemRepositoryImpl_List(t *testing.T) {
 			db: db,
 		}
 
-		selectQuery := regexp.QuoteMeta("SELECT * FROM `items`")
+		selectQuery := regexp.QuoteMeta("SELECT * FROM `items` WHERE `items`.deleted_at IS NULL ORDER BY `items`.id")
 		mock.ExpectQuery(selectQuery).WillReturnRows(
 			sqlmock.NewRows([]string{
 				"id", "created_at", "total_stock_value",
@@ -225,7 +266,7 @@ func TestItemRepositoryImpl_List(t *testing.T) {
 			Page:  1,
 			Limit: 0,
 		}
-		got, err := repo.List(context.Background(), pagination)
+		got, err := repo.List(context.Background(), valueobject.ItemFilter{}, pagination)
 		if err != nil {
 			t.Errorf("repo.List() return an error:%v - want: nil", err)
 			return
@@ -263,7 +304,7 @@ func TestItemRepositoryImpl_List(t *testing.T) {
 			db: db,
 		}
 
-		selectQuery := regexp.QuoteMeta("SELECT * FROM `items` LIMIT 5 OFFSET 5")
+		selectQuery := regexp.QuoteMeta("SELECT * FROM `items` WHERE `items`.deleted_at IS NULL ORDER BY `items`.id LIMIT 5 OFFSET 5")
 		mock.ExpectQuery(selectQuery).WillReturnRows(
 			sqlmock.NewRows([]string{
 				"id", "created_at", "total_stock_value",
@@ -276,7 +317,7 @@ func TestItemRepositoryImpl_List(t *testing.T) {
 			Page:  2,
 			Limit: 5,
 		}
-		got, err := repo.List(context.Background(), pagination)
+		got, err := repo.List(context.Background(), valueobject.ItemFilter{}, pagination)
 		if err != nil {
 			t.Errorf("repo.List() return an error:%v - want: nil", err)
 		}
@@ -313,7 +354,7 @@ func TestItemRepositoryImpl_List(t *testing.T) {
 			db: db,
 		}
 
-		selectQuery := regexp.QuoteMeta("SELECT * FROM `items` LIMIT 5 OFFSET 5")
+		selectQuery := regexp.QuoteMeta("SELECT * FROM `items` WHERE `items`.deleted_at IS NULL ORDER BY `items`.id LIMIT 5 OFFSET 5")
 		wannaErr := errors.New("failed to get items")
 		mock.ExpectQuery(selectQuery).WillReturnError(wannaErr)
 
@@ -321,7 +362,7 @@ func TestItemRepositoryImpl_List(t *testing.T) {
 			Page:  2,
 			Limit: 5,
 		}
-		got, err := repo.List(context.Background(), pagination)
+		got, err := repo.List(context.Background(), valueobject.ItemFilter{}, pagination)
 		if !errors.Is(err, wannaErr) {
 			t.Errorf("repo.List() return an error:%v - want: %v", err, wannaErr)
 			return
@@ -332,6 +373,257 @@ func TestItemRepositoryImpl_List(t *testing.T) {
 			t.Error(diff)
 		}
 	})
+
+	t.Run("#6: List with filter and sorts", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+		repo := ItemRepositoryImpl{
+			db: db,
+		}
+
+		createdFrom := time.Date(2021, 10, 16, 0, 0, 0, 0, time.Local)
+		selectQuery := regexp.QuoteMeta(
+			"SELECT * FROM `items` WHERE `items`.deleted_at IS NULL AND `items`.selling_price >= ? AND `items`.selling_price <= ? " +
+				"AND `items`.current_stock_value > 0 AND `items`.created_at >= ? " +
+				"ORDER BY `items`.selling_price,`items`.created_at DESC,`items`.id LIMIT 5",
+		)
+		mock.ExpectQuery(selectQuery).
+			WithArgs(decimal.NewFromFloat(1.5), decimal.NewFromFloat(3), createdFrom).
+			WillReturnRows(
+				sqlmock.NewRows([]string{
+					"id", "created_at", "total_stock_value",
+					"current_stock_value", "selling_price"}).
+					AddRow(2, time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local), 1, 1, decimal.NewFromFloat(2.55)),
+			)
+
+		minSellingPrice := decimal.NewFromFloat(1.5)
+		maxSellingPrice := decimal.NewFromFloat(3)
+		filter := valueobject.ItemFilter{
+			MinSellingPrice: &minSellingPrice,
+			MaxSellingPrice: &maxSellingPrice,
+			InStockOnly:     true,
+			CreatedFrom:     createdFrom,
+			Sorts: []valueobject.ItemSort{
+				{Field: valueobject.ItemSortFieldSellingPrice},
+				{Field: valueobject.ItemSortFieldCreatedAt, Descending: true},
+				// the field out of the whitelist is never put into the query
+				{Field: valueobject.ItemSortField("version; DROP TABLE items")},
+			},
+		}
+		pagination := valueobject.PaginationRequest{
+			Page:  1,
+			Limit: 5,
+		}
+		got, err := repo.List(context.Background(), filter, pagination)
+		if err != nil {
+			t.Errorf("repo.List() return an error:%v - want: nil", err)
+			return
+		}
+
+		want := []entity.Item{
+			{
+				ID:                2,
+				CreatedAt:         time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local),
+				TotalStockValue:   1,
+				CurrentStockValue: 1,
+				SellingPrice:      decimal.NewFromFloat(2.55),
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#7: List the first page by cursor", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+		repo := ItemRepositoryImpl{
+			db: db,
+		}
+
+		// the sorts are ignored since the cursor has its own order
+		selectQuery := regexp.QuoteMeta(
+			"SELECT * FROM `items` WHERE `items`.deleted_at IS NULL AND `items`.current_stock_value > 0 " +
+				"ORDER BY `items`.created_at, `items`.id LIMIT 2",
+		)
+		mock.ExpectQuery(selectQuery).WillReturnRows(
+			sqlmock.NewRows([]string{
+				"id", "created_at", "total_stock_value",
+				"current_stock_value", "selling_price"}).
+				AddRow(1, time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local), 5, 4, decimal.NewFromFloat(1.55)),
+		)
+
+		filter := valueobject.ItemFilter{
+			InStockOnly: true,
+			Sorts: []valueobject.ItemSort{
+				{Field: valueobject.ItemSortFieldSellingPrice},
+			},
+		}
+		pagination := valueobject.PaginationRequest{
+			Page:   3,
+			Limit:  2,
+			Cursor: &valueobject.Cursor{},
+		}
+		got, err := repo.List(context.Background(), filter, pagination)
+		if err != nil {
+			t.Errorf("repo.List() return an error:%v - want: nil", err)
+			return
+		}
+
+		want := []entity.Item{
+			{
+				ID:                1,
+				CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+				TotalStockValue:   5,
+				CurrentStockValue: 4,
+				SellingPrice:      decimal.NewFromFloat(1.55),
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#8: List the items in the categories", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+		repo := ItemRepositoryImpl{
+			db: db,
+		}
+
+		selectQuery := regexp.QuoteMeta(
+			"SELECT * FROM `items` WHERE `items`.deleted_at IS NULL " +
+				"AND (`items`.id IN (SELECT `item_categories`.item_id FROM `item_categories` WHERE `item_categories`.category_id IN (?,?))) " +
+				"ORDER BY `items`.id LIMIT 2",
+		)
+		mock.ExpectQuery(selectQuery).WithArgs(1, 2).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "selling_price"}).
+				AddRow(1, time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local), decimal.NewFromFloat(1.55)),
+		)
+
+		filter := valueobject.ItemFilter{
+			CategoryIDs: []valueobject.CategoryID{1, 2},
+		}
+		got, err := repo.List(context.Background(), filter, valueobject.PaginationRequest{Page: 1, Limit: 2})
+		if err != nil {
+			t.Errorf("repo.List() return an error:%v - want: nil", err)
+			return
+		}
+
+		want := []entity.Item{
+			{
+				ID:           1,
+				CreatedAt:    time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+				SellingPrice: decimal.NewFromFloat(1.55),
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#9: List the items which have all the tags and attributes", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+		repo := ItemRepositoryImpl{
+			db: db,
+		}
+
+		selectQuery := regexp.QuoteMeta(
+			"SELECT * FROM `items` WHERE `items`.deleted_at IS NULL " +
+				"AND `items`.id IN (SELECT `item_tags`.item_id FROM `item_tags` WHERE `item_tags`.tag = ?) " +
+				"AND (`items`.id IN (SELECT `item_attributes`.item_id FROM `item_attributes` " +
+				"WHERE `item_attributes`.`key` = ? AND `item_attributes`.value = ?)) " +
+				"AND (`items`.id IN (SELECT `item_attributes`.item_id FROM `item_attributes` " +
+				"WHERE `item_attributes`.`key` = ? AND `item_attributes`.value = ?)) " +
+				"ORDER BY `items`.id LIMIT 2",
+		)
+		mock.ExpectQuery(selectQuery).WithArgs("sale", "color", "red", "size", "m").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "selling_price"}).
+				AddRow(1, time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local), decimal.NewFromFloat(1.55)),
+		)
+
+		filter := valueobject.ItemFilter{
+			Tags: []string{"sale"},
+			Attributes: []valueobject.ItemAttribute{
+				{Key: "color", Value: "red"},
+				{Key: "size", Value: "m"},
+			},
+		}
+		got, err := repo.List(context.Background(), filter, valueobject.PaginationRequest{Page: 1, Limit: 2})
+		if err != nil {
+			t.Errorf("repo.List() return an error:%v - want: nil", err)
+			return
+		}
+
+		want := []entity.Item{
+			{
+				ID:           1,
+				CreatedAt:    time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+				SellingPrice: decimal.NewFromFloat(1.55),
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#10: List the free items by the max price of 0", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+		repo := ItemRepositoryImpl{
+			db: db,
+		}
+
+		selectQuery := regexp.QuoteMeta("SELECT * FROM `items` WHERE `items`.deleted_at IS NULL AND `items`.selling_price <= ? ORDER BY `items`.id")
+		mock.ExpectQuery(selectQuery).
+			WithArgs(decimal.Zero).
+			WillReturnRows(
+				sqlmock.NewRows([]string{
+					"id", "created_at", "total_stock_value",
+					"current_stock_value", "selling_price"}).
+					AddRow(3, time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local), 2, 2, decimal.Zero),
+			)
+
+		maxSellingPrice := decimal.Zero
+		got, err := repo.List(
+			context.Background(),
+			valueobject.ItemFilter{MaxSellingPrice: &maxSellingPrice},
+			valueobject.PaginationRequest{},
+		)
+		if err != nil {
+			t.Errorf("repo.List() return an error:%v - want: nil", err)
+			return
+		}
+
+		want := []entity.Item{
+			{
+				ID:                3,
+				CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+				TotalStockValue:   2,
+				CurrentStockValue: 2,
+				SellingPrice:      decimal.Zero,
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
 }
 
 func TestItemRepositoryImpl_GetByID(t *testing.T) {
@@ -424,6 +716,278 @@ func TestItemRepositoryImpl_GetByID(t *testing.T) {
 	})
 }
 
+func TestItemRepositoryImpl_GetByIDForUpdate(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `items` WHERE `items`.id = ? LIMIT 1 FOR UPDATE")
+		mock.ExpectQuery(query).WithArgs(uint64(1)).WillReturnRows(
+			sqlmock.NewRows([]string{
+				"id", "created_at", "total_stock_value",
+				"current_stock_value", "selling_price"}).
+				AddRow(1, time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local), 5, 4, decimal.NewFromFloat(1.55)),
+		)
+
+		repo := ItemRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByIDForUpdate(context.Background(), valueobject.ItemID(1))
+		if err != nil {
+			t.Errorf("repo.GetByIDForUpdate() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := entity.Item{
+			ID:                1,
+			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalStockValue:   5,
+			CurrentStockValue: 4,
+			SellingPrice:      decimal.NewFromFloat(1.55),
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Not found item", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `items` WHERE `items`.id = ? LIMIT 1 FOR UPDATE")
+		mock.ExpectQuery(query).WillReturnError(gorm.ErrRecordNotFound)
+
+		repo := ItemRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByIDForUpdate(context.Background(), valueobject.ItemID(1))
+		if err != nil {
+			t.Errorf("repo.GetByIDForUpdate() return an error:%v - want:nil", err)
+			return
+		}
+
+		var want entity.Item
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#3: Lock the item inside the assigned transaction", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		// the row lock is held until the transaction ends so the query must be sent between begin and commit
+		query := regexp.QuoteMeta("SELECT * FROM `items` WHERE `items`.id = ? LIMIT 1 FOR UPDATE")
+		mock.ExpectBegin()
+		mock.ExpectQuery(query).WithArgs(uint64(1)).WillReturnRows(
+			sqlmock.NewRows([]string{
+				"id", "created_at", "total_stock_value",
+				"current_stock_value", "selling_price"}).
+				AddRow(1, time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local), 5, 4, decimal.NewFromFloat(1.55)),
+		)
+		mock.ExpectCommit()
+
+		txm := &TransactionManagerImpl{
+			db: db.Begin(),
+		}
+		repo := ItemRepositoryImpl{
+			db: db,
+		}
+		repo.AssignTx(txm)
+		_, err = repo.GetByIDForUpdate(context.Background(), valueobject.ItemID(1))
+		if err != nil {
+			t.Errorf("repo.GetByIDForUpdate() return an error:%v - want:nil", err)
+			return
+		}
+
+		if err := txm.Commit(); err != nil {
+			t.Errorf("txm.Commit() return an error:%v - want:nil", err)
+			return
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestItemRepositoryImpl_GetBySKU(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `items` WHERE `items`.sku = ? LIMIT 1")
+		mock.ExpectQuery(query).WithArgs("TS-001").WillReturnRows(
+			sqlmock.NewRows([]string{
+				"id", "created_at", "sku", "name", "description",
+				"total_stock_value", "current_stock_value", "selling_price"}).
+				AddRow(1, time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local), "TS-001", "T-shirt", "Cotton", 5, 4, decimal.NewFromFloat(1.55)),
+		)
+
+		repo := ItemRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetBySKU(context.Background(), "TS-001")
+		if err != nil {
+			t.Errorf("repo.GetBySKU() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := entity.Item{
+			ID:                1,
+			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			SKU:               "TS-001",
+			Name:              "T-shirt",
+			Description:       "Cotton",
+			TotalStockValue:   5,
+			CurrentStockValue: 4,
+			SellingPrice:      decimal.NewFromFloat(1.55),
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Not found item", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `items` WHERE `items`.sku = ? LIMIT 1")
+		mock.ExpectQuery(query).WillReturnError(gorm.ErrRecordNotFound)
+
+		repo := ItemRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetBySKU(context.Background(), "TS-001")
+		if err != nil {
+			t.Errorf("repo.GetBySKU() return an error:%v - want:nil", err)
+			return
+		}
+
+		var want entity.Item
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestItemRepositoryImpl_ListByProduct(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		createdAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		query := regexp.QuoteMeta("SELECT * FROM `items` WHERE `items`.product_id = ? AND `items`.deleted_at IS NULL ORDER BY `items`.id")
+		mock.ExpectQuery(query).WithArgs(valueobject.ProductID(1)).WillReturnRows(
+			sqlmock.NewRows([]string{
+				"id", "created_at", "product_id", "sku", "name",
+				"total_stock_value", "current_stock_value", "selling_price"}).
+				AddRow(1, createdAt, 1, "TS-S", "T-shirt S", 5, 4, decimal.NewFromFloat(1.55)).
+				AddRow(2, createdAt, 1, "TS-M", "T-shirt M", 3, 3, decimal.NewFromFloat(1.75)),
+		)
+
+		repo := ItemRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.ListByProduct(context.Background(), valueobject.ProductID(1))
+		if err != nil {
+			t.Errorf("repo.ListByProduct() return an error:%v - want:nil", err)
+			return
+		}
+
+		productID := valueobject.ProductID(1)
+		want := []entity.Item{
+			{
+				ID:                1,
+				CreatedAt:         createdAt,
+				ProductID:         &productID,
+				SKU:               "TS-S",
+				Name:              "T-shirt S",
+				TotalStockValue:   5,
+				CurrentStockValue: 4,
+				SellingPrice:      decimal.NewFromFloat(1.55),
+			},
+			{
+				ID:                2,
+				CreatedAt:         createdAt,
+				ProductID:         &productID,
+				SKU:               "TS-M",
+				Name:              "T-shirt M",
+				TotalStockValue:   3,
+				CurrentStockValue: 3,
+				SellingPrice:      decimal.NewFromFloat(1.75),
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestItemRepositoryImpl_Count(t *testing.T) {
+	t.Run("#1: Failed to count items", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT count(*) FROM `items` WHERE `items`.deleted_at IS NULL")
+		mock.ExpectQuery(query).WillReturnError(errors.New("failed to count"))
+
+		repo := ItemRepositoryImpl{
+			db: db,
+		}
+		_, err = repo.Count(context.Background(), valueobject.ItemFilter{})
+		if err == nil {
+			t.Errorf("repo.Count() return nil error - want an error")
+		}
+	})
+
+	t.Run("#2: Count the items matched the filter", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT count(*) FROM `items` " +
+			"WHERE `items`.deleted_at IS NULL AND `items`.current_stock_value > 0")
+		mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(3))
+
+		repo := ItemRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.Count(context.Background(), valueobject.ItemFilter{InStockOnly: true})
+		if err != nil {
+			t.Errorf("repo.Count() return an error:%v - want:nil", err)
+			return
+		}
+
+		if got != 3 {
+			t.Errorf("repo.Count() return:%d - want:3", got)
+		}
+	})
+}
+
 func TestItemRepositoryImpl_Updates(t *testing.T) {
 	t.Run("#1: Success", func(t *testing.T) {
 		t.Parallel()
@@ -444,7 +1008,7 @@ func TestItemRepositoryImpl_Updates(t *testing.T) {
 			"selling_price":       decimal.NewFromFloat(2.55),
 		}
 
-		updateQuery := regexp.QuoteMeta("UPDATE `items` SET `current_stock_value`=?,`selling_price`=? WHERE `id` = ?")
+		updateQuery := regexp.QuoteMeta("UPDATE `items` SET `current_stock_value`=?,`selling_price`=?,`version`=`items`.version + 1 WHERE `id` = ?")
 		mock.ExpectBegin()
 		mock.ExpectExec(updateQuery).WithArgs(4, decimal.NewFromFloat(2.55), uint64(1)).WillReturnResult(
 			sqlmock.NewResult(1, 1),
@@ -467,6 +1031,7 @@ func TestItemRepositoryImpl_Updates(t *testing.T) {
 			TotalStockValue:   5,
 			CurrentStockValue: 4,
 			SellingPrice:      decimal.NewFromFloat32(2.55),
+			Version:           1,
 		}
 
 		if diff := cmp.Diff(item, want); diff != "" {
@@ -494,7 +1059,7 @@ func TestItemRepositoryImpl_Updates(t *testing.T) {
 		}
 
 		wannaErr := errors.New("failed to update")
-		updateQuery := regexp.QuoteMeta("UPDATE `items` SET `current_stock_value`=?,`selling_price`=? WHERE `id` = ?")
+		updateQuery := regexp.QuoteMeta("UPDATE `items` SET `current_stock_value`=?,`selling_price`=?,`version`=`items`.version + 1 WHERE `id` = ?")
 		mock.ExpectBegin()
 		mock.ExpectExec(updateQuery).WithArgs(4, decimal.NewFromFloat(2.55), uint64(1)).
 			WillReturnError(wannaErr)
@@ -511,3 +1076,69 @@ func TestItemRepositoryImpl_Updates(t *testing.T) {
 		}
 	})
 }
+
+func TestItemRepositoryImpl_Archive(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalStockValue:   5,
+			CurrentStockValue: 5,
+			SellingPrice:      decimal.NewFromFloat32(1.55),
+		}
+
+		updateQuery := regexp.QuoteMeta("UPDATE `items` SET `deleted_at`=?,`version`=`items`.version + 1 WHERE `id` = ?")
+		mock.ExpectBegin()
+		mock.ExpectExec(updateQuery).WithArgs(sqlmock.AnyArg(), uint64(1)).WillReturnResult(
+			sqlmock.NewResult(1, 1),
+		)
+		mock.ExpectCommit()
+
+		repo := ItemRepositoryImpl{
+			db: db,
+		}
+
+		err = repo.Archive(context.Background(), &item)
+		if err != nil {
+			t.Errorf("repo.Archive() return an error:%v - want:nil", err)
+			return
+		}
+
+		if !item.IsArchived() {
+			t.Error("item must be archived")
+		}
+	})
+
+	t.Run("#2: Failed to archive item", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		item := entity.Item{
+			ID: valueobject.ItemID(1),
+		}
+
+		wannaErr := errors.New("failed to update")
+		updateQuery := regexp.QuoteMeta("UPDATE `items` SET `deleted_at`=?,`version`=`items`.version + 1 WHERE `id` = ?")
+		mock.ExpectBegin()
+		mock.ExpectExec(updateQuery).WithArgs(sqlmock.AnyArg(), uint64(1)).WillReturnError(wannaErr)
+		mock.ExpectRollback()
+
+		repo := ItemRepositoryImpl{
+			db: db,
+		}
+
+		err = repo.Archive(context.Background(), &item)
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("repo.Archive() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+}
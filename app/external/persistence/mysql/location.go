@@ -0,0 +1,54 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// LocationRepositoryImpl location repository implementation
+type LocationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewLocationRepositoryImpl() repository.LocationRepository {
+	return &LocationRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *LocationRepositoryImpl) Create(ctx context.Context, location *entity.Location) error {
+	return r.db.Create(location).Error
+}
+
+func (r *LocationRepositoryImpl) GetByID(ctx context.Context, locationID valueobject.LocationID) (entity.Location, error) {
+	return getLocation(r.db, "`locations`.id = ?", locationID)
+}
+
+func (r *LocationRepositoryImpl) GetByCode(ctx context.Context, code string) (entity.Location, error) {
+	return getLocation(r.db, "`locations`.code = ?", code)
+}
+
+func (r *LocationRepositoryImpl) List(ctx context.Context) ([]entity.Location, error) {
+	var locations []entity.Location
+	err := r.db.Order("`locations`.id").Find(&locations).Error
+	return locations, err
+}
+
+func getLocation(db *gorm.DB, query string, args ...interface{}) (entity.Location, error) {
+	var location entity.Location
+	err := db.Take(&location, append([]interface{}{query}, args...)...).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Location{}, nil
+		}
+		return entity.Location{}, err
+	}
+
+	return location, nil
+}
@@ -0,0 +1,81 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// LocationStockRepositoryImpl location stock repository implementation
+type LocationStockRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewLocationStockRepositoryImpl() repository.LocationStockRepository {
+	return &LocationStockRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *LocationStockRepositoryImpl) AssignTx(txm repository.TransactionManager) {
+	tx := txm.GetTx().(*gorm.DB)
+	r.db = tx
+}
+
+func (r *LocationStockRepositoryImpl) Create(ctx context.Context, stock *entity.LocationStock) error {
+	return r.db.Create(stock).Error
+}
+
+func (r *LocationStockRepositoryImpl) Updates(ctx context.Context, stock *entity.LocationStock, values map[string]interface{}) error {
+	return r.db.Model(stock).Updates(values).Error
+}
+
+func (r *LocationStockRepositoryImpl) ListByItem(ctx context.Context, itemID valueobject.ItemID) ([]entity.LocationStock, error) {
+	return listLocationStocksByItem(r.db, itemID)
+}
+
+func (r *LocationStockRepositoryImpl) ListByItems(ctx context.Context, itemIDs []valueobject.ItemID) ([]entity.LocationStock, error) {
+	var stocks []entity.LocationStock
+	err := r.db.
+		Where("`location_stocks`.item_id IN ?", itemIDs).
+		Order("`location_stocks`.item_id, `location_stocks`.location_id").
+		Find(&stocks).Error
+	return stocks, err
+}
+
+func (r *LocationStockRepositoryImpl) ListByItemForUpdate(ctx context.Context, itemID valueobject.ItemID) ([]entity.LocationStock, error) {
+	return listLocationStocksByItem(r.db.Clauses(clause.Locking{Strength: "UPDATE"}), itemID)
+}
+
+func (r *LocationStockRepositoryImpl) GetForUpdate(
+	ctx context.Context,
+	itemID valueobject.ItemID,
+	locationID valueobject.LocationID,
+) (entity.LocationStock, error) {
+	var stock entity.LocationStock
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Take(&stock, "`location_stocks`.item_id = ? AND `location_stocks`.location_id = ?", itemID, locationID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.LocationStock{}, nil
+		}
+		return entity.LocationStock{}, err
+	}
+
+	return stock, nil
+}
+
+func listLocationStocksByItem(db *gorm.DB, itemID valueobject.ItemID) ([]entity.LocationStock, error) {
+	var stocks []entity.LocationStock
+	err := db.
+		Where("`location_stocks`.item_id = ?", itemID).
+		Order("`location_stocks`.location_id").
+		Find(&stocks).Error
+	return stocks, err
+}
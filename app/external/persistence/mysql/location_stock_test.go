@@ -0,0 +1,78 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/utils/testsupport"
+)
+
+func TestLocationStockRepositoryImpl_ListByItems(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `location_stocks` WHERE `location_stocks`.item_id IN (?,?) ORDER BY `location_stocks`.item_id, `location_stocks`.location_id")
+		mock.ExpectQuery(query).WithArgs(uint64(1), uint64(2)).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "item_id", "location_id", "current_stock_value"}).
+				AddRow(1, 1, 1, 3).
+				AddRow(2, 2, 1, 4),
+		)
+
+		repo := LocationStockRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.ListByItems(context.Background(), []valueobject.ItemID{1, 2})
+		if err != nil {
+			t.Errorf("repo.ListByItems() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := []entity.LocationStock{
+			{ID: 1, ItemID: 1, LocationID: 1, CurrentStockValue: 3},
+			{ID: 2, ItemID: 2, LocationID: 1, CurrentStockValue: 4},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestLocationStockRepositoryImpl_GetForUpdate(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `location_stocks` WHERE `location_stocks`.item_id = ? AND `location_stocks`.location_id = ? LIMIT 1 FOR UPDATE")
+		mock.ExpectQuery(query).WithArgs(uint64(1), uint64(2)).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "item_id", "location_id", "current_stock_value"}).
+				AddRow(3, 1, 2, 5),
+		)
+
+		repo := LocationStockRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetForUpdate(context.Background(), valueobject.ItemID(1), valueobject.LocationID(2))
+		if err != nil {
+			t.Errorf("repo.GetForUpdate() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := entity.LocationStock{ID: 3, ItemID: 1, LocationID: 2, CurrentStockValue: 5}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"gorm.io/gorm"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/utils/testsupport"
+)
+
+func TestLocationRepositoryImpl_GetByCode(t *testing.T) {
+	t.Run("#1: Not found location", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `locations` WHERE `locations`.code = ? LIMIT 1")
+		mock.ExpectQuery(query).WithArgs("HN-01").WillReturnError(gorm.ErrRecordNotFound)
+
+		repo := LocationRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByCode(context.Background(), "HN-01")
+		if err != nil {
+			t.Errorf("repo.GetByCode() return an error:%v - want:nil", err)
+			return
+		}
+
+		var want entity.Location
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestLocationRepositoryImpl_List(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		createdAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		query := regexp.QuoteMeta("SELECT * FROM `locations` ORDER BY `locations`.id")
+		mock.ExpectQuery(query).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "code", "name"}).
+				AddRow(1, createdAt, "HN-01", "Ha Noi warehouse").
+				AddRow(2, createdAt, "HCM-01", "Ho Chi Minh warehouse"),
+		)
+
+		repo := LocationRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.List(context.Background())
+		if err != nil {
+			t.Errorf("repo.List() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := []entity.Location{
+			{ID: 1, CreatedAt: createdAt, Code: "HN-01", Name: "Ha Noi warehouse"},
+			{ID: 2, CreatedAt: createdAt, Code: "HCM-01", Name: "Ho Chi Minh warehouse"},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
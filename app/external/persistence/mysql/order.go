@@ -0,0 +1,45 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// OrderRepositoryImpl order repository implementation
+type OrderRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewOrderRepositoryImpl() repository.OrderRepository {
+	return &OrderRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *OrderRepositoryImpl) AssignTx(txm repository.TransactionManager) {
+	tx := txm.GetTx().(*gorm.DB)
+	r.db = tx
+}
+
+func (r *OrderRepositoryImpl) Create(ctx context.Context, order *entity.Order) error {
+	return r.db.Create(order).Error
+}
+
+func (r *OrderRepositoryImpl) GetByID(ctx context.Context, orderID valueobject.OrderID) (entity.Order, error) {
+	var order entity.Order
+	err := r.db.Preload("Lines").Take(&order, "`orders`.id = ?", orderID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Order{}, nil
+		}
+		return entity.Order{}, err
+	}
+
+	return order, nil
+}
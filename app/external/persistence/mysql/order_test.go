@@ -0,0 +1,145 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/utils/testsupport"
+)
+
+func TestOrderRepositoryImpl_Create(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		order := entity.Order{
+			TotalPrice: decimal.NewFromInt(3),
+			Lines: []entity.OrderLine{
+				{
+					ItemID:     valueobject.ItemID(2),
+					PurchaseID: valueobject.PurchaseID(3),
+					Quantity:   2,
+					UnitPrice:  decimal.NewFromFloat(1.5),
+				},
+			},
+		}
+
+		insertOrderQuery := regexp.QuoteMeta("INSERT INTO `orders` (`created_at`,`total_price`) VALUES (?,?)")
+		insertLineQuery := regexp.QuoteMeta("INSERT INTO `order_lines` (`order_id`,`item_id`,`purchase_id`,`quantity`,`unit_price`) VALUES (?,?,?,?,?)")
+		mock.ExpectBegin()
+		mock.ExpectExec(insertOrderQuery).WillReturnResult(
+			sqlmock.NewResult(1, 1),
+		)
+		mock.ExpectExec(insertLineQuery).WithArgs(uint64(1), uint64(2), uint64(3), uint64(2), sqlmock.AnyArg()).WillReturnResult(
+			sqlmock.NewResult(1, 1),
+		)
+		mock.ExpectCommit()
+
+		repo := OrderRepositoryImpl{
+			db: db,
+		}
+
+		err = repo.Create(context.Background(), &order)
+		if err != nil {
+			t.Errorf("repo.Create() return an error:%v - want:nil", err)
+			return
+		}
+
+		if order.ID == 0 {
+			t.Errorf("ID of a new Order must be different zero:%d", order.ID)
+			return
+		}
+
+		if order.Lines[0].OrderID != order.ID {
+			t.Errorf("OrderID of line:%d - want:%d", order.Lines[0].OrderID, order.ID)
+		}
+	})
+}
+
+func TestOrderRepositoryImpl_GetByID(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		orderQuery := regexp.QuoteMeta("SELECT * FROM `orders` WHERE `orders`.id = ?")
+		mock.ExpectQuery(orderQuery).WithArgs(uint64(1)).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "total_price"}).
+				AddRow(1, time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local), "3.00"),
+		)
+		lineQuery := regexp.QuoteMeta("SELECT * FROM `order_lines` WHERE `order_lines`.`order_id` = ?")
+		mock.ExpectQuery(lineQuery).WithArgs(uint64(1)).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "order_id", "item_id", "purchase_id", "quantity", "unit_price"}).
+				AddRow(1, 1, 2, 3, 2, "1.50"),
+		)
+
+		repo := OrderRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByID(context.Background(), valueobject.OrderID(1))
+		if err != nil {
+			t.Errorf("repo.GetByID() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := entity.Order{
+			ID:         1,
+			CreatedAt:  time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalPrice: decimal.NewFromInt(3),
+			Lines: []entity.OrderLine{
+				{
+					ID:         1,
+					OrderID:    1,
+					ItemID:     2,
+					PurchaseID: 3,
+					Quantity:   2,
+					UnitPrice:  decimal.NewFromFloat(1.5),
+				},
+			},
+		}
+		if diff := cmp.Diff(got, want, cmp.Comparer(func(x, y decimal.Decimal) bool {
+			return x.Equal(y)
+		})); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Not found order", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `orders` WHERE `orders`.id = ?")
+		mock.ExpectQuery(query).WillReturnError(gorm.ErrRecordNotFound)
+
+		repo := OrderRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByID(context.Background(), valueobject.OrderID(1))
+		if err != nil {
+			t.Errorf("repo.GetByID() return an error:%v - want:nil", err)
+			return
+		}
+
+		var want entity.Order
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
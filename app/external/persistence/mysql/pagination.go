@@ -1,6 +1,8 @@
 package mysql
 
 import (
+	"fmt"
+
 	"gorm.io/gorm"
 
 	"github.com/tuanna7593/gosample/app/domain/valueobject"
@@ -17,3 +19,30 @@ func Paginate(paging valueobject.PaginationRequest) func(db *gorm.DB) *gorm.DB {
 		return db.Limit(int(paging.Limit)).Offset(int(offset))
 	}
 }
+
+// PaginateByCursor page the rows of table after the cursor in the (created_at, id) order,
+// the rows are ordered descending when desc is true. The cursor of paging must be set
+func PaginateByCursor(table string, desc bool, paging valueobject.PaginationRequest) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		createdAt := fmt.Sprintf("`%s`.created_at", table)
+		id := fmt.Sprintf("`%s`.id", table)
+		operator, direction := ">", ""
+		if desc {
+			operator, direction = "<", " DESC"
+		}
+
+		if cursor := paging.Cursor; !cursor.IsZero() {
+			db = db.Where(
+				fmt.Sprintf("%s %s ? OR (%s = ? AND %s %s ?)", createdAt, operator, createdAt, id, operator),
+				cursor.CreatedAt, cursor.CreatedAt, cursor.ID,
+			)
+		}
+
+		db = db.Order(fmt.Sprintf("%s%s, %s%s", createdAt, direction, id, direction))
+		if paging.Limit > 0 {
+			db = db.Limit(int(paging.Limit))
+		}
+
+		return db
+	}
+}
@@ -0,0 +1,40 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// ProductRepositoryImpl product repository implementation
+type ProductRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewProductRepositoryImpl() repository.ProductRepository {
+	return &ProductRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *ProductRepositoryImpl) Create(ctx context.Context, product *entity.Product) error {
+	return r.db.Create(product).Error
+}
+
+func (r *ProductRepositoryImpl) GetByID(ctx context.Context, productID valueobject.ProductID) (entity.Product, error) {
+	var product entity.Product
+	err := r.db.Take(&product, "`products`.id = ?", productID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Product{}, nil
+		}
+		return entity.Product{}, err
+	}
+
+	return product, nil
+}
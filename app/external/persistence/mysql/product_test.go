@@ -0,0 +1,77 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/utils/testsupport"
+)
+
+func TestProductRepositoryImpl_GetByID(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		createdAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		query := regexp.QuoteMeta("SELECT * FROM `products` WHERE `products`.id = ? LIMIT 1")
+		mock.ExpectQuery(query).WithArgs(valueobject.ProductID(1)).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "name", "description"}).
+				AddRow(1, createdAt, "T-shirt", "Cotton"),
+		)
+
+		repo := ProductRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByID(context.Background(), valueobject.ProductID(1))
+		if err != nil {
+			t.Errorf("repo.GetByID() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := entity.Product{
+			ID:          1,
+			CreatedAt:   createdAt,
+			Name:        "T-shirt",
+			Description: "Cotton",
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Not found product", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `products` WHERE `products`.id = ? LIMIT 1")
+		mock.ExpectQuery(query).WithArgs(valueobject.ProductID(1)).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "name", "description"}),
+		)
+
+		repo := ProductRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByID(context.Background(), valueobject.ProductID(1))
+		if err != nil {
+			t.Errorf("repo.GetByID() return an error:%v - want:nil", err)
+			return
+		}
+
+		if diff := cmp.Diff(got, entity.Product{}); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
@@ -2,11 +2,14 @@ package mysql
 
 import (
 	"context"
+	"errors"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/tuanna7593/gosample/app/domain/entity"
 	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
 )
 
 // PurchaseRepositoryImpl purchase repository implementation
@@ -28,3 +31,85 @@ func (r *PurchaseRepositoryImpl) AssignTx(txm repository.TransactionManager) {
 func (r *PurchaseRepositoryImpl) Create(ctx context.Context, purchase *entity.Purchase) error {
 	return r.db.Create(purchase).Error
 }
+
+func (r *PurchaseRepositoryImpl) Updates(ctx context.Context, purchase *entity.Purchase, values map[string]interface{}) error {
+	return r.db.Model(purchase).Updates(values).Error
+}
+
+// List get purchases matched the filter, the newest purchases come first
+func (r *PurchaseRepositoryImpl) List(
+	ctx context.Context,
+	filter valueobject.PurchaseFilter,
+	pagination valueobject.PaginationRequest,
+) ([]entity.Purchase, error) {
+	db := r.db.Scopes(filterPurchase(filter))
+	if pagination.Cursor != nil {
+		db = db.Scopes(PaginateByCursor("purchases", true, pagination))
+	} else {
+		db = db.Scopes(Paginate(pagination)).Order("`purchases`.created_at DESC, `purchases`.id DESC")
+	}
+
+	var purchases []entity.Purchase
+	err := db.Find(&purchases).Error
+	return purchases, err
+}
+
+// Count count the purchases matched the filter
+func (r *PurchaseRepositoryImpl) Count(ctx context.Context, filter valueobject.PurchaseFilter) (int64, error) {
+	var total int64
+	err := r.db.Model(&entity.Purchase{}).
+		Scopes(filterPurchase(filter)).
+		Count(&total).Error
+	return total, err
+}
+
+func (r *PurchaseRepositoryImpl) GetByID(ctx context.Context, purchaseID valueobject.PurchaseID) (entity.Purchase, error) {
+	return getPurchaseByID(r.db, purchaseID)
+}
+
+// GetByIDForUpdate get a purchase by id with SELECT ... FOR UPDATE,
+// it must be called in a transaction to prevent the concurrent refunds
+func (r *PurchaseRepositoryImpl) GetByIDForUpdate(ctx context.Context, purchaseID valueobject.PurchaseID) (entity.Purchase, error) {
+	return getPurchaseByID(r.db.Clauses(clause.Locking{Strength: "UPDATE"}), purchaseID)
+}
+
+func (r *PurchaseRepositoryImpl) ListBackorderedByItemForUpdate(ctx context.Context, itemID valueobject.ItemID) ([]entity.Purchase, error) {
+	var purchases []entity.Purchase
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("`purchases`.item_id = ? AND `purchases`.status = ?", itemID, valueobject.PurchaseStatusBackordered).
+		Order("`purchases`.id").
+		Find(&purchases).Error
+	return purchases, err
+}
+
+func getPurchaseByID(db *gorm.DB, purchaseID valueobject.PurchaseID) (entity.Purchase, error) {
+	var purchase entity.Purchase
+	err := db.Take(&purchase, "`purchases`.id = ?", purchaseID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Purchase{}, nil
+		}
+		return entity.Purchase{}, err
+	}
+
+	return purchase, nil
+}
+
+// filterPurchase apply the conditions of purchase filter
+func filterPurchase(filter valueobject.PurchaseFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.ItemID != 0 {
+			db = db.Where("`purchases`.item_id = ?", filter.ItemID)
+		}
+
+		if !filter.CreatedFrom.IsZero() {
+			db = db.Where("`purchases`.created_at >= ?", filter.CreatedFrom)
+		}
+
+		if !filter.CreatedTo.IsZero() {
+			db = db.Where("`purchases`.created_at <= ?", filter.CreatedTo)
+		}
+
+		return db
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"gorm.io/gorm"
 
 	"github.com/tuanna7593/gosample/app/domain/entity"
 	"github.com/tuanna7593/gosample/app/domain/valueobject"
@@ -28,7 +30,7 @@ func TestPurchaseRepositoryImpl_Create(t *testing.T) {
 			Quantity: 2,
 		}
 
-		insertQuery := regexp.QuoteMeta("INSERT INTO `purchases` (`created_at`,`item_id`,`quantity`) VALUES (?,?,?)")
+		insertQuery := regexp.QuoteMeta("INSERT INTO `purchases` (`created_at`,`item_id`,`quantity`,`refunded_quantity`,`refunded_at`,`status`,`fulfilled_at`) VALUES (?,?,?,?,?,?,?)")
 		mock.ExpectBegin()
 		mock.ExpectExec(insertQuery).WillReturnResult(
 			sqlmock.NewResult(1, 1),
@@ -80,7 +82,7 @@ func TestPurchaseRepositoryImpl_Create(t *testing.T) {
 		}
 
 		wannaErr := errors.New("failed to create purchase")
-		insertQuery := regexp.QuoteMeta("INSERT INTO `purchases` (`created_at`,`item_id`,`quantity`) VALUES (?,?,?)")
+		insertQuery := regexp.QuoteMeta("INSERT INTO `purchases` (`created_at`,`item_id`,`quantity`,`refunded_quantity`,`refunded_at`,`status`,`fulfilled_at`) VALUES (?,?,?,?,?,?,?)")
 		mock.ExpectBegin()
 		mock.ExpectExec(insertQuery).WillReturnError(wannaErr)
 		mock.ExpectRollback()
@@ -96,3 +98,417 @@ func TestPurchaseRepositoryImpl_Create(t *testing.T) {
 		}
 	})
 }
+
+func TestPurchaseRepositoryImpl_Updates(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		purchase := entity.Purchase{
+			ID:        valueobject.PurchaseID(1),
+			CreatedAt: time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			ItemID:    valueobject.ItemID(1),
+			Quantity:  3,
+		}
+		updateValues := map[string]interface{}{
+			"refunded_quantity": uint64(2),
+		}
+
+		updateQuery := regexp.QuoteMeta("UPDATE `purchases` SET `refunded_quantity`=? WHERE `id` = ?")
+		mock.ExpectBegin()
+		mock.ExpectExec(updateQuery).WithArgs(uint64(2), uint64(1)).WillReturnResult(
+			sqlmock.NewResult(1, 1),
+		)
+		mock.ExpectCommit()
+
+		repo := PurchaseRepositoryImpl{
+			db: db,
+		}
+
+		err = repo.Updates(context.Background(), &purchase, updateValues)
+		if err != nil {
+			t.Errorf("repo.Updates() return an error:%v - want:nil", err)
+			return
+		}
+
+		if purchase.RefundedQuantity != 2 {
+			t.Errorf("RefundedQuantity of purchase is %d - want:2", purchase.RefundedQuantity)
+		}
+	})
+
+	t.Run("#2: Failed to update purchase", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		purchase := entity.Purchase{
+			ID: valueobject.PurchaseID(1),
+		}
+		updateValues := map[string]interface{}{
+			"refunded_quantity": uint64(2),
+		}
+
+		wannaErr := errors.New("failed to update")
+		updateQuery := regexp.QuoteMeta("UPDATE `purchases` SET `refunded_quantity`=? WHERE `id` = ?")
+		mock.ExpectBegin()
+		mock.ExpectExec(updateQuery).WithArgs(uint64(2), uint64(1)).WillReturnError(wannaErr)
+		mock.ExpectRollback()
+
+		repo := PurchaseRepositoryImpl{
+			db: db,
+		}
+
+		err = repo.Updates(context.Background(), &purchase, updateValues)
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("repo.Updates() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+}
+
+func TestPurchaseRepositoryImpl_List(t *testing.T) {
+	t.Run("#1: List without filter", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+		repo := PurchaseRepositoryImpl{
+			db: db,
+		}
+
+		selectQuery := regexp.QuoteMeta("SELECT * FROM `purchases` ORDER BY `purchases`.created_at DESC, `purchases`.id DESC LIMIT 5 OFFSET 5")
+		mock.ExpectQuery(selectQuery).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "item_id", "quantity"}).
+				AddRow(2, time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local), 1, 3).
+				AddRow(1, time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local), 2, 1),
+		)
+
+		got, err := repo.List(
+			context.Background(),
+			valueobject.PurchaseFilter{},
+			valueobject.PaginationRequest{Page: 2, Limit: 5},
+		)
+		if err != nil {
+			t.Errorf("repo.List() return an error:%v - want: nil", err)
+			return
+		}
+
+		want := []entity.Purchase{
+			{
+				ID:        2,
+				CreatedAt: time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local),
+				ItemID:    1,
+				Quantity:  3,
+			},
+			{
+				ID:        1,
+				CreatedAt: time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+				ItemID:    2,
+				Quantity:  1,
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: List with filter", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+		repo := PurchaseRepositoryImpl{
+			db: db,
+		}
+
+		from := time.Date(2021, 10, 16, 0, 0, 0, 0, time.Local)
+		to := time.Date(2021, 10, 18, 0, 0, 0, 0, time.Local)
+		selectQuery := regexp.QuoteMeta(
+			"SELECT * FROM `purchases` WHERE `purchases`.item_id = ? AND `purchases`.created_at >= ? " +
+				"AND `purchases`.created_at <= ? ORDER BY `purchases`.created_at DESC, `purchases`.id DESC LIMIT 5",
+		)
+		mock.ExpectQuery(selectQuery).WithArgs(uint64(1), from, to).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "item_id", "quantity"}).
+				AddRow(2, time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local), 1, 3),
+		)
+
+		got, err := repo.List(
+			context.Background(),
+			valueobject.PurchaseFilter{
+				ItemID:      valueobject.ItemID(1),
+				CreatedFrom: from,
+				CreatedTo:   to,
+			},
+			valueobject.PaginationRequest{Page: 1, Limit: 5},
+		)
+		if err != nil {
+			t.Errorf("repo.List() return an error:%v - want: nil", err)
+			return
+		}
+
+		want := []entity.Purchase{
+			{
+				ID:        2,
+				CreatedAt: time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local),
+				ItemID:    1,
+				Quantity:  3,
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#3: Failed when get purchases", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+		repo := PurchaseRepositoryImpl{
+			db: db,
+		}
+
+		wannaErr := errors.New("failed to get purchases")
+		selectQuery := regexp.QuoteMeta("SELECT * FROM `purchases`")
+		mock.ExpectQuery(selectQuery).WillReturnError(wannaErr)
+
+		got, err := repo.List(context.Background(), valueobject.PurchaseFilter{}, valueobject.PaginationRequest{})
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("repo.List() return an error:%v - want:%v", err, wannaErr)
+			return
+		}
+
+		if len(got) != 0 {
+			t.Errorf("repo.List() return %d purchases - want:0", len(got))
+		}
+	})
+
+	t.Run("#4: List after cursor", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+		repo := PurchaseRepositoryImpl{
+			db: db,
+		}
+
+		cursorCreatedAt := time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local)
+		selectQuery := regexp.QuoteMeta(
+			"SELECT * FROM `purchases` WHERE `purchases`.item_id = ? AND " +
+				"(`purchases`.created_at < ? OR (`purchases`.created_at = ? AND `purchases`.id < ?)) " +
+				"ORDER BY `purchases`.created_at DESC, `purchases`.id DESC LIMIT 2",
+		)
+		mock.ExpectQuery(selectQuery).WithArgs(uint64(1), cursorCreatedAt, cursorCreatedAt, uint64(5)).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "item_id", "quantity"}).
+				AddRow(4, time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local), 1, 3),
+		)
+
+		got, err := repo.List(
+			context.Background(),
+			valueobject.PurchaseFilter{ItemID: valueobject.ItemID(1)},
+			valueobject.PaginationRequest{
+				Limit:  2,
+				Cursor: &valueobject.Cursor{CreatedAt: cursorCreatedAt, ID: 5},
+			},
+		)
+		if err != nil {
+			t.Errorf("repo.List() return an error:%v - want: nil", err)
+			return
+		}
+
+		want := []entity.Purchase{
+			{
+				ID:        4,
+				CreatedAt: time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local),
+				ItemID:    1,
+				Quantity:  3,
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestPurchaseRepositoryImpl_Count(t *testing.T) {
+	t.Run("#1: Failed to count purchases", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT count(*) FROM `purchases`")
+		mock.ExpectQuery(query).WillReturnError(errors.New("failed to count"))
+
+		repo := PurchaseRepositoryImpl{
+			db: db,
+		}
+		_, err = repo.Count(context.Background(), valueobject.PurchaseFilter{})
+		if err == nil {
+			t.Errorf("repo.Count() return nil error - want an error")
+		}
+	})
+
+	t.Run("#2: Count the purchases matched the filter", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT count(*) FROM `purchases` WHERE `purchases`.item_id = ?")
+		mock.ExpectQuery(query).WithArgs(uint64(1)).WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(3))
+
+		repo := PurchaseRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.Count(context.Background(), valueobject.PurchaseFilter{ItemID: valueobject.ItemID(1)})
+		if err != nil {
+			t.Errorf("repo.Count() return an error:%v - want:nil", err)
+			return
+		}
+
+		if got != 3 {
+			t.Errorf("repo.Count() return:%d - want:3", got)
+		}
+	})
+}
+
+func TestPurchaseRepositoryImpl_GetByID(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `purchases` WHERE `purchases`.id = ?")
+		mock.ExpectQuery(query).WithArgs(uint64(1)).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "item_id", "quantity"}).
+				AddRow(1, time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local), 2, 3),
+		)
+
+		repo := PurchaseRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByID(context.Background(), valueobject.PurchaseID(1))
+		if err != nil {
+			t.Errorf("repo.GetByID() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := entity.Purchase{
+			ID:        1,
+			CreatedAt: time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			ItemID:    2,
+			Quantity:  3,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Not found purchase", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `purchases` WHERE `purchases`.id = ?")
+		mock.ExpectQuery(query).WillReturnError(gorm.ErrRecordNotFound)
+
+		repo := PurchaseRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByID(context.Background(), valueobject.PurchaseID(1))
+		if err != nil {
+			t.Errorf("repo.GetByID() return an error:%v - want:nil", err)
+			return
+		}
+
+		var want entity.Purchase
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestPurchaseRepositoryImpl_GetByIDForUpdate(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `purchases` WHERE `purchases`.id = ? LIMIT 1 FOR UPDATE")
+		mock.ExpectQuery(query).WithArgs(uint64(1)).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "created_at", "item_id", "quantity", "refunded_quantity"}).
+				AddRow(1, time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local), 2, 3, 1),
+		)
+
+		repo := PurchaseRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByIDForUpdate(context.Background(), valueobject.PurchaseID(1))
+		if err != nil {
+			t.Errorf("repo.GetByIDForUpdate() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := entity.Purchase{
+			ID:               1,
+			CreatedAt:        time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			ItemID:           2,
+			Quantity:         3,
+			RefundedQuantity: 1,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestPurchaseRepositoryImpl_ListBackorderedByItemForUpdate(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `purchases` WHERE `purchases`.item_id = ? AND `purchases`.status = ? ORDER BY `purchases`.id FOR UPDATE")
+		mock.ExpectQuery(query).WithArgs(uint64(2), valueobject.PurchaseStatusBackordered).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "item_id", "quantity", "status"}).
+				AddRow(1, 2, 3, "backordered").
+				AddRow(4, 2, 1, "backordered"),
+		)
+
+		repo := PurchaseRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.ListBackorderedByItemForUpdate(context.Background(), valueobject.ItemID(2))
+		if err != nil {
+			t.Errorf("repo.ListBackorderedByItemForUpdate() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := []entity.Purchase{
+			{ID: 1, ItemID: 2, Quantity: 3, Status: valueobject.PurchaseStatusBackordered},
+			{ID: 4, ItemID: 2, Quantity: 1, Status: valueobject.PurchaseStatusBackordered},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
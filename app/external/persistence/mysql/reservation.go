@@ -0,0 +1,71 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// ReservationRepositoryImpl reservation repository implementation
+type ReservationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReservationRepositoryImpl() repository.ReservationRepository {
+	return &ReservationRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *ReservationRepositoryImpl) AssignTx(txm repository.TransactionManager) {
+	tx := txm.GetTx().(*gorm.DB)
+	r.db = tx
+}
+
+func (r *ReservationRepositoryImpl) Create(ctx context.Context, reservation *entity.Reservation) error {
+	return r.db.Create(reservation).Error
+}
+
+func (r *ReservationRepositoryImpl) Updates(ctx context.Context, reservation *entity.Reservation, values map[string]interface{}) error {
+	return r.db.Model(reservation).Updates(values).Error
+}
+
+func (r *ReservationRepositoryImpl) GetByID(ctx context.Context, reservationID valueobject.ReservationID) (entity.Reservation, error) {
+	return getReservationByID(r.db, reservationID)
+}
+
+// GetByIDForUpdate get a reservation by id with SELECT ... FOR UPDATE,
+// it must be called in a transaction
+func (r *ReservationRepositoryImpl) GetByIDForUpdate(ctx context.Context, reservationID valueobject.ReservationID) (entity.Reservation, error) {
+	return getReservationByID(r.db.Clauses(clause.Locking{Strength: "UPDATE"}), reservationID)
+}
+
+func (r *ReservationRepositoryImpl) ListExpired(ctx context.Context, now time.Time, limit int) ([]entity.Reservation, error) {
+	var reservations []entity.Reservation
+	err := r.db.
+		Where("`reservations`.status = ? AND `reservations`.expires_at <= ?", valueobject.ReservationStatusHeld, now).
+		Order("`reservations`.expires_at").
+		Limit(limit).
+		Find(&reservations).Error
+	return reservations, err
+}
+
+func getReservationByID(db *gorm.DB, reservationID valueobject.ReservationID) (entity.Reservation, error) {
+	var reservation entity.Reservation
+	err := db.Take(&reservation, "`reservations`.id = ?", reservationID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Reservation{}, nil
+		}
+		return entity.Reservation{}, err
+	}
+
+	return reservation, nil
+}
@@ -0,0 +1,121 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"gorm.io/gorm"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/utils/testsupport"
+)
+
+func TestReservationRepositoryImpl_Create(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		reservation := entity.Reservation{
+			ItemID:    valueobject.ItemID(1),
+			Quantity:  2,
+			Status:    valueobject.ReservationStatusHeld,
+			ExpiresAt: time.Date(2021, 10, 16, 10, 15, 0, 0, time.Local),
+		}
+
+		insertQuery := regexp.QuoteMeta("INSERT INTO `reservations` (`created_at`,`item_id`,`quantity`,`status`,`expires_at`,`purchase_id`) VALUES (?,?,?,?,?,?)")
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).WillReturnResult(
+			sqlmock.NewResult(1, 1),
+		)
+		mock.ExpectCommit()
+
+		repo := ReservationRepositoryImpl{
+			db: db,
+		}
+
+		err = repo.Create(context.Background(), &reservation)
+		if err != nil {
+			t.Errorf("repo.Create() return an error:%v - want:nil", err)
+			return
+		}
+
+		if reservation.ID == 0 {
+			t.Errorf("ID of a new Reservation must be different zero:%d", reservation.ID)
+		}
+	})
+}
+
+func TestReservationRepositoryImpl_GetByIDForUpdate(t *testing.T) {
+	t.Run("#1: Not found reservation", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `reservations` WHERE `reservations`.id = ? LIMIT 1 FOR UPDATE")
+		mock.ExpectQuery(query).WillReturnError(gorm.ErrRecordNotFound)
+
+		repo := ReservationRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByIDForUpdate(context.Background(), valueobject.ReservationID(1))
+		if err != nil {
+			t.Errorf("repo.GetByIDForUpdate() return an error:%v - want:nil", err)
+			return
+		}
+
+		var want entity.Reservation
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestReservationRepositoryImpl_ListExpired(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		now := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		expiresAt := time.Date(2021, 10, 16, 9, 0, 0, 0, time.Local)
+		query := regexp.QuoteMeta("SELECT * FROM `reservations` WHERE `reservations`.status = ? AND `reservations`.expires_at <= ? ORDER BY `reservations`.expires_at LIMIT 10")
+		mock.ExpectQuery(query).WithArgs(valueobject.ReservationStatusHeld, now).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "item_id", "quantity", "status", "expires_at"}).
+				AddRow(1, 2, 3, "held", expiresAt),
+		)
+
+		repo := ReservationRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.ListExpired(context.Background(), now, 10)
+		if err != nil {
+			t.Errorf("repo.ListExpired() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := []entity.Reservation{
+			{
+				ID:        1,
+				ItemID:    2,
+				Quantity:  3,
+				Status:    valueobject.ReservationStatusHeld,
+				ExpiresAt: expiresAt,
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
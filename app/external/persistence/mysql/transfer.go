@@ -0,0 +1,60 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// TransferRepositoryImpl transfer repository implementation
+type TransferRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTransferRepositoryImpl() repository.TransferRepository {
+	return &TransferRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *TransferRepositoryImpl) AssignTx(txm repository.TransactionManager) {
+	tx := txm.GetTx().(*gorm.DB)
+	r.db = tx
+}
+
+func (r *TransferRepositoryImpl) Create(ctx context.Context, transfer *entity.Transfer) error {
+	return r.db.Create(transfer).Error
+}
+
+func (r *TransferRepositoryImpl) Updates(ctx context.Context, transfer *entity.Transfer, values map[string]interface{}) error {
+	return r.db.Model(transfer).Updates(values).Error
+}
+
+func (r *TransferRepositoryImpl) GetByID(ctx context.Context, transferID valueobject.TransferID) (entity.Transfer, error) {
+	return getTransferByID(r.db, transferID)
+}
+
+// GetByIDForUpdate get a transfer by id with SELECT ... FOR UPDATE,
+// it must be called in a transaction
+func (r *TransferRepositoryImpl) GetByIDForUpdate(ctx context.Context, transferID valueobject.TransferID) (entity.Transfer, error) {
+	return getTransferByID(r.db.Clauses(clause.Locking{Strength: "UPDATE"}), transferID)
+}
+
+func getTransferByID(db *gorm.DB, transferID valueobject.TransferID) (entity.Transfer, error) {
+	var transfer entity.Transfer
+	err := db.Take(&transfer, "`transfers`.id = ?", transferID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Transfer{}, nil
+		}
+		return entity.Transfer{}, err
+	}
+
+	return transfer, nil
+}
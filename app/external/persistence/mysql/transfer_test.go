@@ -0,0 +1,116 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"gorm.io/gorm"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/utils/testsupport"
+)
+
+func TestTransferRepositoryImpl_Create(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		transfer := entity.Transfer{
+			ItemID:         valueobject.ItemID(1),
+			FromLocationID: valueobject.LocationID(2),
+			ToLocationID:   valueobject.LocationID(3),
+			Quantity:       4,
+			Status:         valueobject.TransferStatusInTransit,
+		}
+
+		insertQuery := regexp.QuoteMeta("INSERT INTO `transfers` (`created_at`,`item_id`,`from_location_id`,`to_location_id`,`quantity`,`status`,`received_at`) VALUES (?,?,?,?,?,?,?)")
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).WillReturnResult(
+			sqlmock.NewResult(1, 1),
+		)
+		mock.ExpectCommit()
+
+		repo := TransferRepositoryImpl{
+			db: db,
+		}
+
+		err = repo.Create(context.Background(), &transfer)
+		if err != nil {
+			t.Errorf("repo.Create() return an error:%v - want:nil", err)
+			return
+		}
+
+		if transfer.ID == 0 {
+			t.Errorf("ID of a new Transfer must be different zero:%d", transfer.ID)
+		}
+	})
+}
+
+func TestTransferRepositoryImpl_GetByIDForUpdate(t *testing.T) {
+	t.Run("#1: Not found transfer", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `transfers` WHERE `transfers`.id = ? LIMIT 1 FOR UPDATE")
+		mock.ExpectQuery(query).WillReturnError(gorm.ErrRecordNotFound)
+
+		repo := TransferRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByIDForUpdate(context.Background(), valueobject.TransferID(1))
+		if err != nil {
+			t.Errorf("repo.GetByIDForUpdate() return an error:%v - want:nil", err)
+			return
+		}
+
+		var want entity.Transfer
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Success", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := testsupport.OpenDBConnection()
+		if err != nil {
+			panic(err)
+		}
+
+		query := regexp.QuoteMeta("SELECT * FROM `transfers` WHERE `transfers`.id = ? LIMIT 1 FOR UPDATE")
+		mock.ExpectQuery(query).WithArgs(valueobject.TransferID(1)).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "item_id", "from_location_id", "to_location_id", "quantity", "status"}).
+				AddRow(1, 2, 3, 4, 5, "in_transit"),
+		)
+
+		repo := TransferRepositoryImpl{
+			db: db,
+		}
+		got, err := repo.GetByIDForUpdate(context.Background(), valueobject.TransferID(1))
+		if err != nil {
+			t.Errorf("repo.GetByIDForUpdate() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := entity.Transfer{
+			ID:             1,
+			ItemID:         2,
+			FromLocationID: 3,
+			ToLocationID:   4,
+			Quantity:       5,
+			Status:         valueobject.TransferStatusInTransit,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
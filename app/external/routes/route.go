@@ -6,10 +6,14 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
+	"github.com/tuanna7593/gosample/app/config"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
 	"github.com/tuanna7593/gosample/app/interface/restapi/handler"
+	restmiddleware "github.com/tuanna7593/gosample/app/interface/restapi/middleware"
 )
 
-func Handler() http.Handler {
+func Handler(cfg *config.Config, stockAlertNotifier repository.StockAlertNotifier) http.Handler {
 	r := chi.NewRouter()
 
 	// base middleware stack
@@ -19,12 +23,89 @@ func Handler() http.Handler {
 	r.Use(middleware.Recoverer)
 
 	// init handler
-	itemHandler := handler.NewItemHandler()
+	allocationStrategy := valueobject.AllocationStrategy(cfg.Location.AllocationStrategy)
+	itemHandler := handler.NewItemHandler(allocationStrategy, stockAlertNotifier)
+	purchaseHandler := handler.NewPurchaseHandler(allocationStrategy)
+	orderHandler := handler.NewOrderHandler(allocationStrategy, stockAlertNotifier)
+	reservationHandler := handler.NewReservationHandler(allocationStrategy)
+	inventoryHandler := handler.NewInventoryHandler(allocationStrategy)
+	locationHandler := handler.NewLocationHandler()
+	transferHandler := handler.NewTransferHandler(allocationStrategy)
+	categoryHandler := handler.NewCategoryHandler()
+	itemAttributeHandler := handler.NewItemAttributeHandler()
+	productHandler := handler.NewProductHandler()
+	bundleHandler := handler.NewBundleHandler(allocationStrategy, stockAlertNotifier)
 
 	r.Route("/items", func(r chi.Router) {
-		r.Post("/", itemHandler.Create)
-		r.Post("/{item_id}", itemHandler.BuyItem)
+		r.With(restmiddleware.Idempotency).Post("/", itemHandler.Create)
+		r.With(restmiddleware.Idempotency).Post("/{item_id}", itemHandler.BuyItem)
 		r.Get("/", itemHandler.List)
+		r.Get("/search", itemHandler.Search)
+		r.Get("/sku/{sku}", itemHandler.GetItemBySKU)
+		r.Get("/{item_id}", itemHandler.GetItem)
+		r.Put("/{item_id}", itemHandler.Update)
+		r.Patch("/{item_id}", itemHandler.Patch)
+		r.Delete("/{item_id}", itemHandler.Delete)
+		r.Get("/{item_id}/purchases", purchaseHandler.ListByItem)
+		r.With(restmiddleware.Idempotency).Post("/{item_id}/reservations", reservationHandler.Reserve)
+		r.With(restmiddleware.Idempotency).Post("/{item_id}/restock", inventoryHandler.Restock)
+		r.With(restmiddleware.Idempotency).Post("/{item_id}/adjustments", inventoryHandler.Adjust)
+		r.Get("/{item_id}/movements", inventoryHandler.ListMovements)
+		r.With(restmiddleware.Idempotency).Post("/{item_id}/transfers", transferHandler.Transfer)
+		r.Get("/{item_id}/categories", categoryHandler.ListByItem)
+		r.Put("/{item_id}/categories", categoryHandler.AssignItem)
+		r.Get("/{item_id}/tags", itemAttributeHandler.ListTags)
+		r.Put("/{item_id}/tags/{tag}", itemAttributeHandler.AddTag)
+		r.Delete("/{item_id}/tags/{tag}", itemAttributeHandler.RemoveTag)
+		r.Get("/{item_id}/attributes", itemAttributeHandler.ListAttributes)
+		r.Put("/{item_id}/attributes/{key}", itemAttributeHandler.SetAttribute)
+		r.Delete("/{item_id}/attributes/{key}", itemAttributeHandler.RemoveAttribute)
+	})
+
+	r.Route("/categories", func(r chi.Router) {
+		r.Post("/", categoryHandler.Create)
+		r.Get("/", categoryHandler.List)
+		r.Get("/{category_id}", categoryHandler.GetCategory)
+		r.Put("/{category_id}", categoryHandler.Update)
+		r.Delete("/{category_id}", categoryHandler.Delete)
+	})
+
+	r.Route("/products", func(r chi.Router) {
+		r.Post("/", productHandler.Create)
+		r.Get("/{product_id}", productHandler.GetProduct)
+	})
+
+	r.Route("/bundles", func(r chi.Router) {
+		r.Post("/", bundleHandler.Create)
+		r.Get("/{bundle_id}", bundleHandler.GetBundle)
+		r.With(restmiddleware.Idempotency).Post("/{bundle_id}/buy", bundleHandler.BuyBundle)
+	})
+
+	r.Route("/reservations", func(r chi.Router) {
+		r.Get("/{reservation_id}", reservationHandler.GetReservation)
+		r.With(restmiddleware.Idempotency).Post("/{reservation_id}/confirm", reservationHandler.Confirm)
+		r.Post("/{reservation_id}/cancel", reservationHandler.Cancel)
+	})
+
+	r.Route("/locations", func(r chi.Router) {
+		r.Post("/", locationHandler.Create)
+		r.Get("/", locationHandler.List)
+	})
+
+	r.Route("/transfers", func(r chi.Router) {
+		r.Get("/{transfer_id}", transferHandler.GetTransfer)
+		r.Post("/{transfer_id}/receive", transferHandler.Receive)
+	})
+
+	r.Route("/purchases", func(r chi.Router) {
+		r.Get("/", purchaseHandler.List)
+		r.Get("/{purchase_id}", purchaseHandler.GetPurchase)
+		r.Post("/{purchase_id}/refund", purchaseHandler.Refund)
+	})
+
+	r.Route("/orders", func(r chi.Router) {
+		r.With(restmiddleware.Idempotency).Post("/", orderHandler.Create)
+		r.Get("/{order_id}", orderHandler.GetOrder)
 	})
 
 	return r
@@ -0,0 +1,49 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertCreateBundleRequestToPayload(p presenter.CreateBundleRequest) payload.CreateBundleRequest {
+	components := make([]payload.BundleComponent, len(p.Components))
+	for i, component := range p.Components {
+		components[i] = payload.BundleComponent{
+			ItemID:   component.ItemID,
+			Quantity: component.Quantity,
+		}
+	}
+
+	return payload.CreateBundleRequest{
+		Name:       strings.TrimSpace(p.Name),
+		Components: components,
+	}
+}
+
+func ConvertBuyBundleRequestToPayload(bundleID valueobject.BundleID, p presenter.BuyBundleRequest) payload.BuyBundleRequest {
+	return payload.BuyBundleRequest{
+		BundleID: bundleID,
+		Quantity: p.Quantity,
+	}
+}
+
+func ConvertBundlePayloadToResponse(pl payload.Bundle) presenter.BundleResponse {
+	components := make([]presenter.BundleComponentResponse, len(pl.Components))
+	for i, component := range pl.Components {
+		components[i] = presenter.BundleComponentResponse{
+			ItemID:   component.ItemID,
+			Quantity: component.Quantity,
+		}
+	}
+
+	return presenter.BundleResponse{
+		ID:                pl.ID,
+		Name:              pl.Name,
+		Components:        components,
+		AvailableQuantity: pl.AvailableQuantity,
+		CreatedAt:         pl.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,31 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestConvertCreateBundleRequestToPayload(t *testing.T) {
+	got := ConvertCreateBundleRequestToPayload(presenter.CreateBundleRequest{
+		Name: " Starter kit ",
+		Components: []presenter.BundleComponentRequest{
+			{ItemID: valueobject.ItemID(1), Quantity: 1},
+			{ItemID: valueobject.ItemID(2), Quantity: 3},
+		},
+	})
+	want := payload.CreateBundleRequest{
+		Name: "Starter kit",
+		Components: []payload.BundleComponent{
+			{ItemID: valueobject.ItemID(1), Quantity: 1},
+			{ItemID: valueobject.ItemID(2), Quantity: 3},
+		},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
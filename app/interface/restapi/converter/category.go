@@ -0,0 +1,55 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertCategoryRequestToCreatePayload(p presenter.CategoryRequest) payload.CreateCategoryRequest {
+	return payload.CreateCategoryRequest{
+		Name:     strings.TrimSpace(p.Name),
+		ParentID: p.ParentID,
+	}
+}
+
+func ConvertCategoryRequestToUpdatePayload(
+	categoryID valueobject.CategoryID,
+	p presenter.CategoryRequest,
+) payload.UpdateCategoryRequest {
+	return payload.UpdateCategoryRequest{
+		CategoryID: categoryID,
+		Name:       strings.TrimSpace(p.Name),
+		ParentID:   p.ParentID,
+	}
+}
+
+func ConvertItemCategoriesRequestToPayload(
+	itemID valueobject.ItemID,
+	p presenter.ItemCategoriesRequest,
+) payload.ItemCategoriesRequest {
+	return payload.ItemCategoriesRequest{
+		ItemID:      itemID,
+		CategoryIDs: p.CategoryIDs,
+	}
+}
+
+func ConvertCategoryPayloadToResponse(pl payload.Category) presenter.CategoryResponse {
+	return presenter.CategoryResponse{
+		ID:        pl.ID,
+		Name:      pl.Name,
+		ParentID:  pl.ParentID,
+		CreatedAt: pl.CreatedAt.Unix(),
+	}
+}
+
+func ConvertCategoryPayloadsToResponse(pls []payload.Category) []presenter.CategoryResponse {
+	resps := make([]presenter.CategoryResponse, len(pls))
+	for i := range pls {
+		resps[i] = ConvertCategoryPayloadToResponse(pls[i])
+	}
+
+	return resps
+}
@@ -0,0 +1,44 @@
+package converter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestConvertCategoryRequestToUpdatePayload(t *testing.T) {
+	parentID := valueobject.CategoryID(1)
+	got := ConvertCategoryRequestToUpdatePayload(valueobject.CategoryID(2), presenter.CategoryRequest{
+		Name:     "  Shirts ",
+		ParentID: &parentID,
+	})
+	want := payload.UpdateCategoryRequest{
+		CategoryID: valueobject.CategoryID(2),
+		Name:       "Shirts",
+		ParentID:   &parentID,
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestConvertCategoryPayloadsToResponse(t *testing.T) {
+	parentID := valueobject.CategoryID(1)
+	createdAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+	got := ConvertCategoryPayloadsToResponse([]payload.Category{
+		{ID: valueobject.CategoryID(1), Name: "Clothes", CreatedAt: createdAt},
+		{ID: valueobject.CategoryID(2), Name: "Shirts", ParentID: &parentID, CreatedAt: createdAt},
+	})
+	want := []presenter.CategoryResponse{
+		{ID: valueobject.CategoryID(1), Name: "Clothes", CreatedAt: createdAt.Unix()},
+		{ID: valueobject.CategoryID(2), Name: "Shirts", ParentID: &parentID, CreatedAt: createdAt.Unix()},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
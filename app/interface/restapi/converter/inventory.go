@@ -0,0 +1,66 @@
+package converter
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertRestockItemRequestToPayload(
+	itemID valueobject.ItemID,
+	p presenter.RestockItemRequest,
+	expectedVersion *uint64,
+) payload.RestockRequest {
+	return payload.RestockRequest{
+		ItemID:          itemID,
+		Quantity:        p.Quantity,
+		LocationID:      p.LocationID,
+		ExpectedVersion: expectedVersion,
+	}
+}
+
+func ConvertAdjustItemRequestToPayload(
+	itemID valueobject.ItemID,
+	p presenter.AdjustItemRequest,
+	expectedVersion *uint64,
+) payload.AdjustmentRequest {
+	return payload.AdjustmentRequest{
+		ItemID:          itemID,
+		Delta:           p.Delta,
+		ReasonCode:      p.ReasonCode,
+		Actor:           strings.TrimSpace(p.Actor),
+		Note:            p.Note,
+		LocationID:      p.LocationID,
+		ExpectedVersion: expectedVersion,
+	}
+}
+
+func ConvertInventoryMovementPayloadToResponse(pl payload.InventoryMovement) presenter.InventoryMovement {
+	return presenter.InventoryMovement{
+		ID:         pl.ID,
+		ItemID:     pl.ItemID,
+		Reason:     pl.Reason,
+		Delta:      pl.Delta,
+		ReasonCode: pl.ReasonCode,
+		Actor:      pl.Actor,
+		Note:       pl.Note,
+		MovedAt:    pl.MovedAt.Unix(),
+	}
+}
+
+func ConvertInventoryMovementPayloadsToPage(
+	movements []payload.InventoryMovement,
+	pagination presenter.PaginationRequest,
+	total *int64,
+	u *url.URL,
+) presenter.PageResponse {
+	movementResp := make([]presenter.InventoryMovement, len(movements))
+	for i := range movements {
+		movementResp[i] = ConvertInventoryMovementPayloadToResponse(movements[i])
+	}
+
+	return presenter.NewPageResponse(movementResp, len(movements), pagination, total, u)
+}
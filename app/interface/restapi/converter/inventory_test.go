@@ -0,0 +1,34 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestConvertAdjustItemRequestToPayload(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		expectedVersion := uint64(3)
+		req := presenter.AdjustItemRequest{
+			Delta:      -2,
+			ReasonCode: valueobject.AdjustmentReasonCodeLost,
+			Actor:      " alice ",
+			Note:       "missing after recount",
+		}
+		got := ConvertAdjustItemRequestToPayload(valueobject.ItemID(1), req, &expectedVersion)
+		want := payload.AdjustmentRequest{
+			ItemID:          valueobject.ItemID(1),
+			Delta:           -2,
+			ReasonCode:      valueobject.AdjustmentReasonCodeLost,
+			Actor:           "alice",
+			Note:            "missing after recount",
+			ExpectedVersion: &expectedVersion,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
@@ -1,23 +1,139 @@
 package converter
 
 import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
 	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
 	"github.com/tuanna7593/gosample/app/usecase/payload"
 )
 
 func ConvertCreateItemRequestToPayload(p presenter.CreateItemRequest) payload.CreateItemRequest {
 	return payload.CreateItemRequest{
-		TotalStockValue: p.TotalStockValue,
-		SellingPrice:    p.SellingPrice,
+		ProductID:        p.ProductID,
+		SKU:              p.SKU,
+		Name:             strings.TrimSpace(p.Name),
+		Description:      p.Description,
+		TotalStockValue:  p.TotalStockValue,
+		ReorderThreshold: p.ReorderThreshold,
+		Backorderable:    p.Backorderable,
+		SellingPrice:     p.SellingPrice,
+	}
+}
+
+func ConvertItemFilterRequestToPayload(p presenter.ItemFilterRequest) payload.ItemFilter {
+	filter := payload.ItemFilter{
+		MinSellingPrice: p.MinPrice,
+		MaxSellingPrice: p.MaxPrice,
+		InStockOnly:     p.InStock,
+		ProductID:       p.ProductID,
+		CategoryID:      p.CategoryID,
+		Tags:            p.Tags,
+		Attributes:      p.Attributes,
+		Sorts:           p.Sorts,
+	}
+	if p.CreatedFrom > 0 {
+		filter.CreatedFrom = time.Unix(p.CreatedFrom, 0)
+	}
+	if p.CreatedTo > 0 {
+		filter.CreatedTo = time.Unix(p.CreatedTo, 0)
+	}
+
+	return filter
+}
+
+func ConvertUpdateItemRequestToPayload(
+	itemID valueobject.ItemID,
+	p presenter.UpdateItemRequest,
+	expectedVersion *uint64,
+) payload.UpdateItemRequest {
+	return payload.UpdateItemRequest{
+		ItemID:           itemID,
+		TotalStockValue:  p.TotalStockValue,
+		ReorderThreshold: p.ReorderThreshold,
+		Backorderable:    p.Backorderable,
+		SellingPrice:     p.SellingPrice,
+		ExpectedVersion:  expectedVersion,
 	}
 }
 
 func ConvertPayloadItemToResponse(pl payload.Item) presenter.ItemResponse {
 	return presenter.ItemResponse{
-		ID:                pl.ID,
-		PlacedAt:          pl.PlacedAt.Unix(),
-		TotalStockValue:   pl.TotalStockValue,
-		CurrentStockValue: pl.CurrentStockValue,
-		SellingPrice:      pl.SellingPrice,
+		ID:                    pl.ID,
+		ProductID:             pl.ProductID,
+		SKU:                   pl.SKU,
+		Name:                  pl.Name,
+		Description:           pl.Description,
+		PlacedAt:              pl.PlacedAt.Unix(),
+		TotalStockValue:       pl.TotalStockValue,
+		CurrentStockValue:     pl.CurrentStockValue,
+		ReservedStockValue:    pl.ReservedStockValue,
+		LocatedStockValue:     pl.LocatedStockValue,
+		UnassignedStockValue:  pl.UnassignedStockValue,
+		InTransitStockValue:   pl.InTransitStockValue,
+		BackorderedStockValue: pl.BackorderedStockValue,
+		Backorderable:         pl.Backorderable,
+		ReorderThreshold:      pl.ReorderThreshold,
+		Locations:             ConvertItemLocationStockPayloadsToResponse(pl.Locations),
+		SellingPrice:          pl.SellingPrice,
+		Version:               pl.Version,
 	}
 }
+
+func ConvertItemPayloadsToCursorPage(items []payload.Item, limit int64) presenter.CursorPage {
+	itemResp := make([]presenter.ItemResponse, len(items))
+	for i := range items {
+		itemResp[i] = ConvertPayloadItemToResponse(items[i])
+	}
+
+	var next *valueobject.Cursor
+	if len(items) > 0 {
+		last := items[len(items)-1]
+		next = convertNextCursor(len(items), limit, last.PlacedAt, uint64(last.ID))
+	}
+
+	return presenter.NewCursorPage(itemResp, next)
+}
+
+func ConvertItemPayloadsToPage(
+	items []payload.Item,
+	pagination presenter.PaginationRequest,
+	total *int64,
+	u *url.URL,
+) presenter.PageResponse {
+	itemResp := make([]presenter.ItemResponse, len(items))
+	for i := range items {
+		itemResp[i] = ConvertPayloadItemToResponse(items[i])
+	}
+
+	return presenter.NewPageResponse(itemResp, len(items), pagination, total, u)
+}
+
+func ConvertItemSearchRequestToPayload(p presenter.ItemSearchRequest) payload.ItemSearchRequest {
+	return payload.ItemSearchRequest{
+		Query: p.Query,
+	}
+}
+
+func ConvertItemSearchHitPayloadsToPage(
+	hits []payload.ItemSearchHit,
+	pagination presenter.PaginationRequest,
+	u *url.URL,
+) presenter.PageResponse {
+	hitResp := make([]presenter.ItemSearchHitResponse, len(hits))
+	for i := range hits {
+		hitResp[i] = presenter.ItemSearchHitResponse{
+			Item:  ConvertPayloadItemToResponse(hits[i].Item),
+			Score: hits[i].Score,
+			Highlight: presenter.ItemHighlightResponse{
+				Name:        hits[i].Highlight.Name,
+				Description: hits[i].Highlight.Description,
+			},
+		}
+	}
+
+	// the matched items are not counted, the next page exists when this page is full
+	return presenter.NewPageResponse(hitResp, len(hits), pagination, nil, u)
+}
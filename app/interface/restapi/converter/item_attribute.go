@@ -0,0 +1,37 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertItemAttributeRequestToPayload(
+	itemID valueobject.ItemID,
+	key string,
+	p presenter.ItemAttributeRequest,
+) payload.ItemAttributeRequest {
+	return payload.ItemAttributeRequest{
+		ItemID: itemID,
+		Key:    key,
+		Value:  strings.TrimSpace(p.Value),
+	}
+}
+
+func ConvertItemAttributePayloadToResponse(pl payload.ItemAttribute) presenter.ItemAttributeResponse {
+	return presenter.ItemAttributeResponse{
+		Key:   pl.Key,
+		Value: pl.Value,
+	}
+}
+
+func ConvertItemAttributePayloadsToResponse(pls []payload.ItemAttribute) []presenter.ItemAttributeResponse {
+	resps := make([]presenter.ItemAttributeResponse, len(pls))
+	for i := range pls {
+		resps[i] = ConvertItemAttributePayloadToResponse(pls[i])
+	}
+
+	return resps
+}
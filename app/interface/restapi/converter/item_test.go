@@ -1,6 +1,7 @@
 package converter
 
 import (
+	"net/url"
 	"testing"
 	"time"
 
@@ -16,11 +17,17 @@ func TestConvertCreateItemRequestToPayload(t *testing.T) {
 	t.Run("#1: Success", func(t *testing.T) {
 		t.Parallel()
 		presenterReq := presenter.CreateItemRequest{
+			SKU:             "TS-001",
+			Name:            " T-shirt ",
+			Description:     "Cotton",
 			TotalStockValue: 5,
 			SellingPrice:    decimal.NewFromFloat(1.55),
 		}
 		payloadReq := ConvertCreateItemRequestToPayload(presenterReq)
 		want := payload.CreateItemRequest{
+			SKU:             "TS-001",
+			Name:            "T-shirt",
+			Description:     "Cotton",
 			TotalStockValue: 5,
 			SellingPrice:    decimal.NewFromFloat(1.55),
 		}
@@ -31,24 +38,90 @@ func TestConvertCreateItemRequestToPayload(t *testing.T) {
 	})
 }
 
+func TestConvertItemFilterRequestToPayload(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		createdTo := time.Date(2021, 10, 16, 0, 0, 0, 0, time.Local)
+		minPrice := decimal.NewFromFloat(1.5)
+		req := presenter.ItemFilterRequest{
+			MinPrice:  &minPrice,
+			InStock:   true,
+			CreatedTo: createdTo.Unix(),
+			ProductID: valueobject.ProductID(1),
+			Tags:      []string{"sale"},
+			Attributes: []valueobject.ItemAttribute{
+				{Key: "color", Value: "red"},
+			},
+			Sorts: []valueobject.ItemSort{
+				{Field: valueobject.ItemSortFieldCreatedAt, Descending: true},
+			},
+		}
+		got := ConvertItemFilterRequestToPayload(req)
+		want := payload.ItemFilter{
+			MinSellingPrice: &minPrice,
+			InStockOnly:     true,
+			CreatedTo:       createdTo,
+			ProductID:       valueobject.ProductID(1),
+			Tags:            []string{"sale"},
+			Attributes: []valueobject.ItemAttribute{
+				{Key: "color", Value: "red"},
+			},
+			Sorts: []valueobject.ItemSort{
+				{Field: valueobject.ItemSortFieldCreatedAt, Descending: true},
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestConvertUpdateItemRequestToPayload(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		totalStockValue := uint64(5)
+		presenterReq := presenter.UpdateItemRequest{
+			TotalStockValue: &totalStockValue,
+			Partial:         true,
+		}
+		expectedVersion := uint64(2)
+		payloadReq := ConvertUpdateItemRequestToPayload(valueobject.ItemID(1), presenterReq, &expectedVersion)
+		want := payload.UpdateItemRequest{
+			ItemID:          valueobject.ItemID(1),
+			TotalStockValue: &totalStockValue,
+			ExpectedVersion: &expectedVersion,
+		}
+
+		if diff := cmp.Diff(payloadReq, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
 func TestConvertPayloadItemToResponse(t *testing.T) {
 	t.Run("#1: Success", func(t *testing.T) {
 		t.Parallel()
 		placedAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
 		pl := payload.Item{
 			ID:                valueobject.ItemID(1),
+			SKU:               "TS-001",
+			Name:              "T-shirt",
 			TotalStockValue:   5,
 			CurrentStockValue: 4,
 			SellingPrice:      decimal.NewFromFloat(1.55),
 			PlacedAt:          placedAt,
+			Version:           2,
 		}
 		resp := ConvertPayloadItemToResponse(pl)
 		want := presenter.ItemResponse{
 			ID:                valueobject.ItemID(1),
+			SKU:               "TS-001",
+			Name:              "T-shirt",
 			PlacedAt:          placedAt.Unix(),
 			TotalStockValue:   5,
 			CurrentStockValue: 4,
 			SellingPrice:      decimal.NewFromFloat(1.55),
+			Version:           2,
 		}
 
 		if diff := cmp.Diff(resp, want); diff != "" {
@@ -56,3 +129,97 @@ func TestConvertPayloadItemToResponse(t *testing.T) {
 		}
 	})
 }
+
+func TestConvertItemPayloadsToCursorPage(t *testing.T) {
+	t.Run("#1: Full page has the next cursor", func(t *testing.T) {
+		t.Parallel()
+		placedAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		items := []payload.Item{
+			{ID: valueobject.ItemID(1), PlacedAt: placedAt},
+			{ID: valueobject.ItemID(2), PlacedAt: placedAt},
+		}
+		got := ConvertItemPayloadsToCursorPage(items, 2)
+
+		next, err := presenter.DecodeCursor(got.NextCursor)
+		if err != nil {
+			t.Errorf("presenter.DecodeCursor() return an error:%v - want:nil", err)
+			return
+		}
+		want := valueobject.Cursor{
+			CreatedAt: placedAt,
+			ID:        2,
+		}
+		if !next.CreatedAt.Equal(want.CreatedAt) || next.ID != want.ID {
+			t.Errorf("the next cursor is:%+v - want:%+v", next, want)
+		}
+	})
+
+	t.Run("#2: Last page has no next cursor", func(t *testing.T) {
+		t.Parallel()
+		items := []payload.Item{
+			{ID: valueobject.ItemID(1), PlacedAt: time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)},
+		}
+		got := ConvertItemPayloadsToCursorPage(items, 2)
+		if got.NextCursor != "" {
+			t.Errorf("the next cursor is:%s - want empty", got.NextCursor)
+		}
+	})
+}
+
+func TestConvertItemPayloadsToPage(t *testing.T) {
+	u, err := url.Parse("/items?in_stock=true&limit=2&page=2")
+	if err != nil {
+		panic(err)
+	}
+	items := []payload.Item{
+		{ID: valueobject.ItemID(3)},
+		{ID: valueobject.ItemID(4)},
+	}
+	pagination := presenter.PaginationRequest{
+		Page:  2,
+		Limit: 2,
+	}
+
+	t.Run("#1: Middle page has the next and prev links", func(t *testing.T) {
+		t.Parallel()
+		total := int64(5)
+		got := ConvertItemPayloadsToPage(items, pagination, &total, u)
+
+		next := "/items?in_stock=true&limit=2&page=3"
+		prev := "/items?in_stock=true&limit=2&page=1"
+		want := presenter.PageResponse{
+			Data: []presenter.ItemResponse{
+				ConvertPayloadItemToResponse(items[0]),
+				ConvertPayloadItemToResponse(items[1]),
+			},
+			Page:  2,
+			Limit: 2,
+			Total: &total,
+			Next:  &next,
+			Prev:  &prev,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Last page has no next link", func(t *testing.T) {
+		t.Parallel()
+		total := int64(4)
+		got := ConvertItemPayloadsToPage(items, pagination, &total, u)
+		if got.Next != nil {
+			t.Errorf("the next link is:%s - want nil", *got.Next)
+		}
+	})
+
+	t.Run("#3: Full page has the next link when the total is skipped", func(t *testing.T) {
+		t.Parallel()
+		got := ConvertItemPayloadsToPage(items, pagination, nil, u)
+		if got.Total != nil {
+			t.Errorf("the total is:%d - want nil", *got.Total)
+		}
+		if got.Next == nil {
+			t.Errorf("the next link is nil - want a link")
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertCreateLocationRequestToPayload(p presenter.CreateLocationRequest) payload.CreateLocationRequest {
+	return payload.CreateLocationRequest{
+		Code: p.Code,
+		Name: strings.TrimSpace(p.Name),
+	}
+}
+
+func ConvertLocationPayloadToResponse(pl payload.Location) presenter.LocationResponse {
+	return presenter.LocationResponse{
+		ID:        pl.ID,
+		Code:      pl.Code,
+		Name:      pl.Name,
+		CreatedAt: pl.CreatedAt.Unix(),
+	}
+}
+
+func ConvertItemLocationStockPayloadsToResponse(pls []payload.ItemLocationStock) []presenter.ItemLocationStockResponse {
+	if len(pls) == 0 {
+		return nil
+	}
+
+	resps := make([]presenter.ItemLocationStockResponse, len(pls))
+	for i := range pls {
+		resps[i] = presenter.ItemLocationStockResponse{
+			LocationID:        pls[i].LocationID,
+			CurrentStockValue: pls[i].CurrentStockValue,
+		}
+	}
+
+	return resps
+}
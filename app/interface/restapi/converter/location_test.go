@@ -0,0 +1,31 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestConvertItemLocationStockPayloadsToResponse(t *testing.T) {
+	t.Run("#1: No location", func(t *testing.T) {
+		got := ConvertItemLocationStockPayloadsToResponse([]payload.ItemLocationStock{})
+		if got != nil {
+			t.Errorf("ConvertItemLocationStockPayloadsToResponse() return:%v - want:nil", got)
+		}
+	})
+
+	t.Run("#2: Success", func(t *testing.T) {
+		got := ConvertItemLocationStockPayloadsToResponse([]payload.ItemLocationStock{
+			{LocationID: valueobject.LocationID(1), CurrentStockValue: 3},
+		})
+		want := []presenter.ItemLocationStockResponse{
+			{LocationID: valueobject.LocationID(1), CurrentStockValue: 3},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package converter
+
+import (
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertCreateOrderRequestToPayload(p presenter.CreateOrderRequest) payload.OrderRequest {
+	lines := make([]payload.OrderLineRequest, len(p.Lines))
+	for i, line := range p.Lines {
+		lines[i] = payload.OrderLineRequest{
+			ItemID:   line.ItemID,
+			Quantity: line.Quantity,
+		}
+	}
+
+	return payload.OrderRequest{
+		Lines: lines,
+	}
+}
+
+func ConvertOrderPayloadToResponse(pl payload.Order) presenter.Order {
+	lines := make([]presenter.OrderLine, len(pl.Lines))
+	for i, line := range pl.Lines {
+		lines[i] = presenter.OrderLine{
+			ItemID:     line.ItemID,
+			PurchaseID: line.PurchaseID,
+			Quantity:   line.Quantity,
+			UnitPrice:  line.UnitPrice,
+			LineTotal:  line.LineTotal,
+		}
+	}
+
+	return presenter.Order{
+		ID:         pl.ID,
+		PlacedAt:   pl.PlacedAt.Unix(),
+		TotalPrice: pl.TotalPrice,
+		Lines:      lines,
+	}
+}
@@ -0,0 +1,31 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestConvertCreateOrderRequestToPayload(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		req := presenter.CreateOrderRequest{
+			Lines: []presenter.OrderLineRequest{
+				{ItemID: valueobject.ItemID(1), Quantity: 2},
+				{ItemID: valueobject.ItemID(3), Quantity: 1},
+			},
+		}
+		got := ConvertCreateOrderRequestToPayload(req)
+		want := payload.OrderRequest{
+			Lines: []payload.OrderLineRequest{
+				{ItemID: valueobject.ItemID(1), Quantity: 2},
+				{ItemID: valueobject.ItemID(3), Quantity: 1},
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
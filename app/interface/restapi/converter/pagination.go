@@ -1,13 +1,30 @@
 package converter
 
 import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
 	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
 	"github.com/tuanna7593/gosample/app/usecase/payload"
 )
 
 func ConvertPaginationRequestToPayload(p presenter.PaginationRequest) payload.PaginationRequest {
 	return payload.PaginationRequest{
-		Page:  p.Page,
-		Limit: p.Limit,
+		Page:   p.Page,
+		Limit:  p.Limit,
+		Cursor: p.Cursor,
+	}
+}
+
+// convertNextCursor the cursor of the last row is only the next cursor when the page is full,
+// so a page with less rows than the limit is the last one
+func convertNextCursor(count int, limit int64, lastCreatedAt time.Time, lastID uint64) *valueobject.Cursor {
+	if count == 0 || int64(count) < limit {
+		return nil
+	}
+
+	return &valueobject.Cursor{
+		CreatedAt: lastCreatedAt,
+		ID:        lastID,
 	}
 }
@@ -2,8 +2,11 @@ package converter
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
 	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
 	"github.com/tuanna7593/gosample/app/usecase/payload"
 )
@@ -24,4 +27,26 @@ func TestConvertPaginationRequestToPayload(t *testing.T) {
 			t.Error(diff)
 		}
 	})
+
+	t.Run("#2 Success with cursor", func(t *testing.T) {
+		t.Parallel()
+		cursor := valueobject.Cursor{
+			CreatedAt: time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			ID:        3,
+		}
+		req := presenter.PaginationRequest{
+			Page:   1,
+			Limit:  5,
+			Cursor: &cursor,
+		}
+		got := ConvertPaginationRequestToPayload(req)
+		want := payload.PaginationRequest{
+			Page:   1,
+			Limit:  5,
+			Cursor: &cursor,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
 }
@@ -0,0 +1,34 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertCreateProductRequestToPayload(p presenter.CreateProductRequest) payload.CreateProductRequest {
+	return payload.CreateProductRequest{
+		Name:        strings.TrimSpace(p.Name),
+		Description: p.Description,
+	}
+}
+
+func ConvertProductPayloadToResponse(pl payload.Product) presenter.ProductResponse {
+	variants := make([]presenter.ItemResponse, len(pl.Variants))
+	for i := range pl.Variants {
+		variants[i] = ConvertPayloadItemToResponse(pl.Variants[i])
+	}
+
+	return presenter.ProductResponse{
+		ID:                    pl.ID,
+		Name:                  pl.Name,
+		Description:           pl.Description,
+		TotalStockValue:       pl.TotalStockValue,
+		CurrentStockValue:     pl.CurrentStockValue,
+		ReservedStockValue:    pl.ReservedStockValue,
+		BackorderedStockValue: pl.BackorderedStockValue,
+		Variants:              variants,
+		CreatedAt:             pl.CreatedAt.Unix(),
+	}
+}
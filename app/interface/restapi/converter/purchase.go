@@ -1,15 +1,87 @@
 package converter
 
 import (
+	"net/url"
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
 	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
 	"github.com/tuanna7593/gosample/app/usecase/payload"
 )
 
+func ConvertPurchaseFilterRequestToPayload(itemID valueobject.ItemID, p presenter.PurchaseFilterRequest) payload.PurchaseFilter {
+	filter := payload.PurchaseFilter{
+		ItemID: itemID,
+	}
+	if p.From > 0 {
+		filter.CreatedFrom = time.Unix(p.From, 0)
+	}
+	if p.To > 0 {
+		filter.CreatedTo = time.Unix(p.To, 0)
+	}
+
+	return filter
+}
+
 func ConvertPurchasePayloadToResponse(pl payload.Purchase) presenter.Purchase {
 	return presenter.Purchase{
-		ID:       pl.ID,
-		ItemID:   pl.ItemID,
-		Quantity: pl.Quantity,
-		BoughtAt: pl.BoughtAt.Unix(),
+		ID:               pl.ID,
+		ItemID:           pl.ItemID,
+		Quantity:         pl.Quantity,
+		BoughtAt:         pl.BoughtAt.Unix(),
+		RefundedQuantity: pl.RefundedQuantity,
+		RefundedAt:       convertTimeToUnixPointer(pl.RefundedAt),
+		Status:           pl.Status,
+		FulfilledAt:      convertTimeToUnixPointer(pl.FulfilledAt),
+	}
+}
+
+func ConvertRefundPurchaseRequestToPayload(purchaseID valueobject.PurchaseID, p presenter.RefundPurchaseRequest) payload.RefundRequest {
+	req := payload.RefundRequest{
+		PurchaseID: purchaseID,
+	}
+	if p.Quantity != nil {
+		req.Quantity = *p.Quantity
+	}
+
+	return req
+}
+
+// convertTimeToUnixPointer keep nil time as nil
+func convertTimeToUnixPointer(t *time.Time) *int64 {
+	if t == nil {
+		return nil
 	}
+
+	unix := t.Unix()
+	return &unix
+}
+
+func ConvertPurchasePayloadsToCursorPage(purchases []payload.Purchase, limit int64) presenter.CursorPage {
+	purchaseResp := make([]presenter.Purchase, len(purchases))
+	for i := range purchases {
+		purchaseResp[i] = ConvertPurchasePayloadToResponse(purchases[i])
+	}
+
+	var next *valueobject.Cursor
+	if len(purchases) > 0 {
+		last := purchases[len(purchases)-1]
+		next = convertNextCursor(len(purchases), limit, last.BoughtAt, uint64(last.ID))
+	}
+
+	return presenter.NewCursorPage(purchaseResp, next)
+}
+
+func ConvertPurchasePayloadsToPage(
+	purchases []payload.Purchase,
+	pagination presenter.PaginationRequest,
+	total *int64,
+	u *url.URL,
+) presenter.PageResponse {
+	purchaseResp := make([]presenter.Purchase, len(purchases))
+	for i := range purchases {
+		purchaseResp[i] = ConvertPurchasePayloadToResponse(purchases[i])
+	}
+
+	return presenter.NewPageResponse(purchaseResp, len(purchases), pagination, total, u)
 }
@@ -1,6 +1,7 @@
 package converter
 
 import (
+	"net/url"
 	"testing"
 	"time"
 
@@ -10,6 +11,23 @@ import (
 	"github.com/tuanna7593/gosample/app/usecase/payload"
 )
 
+func TestConvertPurchaseFilterRequestToPayload(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		from := time.Date(2021, 10, 16, 0, 0, 0, 0, time.Local)
+		req := presenter.PurchaseFilterRequest{
+			From: from.Unix(),
+		}
+		got := ConvertPurchaseFilterRequestToPayload(valueobject.ItemID(1), req)
+		want := payload.PurchaseFilter{
+			ItemID:      valueobject.ItemID(1),
+			CreatedFrom: from,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
 func TestConvertPurchasePayloadToResponse(t *testing.T) {
 	t.Run("#1: Success", func(t *testing.T) {
 		pl := payload.Purchase{
@@ -29,4 +47,86 @@ func TestConvertPurchasePayloadToResponse(t *testing.T) {
 			t.Error(diff)
 		}
 	})
+
+	t.Run("#2: Refunded purchase", func(t *testing.T) {
+		refundedAt := time.Date(2021, 10, 17, 0, 0, 0, 0, time.Local)
+		pl := payload.Purchase{
+			ID:               valueobject.PurchaseID(1),
+			ItemID:           valueobject.ItemID(2),
+			Quantity:         2,
+			BoughtAt:         time.Date(2021, 10, 16, 0, 0, 0, 0, time.Local),
+			RefundedQuantity: 1,
+			RefundedAt:       &refundedAt,
+		}
+		got := ConvertPurchasePayloadToResponse(pl)
+		refundedAtUnix := refundedAt.Unix()
+		want := presenter.Purchase{
+			ID:               valueobject.PurchaseID(1),
+			ItemID:           valueobject.ItemID(2),
+			Quantity:         2,
+			BoughtAt:         time.Date(2021, 10, 16, 0, 0, 0, 0, time.Local).Unix(),
+			RefundedQuantity: 1,
+			RefundedAt:       &refundedAtUnix,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestConvertPurchasePayloadsToPage(t *testing.T) {
+	t.Run("#1: First page has the next link", func(t *testing.T) {
+		u, err := url.Parse("/items/2/purchases?limit=1")
+		if err != nil {
+			panic(err)
+		}
+		purchases := []payload.Purchase{
+			{
+				ID:       valueobject.PurchaseID(1),
+				ItemID:   valueobject.ItemID(2),
+				Quantity: 1,
+				BoughtAt: time.Date(2021, 10, 16, 0, 0, 0, 0, time.Local),
+			},
+		}
+		total := int64(3)
+		got := ConvertPurchasePayloadsToPage(purchases, presenter.PaginationRequest{Page: 1, Limit: 1}, &total, u)
+
+		next := "/items/2/purchases?limit=1&page=2"
+		want := presenter.PageResponse{
+			Data:  []presenter.Purchase{ConvertPurchasePayloadToResponse(purchases[0])},
+			Page:  1,
+			Limit: 1,
+			Total: &total,
+			Next:  &next,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestConvertRefundPurchaseRequestToPayload(t *testing.T) {
+	t.Run("#1: Refund the remaining quantity", func(t *testing.T) {
+		got := ConvertRefundPurchaseRequestToPayload(valueobject.PurchaseID(1), presenter.RefundPurchaseRequest{})
+		want := payload.RefundRequest{
+			PurchaseID: valueobject.PurchaseID(1),
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Refund a part of purchase", func(t *testing.T) {
+		quantity := uint64(2)
+		got := ConvertRefundPurchaseRequestToPayload(valueobject.PurchaseID(1), presenter.RefundPurchaseRequest{
+			Quantity: &quantity,
+		})
+		want := payload.RefundRequest{
+			PurchaseID: valueobject.PurchaseID(1),
+			Quantity:   2,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
 }
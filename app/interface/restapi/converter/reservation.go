@@ -0,0 +1,34 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertReserveItemRequestToPayload(itemID valueobject.ItemID, p presenter.ReserveItemRequest) payload.ReservationRequest {
+	ttlSeconds := presenter.DefaultReservationTTLSeconds
+	if p.TTLSeconds != nil {
+		ttlSeconds = *p.TTLSeconds
+	}
+
+	return payload.ReservationRequest{
+		ItemID:   itemID,
+		Quantity: p.Quantity,
+		TTL:      time.Duration(ttlSeconds) * time.Second,
+	}
+}
+
+func ConvertReservationPayloadToResponse(pl payload.Reservation) presenter.Reservation {
+	return presenter.Reservation{
+		ID:         pl.ID,
+		ItemID:     pl.ItemID,
+		Quantity:   pl.Quantity,
+		Status:     pl.Status,
+		ReservedAt: pl.ReservedAt.Unix(),
+		ExpiresAt:  pl.ExpiresAt.Unix(),
+		PurchaseID: pl.PurchaseID,
+	}
+}
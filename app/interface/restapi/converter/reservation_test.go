@@ -0,0 +1,45 @@
+package converter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestConvertReserveItemRequestToPayload(t *testing.T) {
+	t.Run("#1: Default TTL", func(t *testing.T) {
+		req := presenter.ReserveItemRequest{
+			Quantity: 2,
+		}
+		got := ConvertReserveItemRequestToPayload(valueobject.ItemID(1), req)
+		want := payload.ReservationRequest{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 2,
+			TTL:      15 * time.Minute,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Request TTL", func(t *testing.T) {
+		ttlSeconds := uint64(60)
+		req := presenter.ReserveItemRequest{
+			Quantity:   2,
+			TTLSeconds: &ttlSeconds,
+		}
+		got := ConvertReserveItemRequestToPayload(valueobject.ItemID(1), req)
+		want := payload.ReservationRequest{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 2,
+			TTL:      time.Minute,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
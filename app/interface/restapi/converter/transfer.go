@@ -0,0 +1,29 @@
+package converter
+
+import (
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertTransferItemRequestToPayload(itemID valueobject.ItemID, p presenter.TransferItemRequest) payload.TransferRequest {
+	return payload.TransferRequest{
+		ItemID:         itemID,
+		FromLocationID: p.FromLocationID,
+		ToLocationID:   p.ToLocationID,
+		Quantity:       p.Quantity,
+	}
+}
+
+func ConvertTransferPayloadToResponse(pl payload.Transfer) presenter.Transfer {
+	return presenter.Transfer{
+		ID:             pl.ID,
+		ItemID:         pl.ItemID,
+		FromLocationID: pl.FromLocationID,
+		ToLocationID:   pl.ToLocationID,
+		Quantity:       pl.Quantity,
+		Status:         pl.Status,
+		SentAt:         pl.SentAt.Unix(),
+		ReceivedAt:     convertTimeToUnixPointer(pl.ReceivedAt),
+	}
+}
@@ -0,0 +1,69 @@
+package converter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestConvertTransferPayloadToResponse(t *testing.T) {
+	sentAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.UTC)
+
+	t.Run("#1: In transit", func(t *testing.T) {
+		pl := payload.Transfer{
+			ID:             valueobject.TransferID(1),
+			ItemID:         valueobject.ItemID(2),
+			FromLocationID: valueobject.LocationID(3),
+			ToLocationID:   valueobject.LocationID(4),
+			Quantity:       5,
+			Status:         valueobject.TransferStatusInTransit,
+			SentAt:         sentAt,
+		}
+		got := ConvertTransferPayloadToResponse(pl)
+		want := presenter.Transfer{
+			ID:             valueobject.TransferID(1),
+			ItemID:         valueobject.ItemID(2),
+			FromLocationID: valueobject.LocationID(3),
+			ToLocationID:   valueobject.LocationID(4),
+			Quantity:       5,
+			Status:         valueobject.TransferStatusInTransit,
+			SentAt:         sentAt.Unix(),
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Received", func(t *testing.T) {
+		receivedAt := sentAt.Add(time.Hour)
+		pl := payload.Transfer{
+			ID:             valueobject.TransferID(1),
+			ItemID:         valueobject.ItemID(2),
+			FromLocationID: valueobject.LocationID(3),
+			ToLocationID:   valueobject.LocationID(4),
+			Quantity:       5,
+			Status:         valueobject.TransferStatusReceived,
+			SentAt:         sentAt,
+			ReceivedAt:     &receivedAt,
+		}
+		got := ConvertTransferPayloadToResponse(pl)
+		receivedAtUnix := receivedAt.Unix()
+		want := presenter.Transfer{
+			ID:             valueobject.TransferID(1),
+			ItemID:         valueobject.ItemID(2),
+			FromLocationID: valueobject.LocationID(3),
+			ToLocationID:   valueobject.LocationID(4),
+			Quantity:       5,
+			Status:         valueobject.TransferStatusReceived,
+			SentAt:         sentAt.Unix(),
+			ReceivedAt:     &receivedAtUnix,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
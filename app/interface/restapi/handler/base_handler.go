@@ -22,7 +22,7 @@ func (b *BaseHandler) SetError(w http.ResponseWriter, err error) {
 		writeErrorMessage(w, e.Error())
 	case payload.Errors:
 		writeErrorStatusCode(w, e[0].Type)
-		writeErrorMessage(w, e.Error())
+		writeErrorDetails(w, e)
 	default:
 		w.WriteHeader(http.StatusInternalServerError)
 	}
@@ -48,13 +48,26 @@ func writeErrorStatusCode(w http.ResponseWriter, eType payload.ErrorType) {
 		w.WriteHeader(http.StatusBadRequest)
 	case payload.ErrorTypeNotFound:
 		w.WriteHeader(http.StatusNotFound)
+	case payload.ErrorTypePreconditionFailed:
+		w.WriteHeader(http.StatusPreconditionFailed)
+	case payload.ErrorTypeConflict:
+		w.WriteHeader(http.StatusConflict)
+	case payload.ErrorTypeUnprocessable:
+		w.WriteHeader(http.StatusUnprocessableEntity)
 	default:
 		w.WriteHeader(http.StatusInternalServerError)
 	}
 }
 
 type responseErr struct {
-	Error string `json:"error"`
+	Error   string              `json:"error"`
+	Details []responseErrDetail `json:"details,omitempty"`
+}
+
+type responseErrDetail struct {
+	Code    payload.ErrorCode `json:"code"`
+	Message string            `json:"message"`
+	Param   interface{}       `json:"param,omitempty"`
 }
 
 func writeErrorMessage(w http.ResponseWriter, message string) {
@@ -67,3 +80,23 @@ func writeErrorMessage(w http.ResponseWriter, message string) {
 		w.WriteHeader(http.StatusInternalServerError)
 	}
 }
+
+// writeErrorDetails write every error so the client can tell which fields or lines are failed
+func writeErrorDetails(w http.ResponseWriter, errs payload.Errors) {
+	respErr := responseErr{
+		Error:   errs.Error(),
+		Details: make([]responseErrDetail, len(errs)),
+	}
+	for i := range errs {
+		respErr.Details[i] = responseErrDetail{
+			Code:    errs[i].Code,
+			Message: errs[i].Message,
+			Param:   errs[i].Param,
+		}
+	}
+
+	if err := json.NewEncoder(w).Encode(respErr); err != nil {
+		// force return 500 error
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
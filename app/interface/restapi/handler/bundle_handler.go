@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/external/persistence/mysql"
+	"github.com/tuanna7593/gosample/app/interface/restapi/converter"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/interactor"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+type BundleHandler struct {
+	BaseHandler
+	allocationStrategy valueobject.AllocationStrategy
+	stockAlertNotifier repository.StockAlertNotifier
+}
+
+// NewBundleHandler create a new handler for Bundles
+func NewBundleHandler(
+	allocationStrategy valueobject.AllocationStrategy,
+	stockAlertNotifier repository.StockAlertNotifier,
+) *BundleHandler {
+	return &BundleHandler{
+		allocationStrategy: allocationStrategy,
+		stockAlertNotifier: stockAlertNotifier,
+	}
+}
+
+// Create create a new bundle of items
+func (hdl *BundleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var (
+		req presenter.CreateBundleRequest
+		err error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	// decoding request body to struct
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		log.Printf("failed to decode request create bundle:%s\n", errDecode.Error())
+		err = payload.Error{
+			Message: "failed to decode create bundle request",
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return
+	}
+
+	// validate create bundle request
+	err = req.Validate()
+	if err != nil {
+		log.Println("invalid create bundle request")
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewBundleUseCaseInteractor(
+		mysql.NewBundleRepositoryImpl(),
+		mysql.NewItemRepositoryImpl(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		hdl.allocationStrategy,
+		hdl.stockAlertNotifier,
+	)
+
+	// execute use case
+	bundle, err := uc.Create(r.Context(), converter.ConvertCreateBundleRequestToPayload(req))
+	if err != nil {
+		log.Println("failed to create bundle")
+		return
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusCreated, converter.ConvertBundlePayloadToResponse(bundle))
+}
+
+// GetBundle get a bundle by id with its available quantity
+func (hdl *BundleHandler) GetBundle(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	bundleID, err := parseBundleID(r)
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewBundleUseCaseInteractor(
+		mysql.NewBundleRepositoryImpl(),
+		mysql.NewItemRepositoryImpl(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		hdl.allocationStrategy,
+		hdl.stockAlertNotifier,
+	)
+
+	bundle, err := uc.GetBundle(r.Context(), bundleID)
+	if err != nil {
+		log.Printf("failed to get bundle:%d\n", bundleID)
+		return
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusOK, converter.ConvertBundlePayloadToResponse(bundle))
+}
+
+// BuyBundle buy a bundle by taking the stock of all its components
+func (hdl *BundleHandler) BuyBundle(w http.ResponseWriter, r *http.Request) {
+	var (
+		req presenter.BuyBundleRequest
+		err error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	bundleID, err := parseBundleID(r)
+	if err != nil {
+		return
+	}
+
+	// decoding request body to struct
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		log.Printf("failed to decode request buy bundle:%s\n", errDecode.Error())
+		err = payload.Error{
+			Message: "failed to decode buy bundle request",
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return
+	}
+
+	// validate buy bundle request
+	err = req.Validate()
+	if err != nil {
+		log.Println("invalid buy bundle request")
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewBundleUseCaseInteractor(
+		mysql.NewBundleRepositoryImpl(),
+		mysql.NewItemRepositoryImpl(),
+		mysql.NewPurchaseRepositoryImpl(),
+		mysql.NewOrderRepositoryImpl(),
+		mysql.NewInventoryMovementRepositoryImpl(),
+		mysql.NewLocationStockRepositoryImpl(),
+		mysql.NewTransactionManagerImpl(),
+		hdl.allocationStrategy,
+		hdl.stockAlertNotifier,
+	)
+
+	// execute use case
+	order, err := uc.BuyBundle(r.Context(), converter.ConvertBuyBundleRequestToPayload(bundleID, req))
+	if err != nil {
+		log.Printf("failed to buy bundle:%d\n", bundleID)
+		return
+	}
+
+	// success
+	resp := converter.ConvertOrderPayloadToResponse(order)
+	hdl.WriteResponse(w, http.StatusCreated, resp)
+}
+
+// parseBundleID get bundle id from url param
+func parseBundleID(r *http.Request) (valueobject.BundleID, error) {
+	bundleIDStr := chi.URLParam(r, "bundle_id")
+	if bundleIDStr == "" {
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidBundleID,
+			Message: "not found bundle_id",
+			Param:   nil,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+	}
+
+	bundleID, err := strconv.ParseUint(bundleIDStr, 10, 64)
+	if err != nil {
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidBundleID,
+			Message: "failed to parse bundle_id",
+			Param:   bundleIDStr,
+			Type:    payload.ErrorTypeInvalidArgument,
+		}
+	}
+
+	return valueobject.BundleID(bundleID), nil
+}
@@ -0,0 +1,293 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/external/persistence/mysql"
+	"github.com/tuanna7593/gosample/app/interface/restapi/converter"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/interactor"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+type CategoryHandler struct {
+	BaseHandler
+}
+
+// NewCategoryHandler create a new handler for Categories
+func NewCategoryHandler() *CategoryHandler {
+	return &CategoryHandler{}
+}
+
+// Create create a new category
+func (hdl *CategoryHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var (
+		req presenter.CategoryRequest
+		err error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	// decoding request body to struct
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		log.Printf("failed to decode request create category:%s\n", errDecode.Error())
+		err = payload.Error{
+			Message: "failed to decode create category request",
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return
+	}
+
+	// validate create category request
+	err = req.Validate()
+	if err != nil {
+		log.Println("invalid create category request")
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewCategoryUseCaseInteractor(mysql.NewCategoryRepositoryImpl(), nil, nil, nil)
+
+	// execute use case
+	category, err := uc.Create(r.Context(), converter.ConvertCategoryRequestToCreatePayload(req))
+	if err != nil {
+		return
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusCreated, converter.ConvertCategoryPayloadToResponse(category))
+}
+
+// List get all the categories
+func (hdl *CategoryHandler) List(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	// init usecase
+	uc := interactor.NewCategoryUseCaseInteractor(mysql.NewCategoryRepositoryImpl(), nil, nil, nil)
+
+	categories, err := uc.List(r.Context())
+	if err != nil {
+		log.Println("failed to get categories")
+		return
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusOK, converter.ConvertCategoryPayloadsToResponse(categories))
+}
+
+// GetCategory get a category by id
+func (hdl *CategoryHandler) GetCategory(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	categoryID, err := parseCategoryID(r)
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewCategoryUseCaseInteractor(mysql.NewCategoryRepositoryImpl(), nil, nil, nil)
+
+	category, err := uc.GetCategory(r.Context(), categoryID)
+	if err != nil {
+		log.Printf("failed to get category:%d\n", categoryID)
+		return
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusOK, converter.ConvertCategoryPayloadToResponse(category))
+}
+
+// Update rename a category and move it under another parent
+func (hdl *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var (
+		req presenter.CategoryRequest
+		err error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	categoryID, err := parseCategoryID(r)
+	if err != nil {
+		return
+	}
+
+	// decoding request body to struct
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		log.Printf("failed to decode request update category:%s\n", errDecode.Error())
+		err = payload.Error{
+			Message: "failed to decode update category request",
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return
+	}
+
+	// validate update category request
+	err = req.Validate()
+	if err != nil {
+		log.Println("invalid update category request")
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewCategoryUseCaseInteractor(mysql.NewCategoryRepositoryImpl(), nil, nil, mysql.NewTransactionManagerImpl())
+
+	category, err := uc.UpdateCategory(r.Context(), converter.ConvertCategoryRequestToUpdatePayload(categoryID, req))
+	if err != nil {
+		log.Printf("failed to update category:%d\n", categoryID)
+		return
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusOK, converter.ConvertCategoryPayloadToResponse(category))
+}
+
+// Delete delete a category which has no children
+func (hdl *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	categoryID, err := parseCategoryID(r)
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewCategoryUseCaseInteractor(mysql.NewCategoryRepositoryImpl(), nil, nil, nil)
+
+	err = uc.DeleteCategory(r.Context(), categoryID)
+	if err != nil {
+		log.Printf("failed to delete category:%d\n", categoryID)
+		return
+	}
+
+	// success
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AssignItem replace the categories of an item
+func (hdl *CategoryHandler) AssignItem(w http.ResponseWriter, r *http.Request) {
+	var (
+		req presenter.ItemCategoriesRequest
+		err error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	itemID, err := parseItemID(r)
+	if err != nil {
+		return
+	}
+
+	// decoding request body to struct
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		log.Printf("failed to decode request item categories:%s\n", errDecode.Error())
+		err = payload.Error{
+			Message: "failed to decode item categories request",
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return
+	}
+
+	// validate item categories request
+	err = req.Validate()
+	if err != nil {
+		log.Println("invalid item categories request")
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewCategoryUseCaseInteractor(
+		mysql.NewCategoryRepositoryImpl(),
+		mysql.NewItemCategoryRepositoryImpl(),
+		mysql.NewItemRepositoryImpl(),
+		mysql.NewTransactionManagerImpl(),
+	)
+
+	categories, err := uc.AssignItem(r.Context(), converter.ConvertItemCategoriesRequestToPayload(itemID, req))
+	if err != nil {
+		log.Printf("failed to assign categories to item:%d\n", itemID)
+		return
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusOK, converter.ConvertCategoryPayloadsToResponse(categories))
+}
+
+// ListByItem get the categories of an item
+func (hdl *CategoryHandler) ListByItem(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	itemID, err := parseItemID(r)
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewCategoryUseCaseInteractor(
+		nil,
+		mysql.NewItemCategoryRepositoryImpl(),
+		mysql.NewItemRepositoryImpl(),
+		nil,
+	)
+
+	categories, err := uc.ListByItem(r.Context(), itemID)
+	if err != nil {
+		log.Printf("failed to get categories of item:%d\n", itemID)
+		return
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusOK, converter.ConvertCategoryPayloadsToResponse(categories))
+}
+
+// parseCategoryID get category id from url param
+func parseCategoryID(r *http.Request) (valueobject.CategoryID, error) {
+	categoryIDStr := chi.URLParam(r, "category_id")
+	if categoryIDStr == "" {
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidCategoryID,
+			Message: "not found category_id",
+			Param:   nil,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+	}
+
+	categoryID, err := strconv.ParseUint(categoryIDStr, 10, 64)
+	if err != nil {
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidCategoryID,
+			Message: "failed to parse category_id",
+			Param:   categoryIDStr,
+			Type:    payload.ErrorTypeInvalidArgument,
+		}
+	}
+
+	return valueobject.CategoryID(categoryID), nil
+}
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/external/persistence/mysql"
+	"github.com/tuanna7593/gosample/app/interface/restapi/converter"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/interactor"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+type InventoryHandler struct {
+	BaseHandler
+	allocationStrategy valueobject.AllocationStrategy
+}
+
+// NewInventoryHandler create a new handler for the stock of Items
+func NewInventoryHandler(allocationStrategy valueobject.AllocationStrategy) *InventoryHandler {
+	return &InventoryHandler{
+		allocationStrategy: allocationStrategy,
+	}
+}
+
+// Restock add stock to an item
+func (hdl *InventoryHandler) Restock(w http.ResponseWriter, r *http.Request) {
+	var (
+		req presenter.RestockItemRequest
+		err error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	itemID, err := parseItemID(r)
+	if err != nil {
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		return
+	}
+
+	// decoding request body to struct
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		log.Printf("failed to decode request restock item:%s\n", errDecode.Error())
+		err = payload.Error{
+			Message: "failed to decode restock item request",
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return
+	}
+
+	// validate restock item request
+	err = req.Validate()
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewInventoryUseCaseInteractor(
+		mysql.NewItemRepositoryImpl(),
+		mysql.NewPurchaseRepositoryImpl(),
+		mysql.NewInventoryMovementRepositoryImpl(),
+		mysql.NewLocationRepositoryImpl(),
+		mysql.NewLocationStockRepositoryImpl(),
+		mysql.NewTransactionManagerImpl(),
+		hdl.allocationStrategy,
+	)
+
+	// execute use case
+	item, err := uc.Restock(r.Context(), converter.ConvertRestockItemRequestToPayload(itemID, req, expectedVersion))
+	if err != nil {
+		log.Printf("failed to restock item:%d\n", itemID)
+		return
+	}
+
+	// success
+	resp := converter.ConvertPayloadItemToResponse(item)
+	setItemETag(w, resp.Version)
+	hdl.WriteResponse(w, http.StatusOK, resp)
+}
+
+// Adjust correct the stock of an item for damage, loss or recount
+func (hdl *InventoryHandler) Adjust(w http.ResponseWriter, r *http.Request) {
+	var (
+		req presenter.AdjustItemRequest
+		err error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	itemID, err := parseItemID(r)
+	if err != nil {
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		return
+	}
+
+	// decoding request body to struct
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		log.Printf("failed to decode request adjust item:%s\n", errDecode.Error())
+		err = payload.Error{
+			Message: "failed to decode adjust item request",
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return
+	}
+
+	// validate adjust item request
+	err = req.Validate()
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewInventoryUseCaseInteractor(
+		mysql.NewItemRepositoryImpl(),
+		mysql.NewPurchaseRepositoryImpl(),
+		mysql.NewInventoryMovementRepositoryImpl(),
+		mysql.NewLocationRepositoryImpl(),
+		mysql.NewLocationStockRepositoryImpl(),
+		mysql.NewTransactionManagerImpl(),
+		hdl.allocationStrategy,
+	)
+
+	// execute use case
+	item, err := uc.Adjust(r.Context(), converter.ConvertAdjustItemRequestToPayload(itemID, req, expectedVersion))
+	if err != nil {
+		log.Printf("failed to adjust item:%d\n", itemID)
+		return
+	}
+
+	// success
+	resp := converter.ConvertPayloadItemToResponse(item)
+	setItemETag(w, resp.Version)
+	hdl.WriteResponse(w, http.StatusOK, resp)
+}
+
+// ListMovements get the stock movements of an item
+func (hdl *InventoryHandler) ListMovements(w http.ResponseWriter, r *http.Request) {
+	var (
+		paginationRequest presenter.PaginationRequest
+		err               error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	itemID, err := parseItemID(r)
+	if err != nil {
+		return
+	}
+
+	// parse pagination request
+	err = paginationRequest.Parse(r.URL.Query())
+	if err != nil {
+		log.Println("failed to parse query string to pagination")
+		return
+	}
+
+	// validate pagination request
+	err = paginationRequest.Valiate()
+	if err != nil {
+		log.Printf("invalid pagination request:%+v\n", paginationRequest)
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewInventoryUseCaseInteractor(
+		mysql.NewItemRepositoryImpl(),
+		nil,
+		mysql.NewInventoryMovementRepositoryImpl(),
+		nil,
+		nil,
+		nil,
+		hdl.allocationStrategy,
+	)
+
+	movements, err := uc.ListMovements(
+		r.Context(),
+		itemID,
+		converter.ConvertPaginationRequestToPayload(paginationRequest),
+	)
+	if err != nil {
+		log.Printf("failed to get movements of item:%d\n", itemID)
+		return
+	}
+
+	// count the movements of item unless it's skipped
+	var total *int64
+	if !paginationRequest.SkipTotal {
+		count, errCount := uc.CountMovements(r.Context(), itemID)
+		if errCount != nil {
+			err = errCount
+			log.Printf("failed to count movements of item:%d\n", itemID)
+			return
+		}
+		total = &count
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusOK, converter.ConvertInventoryMovementPayloadsToPage(movements, paginationRequest, total, r.URL))
+}
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/tuanna7593/gosample/app/external/persistence/mysql"
+	"github.com/tuanna7593/gosample/app/interface/restapi/converter"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/interactor"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+type ItemAttributeHandler struct {
+	BaseHandler
+}
+
+// NewItemAttributeHandler create a new handler for the tags and attributes of Items
+func NewItemAttributeHandler() *ItemAttributeHandler {
+	return &ItemAttributeHandler{}
+}
+
+// ListTags get the tags of an item
+func (hdl *ItemAttributeHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	itemID, err := parseItemID(r)
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewItemAttributeUseCaseInteractor(mysql.NewItemRepositoryImpl(), mysql.NewItemTagRepositoryImpl(), nil)
+
+	tags, err := uc.ListTags(r.Context(), itemID)
+	if err != nil {
+		log.Printf("failed to get tags of item:%d\n", itemID)
+		return
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusOK, tags)
+}
+
+// AddTag add a tag to an item
+func (hdl *ItemAttributeHandler) AddTag(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	itemID, err := parseItemID(r)
+	if err != nil {
+		return
+	}
+
+	tag := chi.URLParam(r, "tag")
+	if errValidate := presenter.ValidateItemTag(tag); errValidate != nil {
+		err = *errValidate
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewItemAttributeUseCaseInteractor(mysql.NewItemRepositoryImpl(), mysql.NewItemTagRepositoryImpl(), nil)
+
+	err = uc.AddTag(r.Context(), payload.ItemTagRequest{ItemID: itemID, Tag: tag})
+	if err != nil {
+		log.Printf("failed to add tag:%s to item:%d\n", tag, itemID)
+		return
+	}
+
+	// success
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveTag remove a tag from an item
+func (hdl *ItemAttributeHandler) RemoveTag(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	itemID, err := parseItemID(r)
+	if err != nil {
+		return
+	}
+
+	tag := chi.URLParam(r, "tag")
+	if errValidate := presenter.ValidateItemTag(tag); errValidate != nil {
+		err = *errValidate
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewItemAttributeUseCaseInteractor(mysql.NewItemRepositoryImpl(), mysql.NewItemTagRepositoryImpl(), nil)
+
+	err = uc.RemoveTag(r.Context(), payload.ItemTagRequest{ItemID: itemID, Tag: tag})
+	if err != nil {
+		log.Printf("failed to remove tag:%s from item:%d\n", tag, itemID)
+		return
+	}
+
+	// success
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListAttributes get the attributes of an item
+func (hdl *ItemAttributeHandler) ListAttributes(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	itemID, err := parseItemID(r)
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewItemAttributeUseCaseInteractor(mysql.NewItemRepositoryImpl(), nil, mysql.NewItemAttributeRepositoryImpl())
+
+	attributes, err := uc.ListAttributes(r.Context(), itemID)
+	if err != nil {
+		log.Printf("failed to get attributes of item:%d\n", itemID)
+		return
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusOK, converter.ConvertItemAttributePayloadsToResponse(attributes))
+}
+
+// SetAttribute set the value of an attribute key of an item
+func (hdl *ItemAttributeHandler) SetAttribute(w http.ResponseWriter, r *http.Request) {
+	var (
+		req presenter.ItemAttributeRequest
+		err error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	itemID, err := parseItemID(r)
+	if err != nil {
+		return
+	}
+
+	key := chi.URLParam(r, "key")
+	if errValidate := presenter.ValidateItemAttributeKey(key); errValidate != nil {
+		err = *errValidate
+		return
+	}
+
+	// decoding request body to struct
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		log.Printf("failed to decode request set item attribute:%s\n", errDecode.Error())
+		err = payload.Error{
+			Message: "failed to decode set item attribute request",
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return
+	}
+
+	// validate set item attribute request
+	err = req.Validate()
+	if err != nil {
+		log.Println("invalid set item attribute request")
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewItemAttributeUseCaseInteractor(mysql.NewItemRepositoryImpl(), nil, mysql.NewItemAttributeRepositoryImpl())
+
+	attribute, err := uc.SetAttribute(r.Context(), converter.ConvertItemAttributeRequestToPayload(itemID, key, req))
+	if err != nil {
+		log.Printf("failed to set attribute:%s of item:%d\n", key, itemID)
+		return
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusOK, converter.ConvertItemAttributePayloadToResponse(attribute))
+}
+
+// RemoveAttribute remove an attribute key from an item
+func (hdl *ItemAttributeHandler) RemoveAttribute(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	itemID, err := parseItemID(r)
+	if err != nil {
+		return
+	}
+
+	key := chi.URLParam(r, "key")
+	if errValidate := presenter.ValidateItemAttributeKey(key); errValidate != nil {
+		err = *errValidate
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewItemAttributeUseCaseInteractor(mysql.NewItemRepositoryImpl(), nil, mysql.NewItemAttributeRepositoryImpl())
+
+	err = uc.RemoveAttribute(r.Context(), payload.ItemAttributeRequest{ItemID: itemID, Key: key})
+	if err != nil {
+		log.Printf("failed to remove attribute:%s from item:%d\n", key, itemID)
+		return
+	}
+
+	// success
+	w.WriteHeader(http.StatusNoContent)
+}
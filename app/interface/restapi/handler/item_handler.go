@@ -6,8 +6,10 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/tuanna7593/gosample/app/domain/repository"
 	"github.com/tuanna7593/gosample/app/domain/valueobject"
 	"github.com/tuanna7593/gosample/app/external/persistence/mysql"
 	"github.com/tuanna7593/gosample/app/interface/restapi/converter"
@@ -18,11 +20,19 @@ import (
 
 type ItemHandler struct {
 	BaseHandler
+	allocationStrategy valueobject.AllocationStrategy
+	stockAlertNotifier repository.StockAlertNotifier
 }
 
 // NewItemHandler create a new handler for Items
-func NewItemHandler() *ItemHandler {
-	return &ItemHandler{}
+func NewItemHandler(
+	allocationStrategy valueobject.AllocationStrategy,
+	stockAlertNotifier repository.StockAlertNotifier,
+) *ItemHandler {
+	return &ItemHandler{
+		allocationStrategy: allocationStrategy,
+		stockAlertNotifier: stockAlertNotifier,
+	}
 }
 
 // Create create a new item
@@ -60,7 +70,14 @@ func (hdl *ItemHandler) Create(w http.ResponseWriter, r *http.Request) {
 	uc := interactor.NewItemUseCaseInteractor(
 		mysql.NewItemRepositoryImpl(),
 		nil,
+		mysql.NewInventoryMovementRepositoryImpl(),
+		nil,
 		nil,
+		nil,
+		mysql.NewProductRepositoryImpl(),
+		mysql.NewTransactionManagerImpl(),
+		hdl.allocationStrategy,
+		hdl.stockAlertNotifier,
 	)
 
 	// execute use case create
@@ -71,12 +88,15 @@ func (hdl *ItemHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	// success
 	resp := converter.ConvertPayloadItemToResponse(itemPayload)
+	setItemETag(w, resp.Version)
 	hdl.WriteResponse(w, http.StatusCreated, resp)
 }
 
+// List get the items matched the filter
 func (hdl *ItemHandler) List(w http.ResponseWriter, r *http.Request) {
 	var (
 		paginationRequest presenter.PaginationRequest
+		filterRequest     presenter.ItemFilterRequest
 		err               error
 	)
 
@@ -98,7 +118,22 @@ func (hdl *ItemHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// parse filter request
+	err = filterRequest.Parse(r.URL.Query())
+	if err != nil {
+		log.Println("failed to parse query string to item filter")
+		return
+	}
+
+	// validate filter request
+	err = filterRequest.Validate()
+	if err != nil {
+		log.Printf("invalid item filter request:%+v\n", filterRequest)
+		return
+	}
+
 	// convert to payload
+	payloadFilter := converter.ConvertItemFilterRequestToPayload(filterRequest)
 	payloadPagination := converter.ConvertPaginationRequestToPayload(paginationRequest)
 
 	// init usecase
@@ -106,22 +141,278 @@ func (hdl *ItemHandler) List(w http.ResponseWriter, r *http.Request) {
 		mysql.NewItemRepositoryImpl(),
 		nil,
 		nil,
+		mysql.NewLocationStockRepositoryImpl(),
+		nil,
+		mysql.NewCategoryRepositoryImpl(),
+		nil,
+		nil,
+		hdl.allocationStrategy,
+		hdl.stockAlertNotifier,
 	)
 
-	items, err := uc.List(r.Context(), payloadPagination)
+	items, err := uc.List(r.Context(), payloadFilter, payloadPagination)
 	if err != nil {
 		log.Println("failed to get items")
 		return
 	}
 
-	// convert payload to prenseter
-	itemResp := make([]presenter.ItemResponse, len(items))
-	for i := range itemResp {
-		itemResp[i] = converter.ConvertPayloadItemToResponse(items[i])
+	// the items are paged by the cursor
+	if paginationRequest.Cursor != nil {
+		hdl.WriteResponse(w, http.StatusOK, converter.ConvertItemPayloadsToCursorPage(items, paginationRequest.Limit))
+		return
+	}
+
+	// count the items matched the filter unless it's skipped
+	var total *int64
+	if !paginationRequest.SkipTotal {
+		count, errCount := uc.Count(r.Context(), payloadFilter)
+		if errCount != nil {
+			err = errCount
+			log.Println("failed to count items")
+			return
+		}
+		total = &count
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusOK, converter.ConvertItemPayloadsToPage(items, paginationRequest, total, r.URL))
+}
+
+// Search get the items matched the words of the query ordered by relevance
+func (hdl *ItemHandler) Search(w http.ResponseWriter, r *http.Request) {
+	var (
+		paginationRequest presenter.PaginationRequest
+		searchRequest     presenter.ItemSearchRequest
+		err               error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	// parse pagination request
+	err = paginationRequest.Parse(r.URL.Query())
+	if err != nil {
+		log.Println("failed to parse query string to pagination")
+		return
+	}
+
+	// validate pagination request
+	err = paginationRequest.Valiate()
+	if err != nil {
+		log.Printf("invalid pagination request:%+v\n", paginationRequest)
+		return
+	}
+
+	// parse and validate search request
+	searchRequest.Parse(r.URL.Query())
+	err = searchRequest.Validate()
+	if err != nil {
+		log.Printf("invalid item search request:%+v\n", searchRequest)
+		return
+	}
+
+	// convert to payload
+	payloadSearch := converter.ConvertItemSearchRequestToPayload(searchRequest)
+	payloadPagination := converter.ConvertPaginationRequestToPayload(paginationRequest)
+
+	// init usecase
+	uc := interactor.NewItemSearchUseCaseInteractor(mysql.NewItemSearchRepositoryImpl())
+
+	hits, err := uc.Search(r.Context(), payloadSearch, payloadPagination)
+	if err != nil {
+		log.Printf("failed to search items:%s\n", searchRequest.Query)
+		return
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusOK, converter.ConvertItemSearchHitPayloadsToPage(hits, paginationRequest, r.URL))
+}
+
+// GetItem get an item by id
+func (hdl *ItemHandler) GetItem(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	itemID, err := parseItemID(r)
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewItemUseCaseInteractor(
+		mysql.NewItemRepositoryImpl(),
+		nil,
+		nil,
+		mysql.NewLocationStockRepositoryImpl(),
+		nil,
+		nil,
+		nil,
+		nil,
+		hdl.allocationStrategy,
+		hdl.stockAlertNotifier,
+	)
+
+	item, err := uc.GetItem(r.Context(), itemID)
+	if err != nil {
+		log.Printf("failed to get item:%d\n", itemID)
+		return
+	}
+
+	// success
+	resp := converter.ConvertPayloadItemToResponse(item)
+	setItemETag(w, resp.Version)
+	hdl.WriteResponse(w, http.StatusOK, resp)
+}
+
+// GetItemBySKU get an item by its sku
+func (hdl *ItemHandler) GetItemBySKU(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	sku := chi.URLParam(r, "sku")
+	if errValidate := presenter.ValidateSKU(sku); errValidate != nil {
+		err = *errValidate
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewItemUseCaseInteractor(
+		mysql.NewItemRepositoryImpl(),
+		nil,
+		nil,
+		mysql.NewLocationStockRepositoryImpl(),
+		nil,
+		nil,
+		nil,
+		nil,
+		hdl.allocationStrategy,
+		hdl.stockAlertNotifier,
+	)
+
+	item, err := uc.GetItemBySKU(r.Context(), sku)
+	if err != nil {
+		log.Printf("failed to get item by sku:%s\n", sku)
+		return
+	}
+
+	// success
+	resp := converter.ConvertPayloadItemToResponse(item)
+	setItemETag(w, resp.Version)
+	hdl.WriteResponse(w, http.StatusOK, resp)
+}
+
+// Update replace the total stock and selling price of an item
+func (hdl *ItemHandler) Update(w http.ResponseWriter, r *http.Request) {
+	hdl.update(w, r, false)
+}
+
+// Patch partially update an item with JSON merge-patch semantics
+func (hdl *ItemHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	hdl.update(w, r, true)
+}
+
+func (hdl *ItemHandler) update(w http.ResponseWriter, r *http.Request, partial bool) {
+	var (
+		req = presenter.UpdateItemRequest{Partial: partial}
+		err error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	itemID, err := parseItemID(r)
+	if err != nil {
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		return
+	}
+
+	// decoding request body to struct
+	err = req.Decode(r.Body)
+	if err != nil {
+		return
+	}
+
+	// validate update item request
+	err = req.Validate()
+	if err != nil {
+		log.Println("invalid update item request")
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewItemUseCaseInteractor(
+		mysql.NewItemRepositoryImpl(),
+		mysql.NewPurchaseRepositoryImpl(),
+		mysql.NewInventoryMovementRepositoryImpl(),
+		mysql.NewLocationStockRepositoryImpl(),
+		nil,
+		nil,
+		nil,
+		mysql.NewTransactionManagerImpl(),
+		hdl.allocationStrategy,
+		hdl.stockAlertNotifier,
+	)
+
+	// execute use case
+	item, err := uc.UpdateItem(r.Context(), converter.ConvertUpdateItemRequestToPayload(itemID, req, expectedVersion))
+	if err != nil {
+		log.Printf("failed to update item:%d\n", itemID)
+		return
+	}
+
+	// success
+	resp := converter.ConvertPayloadItemToResponse(item)
+	setItemETag(w, resp.Version)
+	hdl.WriteResponse(w, http.StatusOK, resp)
+}
+
+// Delete archive an item
+func (hdl *ItemHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	itemID, err := parseItemID(r)
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewItemUseCaseInteractor(
+		mysql.NewItemRepositoryImpl(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		mysql.NewTransactionManagerImpl(),
+		hdl.allocationStrategy,
+		hdl.stockAlertNotifier,
+	)
+
+	err = uc.DeleteItem(r.Context(), itemID)
+	if err != nil {
+		log.Printf("failed to delete item:%d\n", itemID)
+		return
 	}
 
 	// success
-	hdl.WriteResponse(w, http.StatusOK, itemResp)
+	w.WriteHeader(http.StatusNoContent)
 }
 
 func (hdl *ItemHandler) BuyItem(w http.ResponseWriter, r *http.Request) {
@@ -134,25 +425,13 @@ func (hdl *ItemHandler) BuyItem(w http.ResponseWriter, r *http.Request) {
 		hdl.SetError(w, err)
 	}()
 
-	itemIDStr := chi.URLParam(r, "item_id")
-	if itemIDStr == "" {
-		err = payload.Error{
-			Code:    payload.ErrCodeInvalidItemID,
-			Message: "not found item_id",
-			Param:   nil,
-			Type:    payload.ErrorTypeBadRequest,
-		}
+	itemID, err := parseItemID(r)
+	if err != nil {
 		return
 	}
 
-	itemID, err := strconv.ParseInt(itemIDStr, 10, 64)
+	expectedVersion, err := parseIfMatch(r)
 	if err != nil {
-		err = payload.Error{
-			Code:    payload.ErrCodeInvalidItemID,
-			Message: "failed to parse item_id",
-			Param:   itemIDStr,
-			Type:    payload.ErrorTypeInvalidArgument,
-		}
 		return
 	}
 
@@ -176,13 +455,22 @@ func (hdl *ItemHandler) BuyItem(w http.ResponseWriter, r *http.Request) {
 	uc := interactor.NewItemUseCaseInteractor(
 		mysql.NewItemRepositoryImpl(),
 		mysql.NewPurchaseRepositoryImpl(),
+		mysql.NewInventoryMovementRepositoryImpl(),
+		mysql.NewLocationStockRepositoryImpl(),
+		mysql.NewLocationRepositoryImpl(),
+		nil,
+		nil,
 		mysql.NewTransactionManagerImpl(),
+		hdl.allocationStrategy,
+		hdl.stockAlertNotifier,
 	)
 
 	// execute use case
 	purchase, err := uc.BuyItem(r.Context(), payload.PurchaseRequest{
-		ItemID:   valueobject.ItemID(itemID),
-		Quantity: req.Quantity,
+		ItemID:          itemID,
+		Quantity:        req.Quantity,
+		LocationID:      req.LocationID,
+		ExpectedVersion: expectedVersion,
 	})
 	if err != nil {
 		fmt.Printf("failed to buy item:%+v\n", purchase)
@@ -193,3 +481,56 @@ func (hdl *ItemHandler) BuyItem(w http.ResponseWriter, r *http.Request) {
 	resp := converter.ConvertPurchasePayloadToResponse(purchase)
 	hdl.WriteResponse(w, http.StatusCreated, resp)
 }
+
+// parseItemID get item id from url param
+func parseItemID(r *http.Request) (valueobject.ItemID, error) {
+	itemIDStr := chi.URLParam(r, "item_id")
+	if itemIDStr == "" {
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidItemID,
+			Message: "not found item_id",
+			Param:   nil,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+	}
+
+	itemID, err := strconv.ParseUint(itemIDStr, 10, 64)
+	if err != nil {
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidItemID,
+			Message: "failed to parse item_id",
+			Param:   itemIDStr,
+			Type:    payload.ErrorTypeInvalidArgument,
+		}
+	}
+
+	return valueobject.ItemID(itemID), nil
+}
+
+// setItemETag set the version of item as a strong ETag
+func setItemETag(w http.ResponseWriter, version uint64) {
+	w.Header().Set("ETag", fmt.Sprintf("\"%d\"", version))
+}
+
+// parseIfMatch get the expected version of item from If-Match header,
+// nil is returned when the header is absent or "*"
+func parseIfMatch(r *http.Request) (*uint64, error) {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, nil
+	}
+
+	// weak ETag never matches because If-Match uses the strong comparison
+	version, err := strconv.ParseUint(strings.Trim(ifMatch, "\""), 10, 64)
+	if err != nil || !strings.HasPrefix(ifMatch, "\"") || !strings.HasSuffix(ifMatch, "\"") {
+		log.Printf("invalid If-Match header:%s\n", ifMatch)
+		return nil, payload.Error{
+			Code:    payload.ErrCodeItemVersionMismatch,
+			Message: "'If-Match' does not match the ETag of item",
+			Param:   ifMatch,
+			Type:    payload.ErrorTypePreconditionFailed,
+		}
+	}
+
+	return &version, nil
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/tuanna7593/gosample/app/external/persistence/mysql"
+	"github.com/tuanna7593/gosample/app/interface/restapi/converter"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/interactor"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+type LocationHandler struct {
+	BaseHandler
+}
+
+// NewLocationHandler create a new handler for Locations
+func NewLocationHandler() *LocationHandler {
+	return &LocationHandler{}
+}
+
+// Create create a new location
+func (hdl *LocationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var (
+		req presenter.CreateLocationRequest
+		err error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	// decoding request body to struct
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		log.Printf("failed to decode request create location:%s\n", errDecode.Error())
+		err = payload.Error{
+			Message: "failed to decode create location request",
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return
+	}
+
+	// validate create location request
+	err = req.Validate()
+	if err != nil {
+		log.Println("invalid create location request")
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewLocationUseCaseInteractor(mysql.NewLocationRepositoryImpl())
+
+	// execute use case
+	location, err := uc.Create(r.Context(), converter.ConvertCreateLocationRequestToPayload(req))
+	if err != nil {
+		return
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusCreated, converter.ConvertLocationPayloadToResponse(location))
+}
+
+// List get all the locations
+func (hdl *LocationHandler) List(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	// init usecase
+	uc := interactor.NewLocationUseCaseInteractor(mysql.NewLocationRepositoryImpl())
+
+	locations, err := uc.List(r.Context())
+	if err != nil {
+		log.Println("failed to get locations")
+		return
+	}
+
+	// convert payload to prenseter
+	locationResp := make([]presenter.LocationResponse, len(locations))
+	for i := range locationResp {
+		locationResp[i] = converter.ConvertLocationPayloadToResponse(locations[i])
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusOK, locationResp)
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/external/persistence/mysql"
+	"github.com/tuanna7593/gosample/app/interface/restapi/converter"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/interactor"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+type OrderHandler struct {
+	BaseHandler
+	allocationStrategy valueobject.AllocationStrategy
+	stockAlertNotifier repository.StockAlertNotifier
+}
+
+// NewOrderHandler create a new handler for Orders
+func NewOrderHandler(
+	allocationStrategy valueobject.AllocationStrategy,
+	stockAlertNotifier repository.StockAlertNotifier,
+) *OrderHandler {
+	return &OrderHandler{
+		allocationStrategy: allocationStrategy,
+		stockAlertNotifier: stockAlertNotifier,
+	}
+}
+
+// Create checkout all the lines of an order
+func (hdl *OrderHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var (
+		req presenter.CreateOrderRequest
+		err error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	// decoding request body to struct
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		log.Printf("failed to decode request create order:%s\n", errDecode.Error())
+		err = payload.Error{
+			Message: "failed to decode create order request",
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return
+	}
+
+	// validate create order request
+	err = req.Validate()
+	if err != nil {
+		log.Println("invalid create order request")
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewOrderUseCaseInteractor(
+		mysql.NewItemRepositoryImpl(),
+		mysql.NewPurchaseRepositoryImpl(),
+		mysql.NewOrderRepositoryImpl(),
+		mysql.NewInventoryMovementRepositoryImpl(),
+		mysql.NewLocationStockRepositoryImpl(),
+		mysql.NewTransactionManagerImpl(),
+		hdl.allocationStrategy,
+		hdl.stockAlertNotifier,
+	)
+
+	// execute use case
+	order, err := uc.PlaceOrder(r.Context(), converter.ConvertCreateOrderRequestToPayload(req))
+	if err != nil {
+		log.Println("failed to place order")
+		return
+	}
+
+	// success
+	resp := converter.ConvertOrderPayloadToResponse(order)
+	hdl.WriteResponse(w, http.StatusCreated, resp)
+}
+
+// GetOrder get an order by id
+func (hdl *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	orderID, err := parseOrderID(r)
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewOrderUseCaseInteractor(
+		nil,
+		nil,
+		mysql.NewOrderRepositoryImpl(),
+		nil,
+		nil,
+		nil,
+		hdl.allocationStrategy,
+		hdl.stockAlertNotifier,
+	)
+
+	order, err := uc.GetOrder(r.Context(), orderID)
+	if err != nil {
+		log.Printf("failed to get order:%d\n", orderID)
+		return
+	}
+
+	// success
+	resp := converter.ConvertOrderPayloadToResponse(order)
+	hdl.WriteResponse(w, http.StatusOK, resp)
+}
+
+// parseOrderID get order id from url param
+func parseOrderID(r *http.Request) (valueobject.OrderID, error) {
+	orderIDStr := chi.URLParam(r, "order_id")
+	if orderIDStr == "" {
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidOrderID,
+			Message: "not found order_id",
+			Param:   nil,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+	}
+
+	orderID, err := strconv.ParseUint(orderIDStr, 10, 64)
+	if err != nil {
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidOrderID,
+			Message: "failed to parse order_id",
+			Param:   orderIDStr,
+			Type:    payload.ErrorTypeInvalidArgument,
+		}
+	}
+
+	return valueobject.OrderID(orderID), nil
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/external/persistence/mysql"
+	"github.com/tuanna7593/gosample/app/interface/restapi/converter"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/interactor"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+type ProductHandler struct {
+	BaseHandler
+}
+
+// NewProductHandler create a new handler for Products
+func NewProductHandler() *ProductHandler {
+	return &ProductHandler{}
+}
+
+// Create create a new product
+func (hdl *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var (
+		req presenter.CreateProductRequest
+		err error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	// decoding request body to struct
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		log.Printf("failed to decode request create product:%s\n", errDecode.Error())
+		err = payload.Error{
+			Message: "failed to decode create product request",
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return
+	}
+
+	// validate create product request
+	err = req.Validate()
+	if err != nil {
+		log.Println("invalid create product request")
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewProductUseCaseInteractor(mysql.NewProductRepositoryImpl(), nil)
+
+	// execute use case
+	product, err := uc.Create(r.Context(), converter.ConvertCreateProductRequestToPayload(req))
+	if err != nil {
+		return
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusCreated, converter.ConvertProductPayloadToResponse(product))
+}
+
+// GetProduct get a product with its variants
+func (hdl *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	productID, err := parseProductID(r)
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewProductUseCaseInteractor(mysql.NewProductRepositoryImpl(), mysql.NewItemRepositoryImpl())
+
+	product, err := uc.GetProduct(r.Context(), productID)
+	if err != nil {
+		log.Printf("failed to get product:%d\n", productID)
+		return
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusOK, converter.ConvertProductPayloadToResponse(product))
+}
+
+func parseProductID(r *http.Request) (valueobject.ProductID, error) {
+	productIDStr := chi.URLParam(r, "product_id")
+	if productIDStr == "" {
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidProductID,
+			Message: "not found product_id",
+			Param:   nil,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+	}
+
+	productID, err := strconv.ParseUint(productIDStr, 10, 64)
+	if err != nil {
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidProductID,
+			Message: "failed to parse product_id",
+			Param:   productIDStr,
+			Type:    payload.ErrorTypeInvalidArgument,
+		}
+	}
+
+	return valueobject.ProductID(productID), nil
+}
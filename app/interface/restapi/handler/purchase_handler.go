@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/external/persistence/mysql"
+	"github.com/tuanna7593/gosample/app/interface/restapi/converter"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/interactor"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+type PurchaseHandler struct {
+	BaseHandler
+	allocationStrategy valueobject.AllocationStrategy
+}
+
+// NewPurchaseHandler create a new handler for Purchases
+func NewPurchaseHandler(allocationStrategy valueobject.AllocationStrategy) *PurchaseHandler {
+	return &PurchaseHandler{
+		allocationStrategy: allocationStrategy,
+	}
+}
+
+// List get list purchase
+func (hdl *PurchaseHandler) List(w http.ResponseWriter, r *http.Request) {
+	hdl.list(w, r, 0)
+}
+
+// ListByItem get list purchase of an item
+func (hdl *PurchaseHandler) ListByItem(w http.ResponseWriter, r *http.Request) {
+	itemID, err := parseItemID(r)
+	if err != nil {
+		hdl.SetError(w, err)
+		return
+	}
+
+	hdl.list(w, r, itemID)
+}
+
+func (hdl *PurchaseHandler) list(w http.ResponseWriter, r *http.Request, itemID valueobject.ItemID) {
+	var (
+		paginationRequest presenter.PaginationRequest
+		filterRequest     presenter.PurchaseFilterRequest
+		err               error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	// parse pagination request
+	err = paginationRequest.Parse(r.URL.Query())
+	if err != nil {
+		log.Println("failed to parse query string to pagination")
+		return
+	}
+
+	// validate pagination request
+	err = paginationRequest.Valiate()
+	if err != nil {
+		log.Printf("invalid pagination request:%+v\n", paginationRequest)
+		return
+	}
+
+	// parse filter request
+	err = filterRequest.Parse(r.URL.Query())
+	if err != nil {
+		log.Println("failed to parse query string to purchase filter")
+		return
+	}
+
+	// validate filter request
+	err = filterRequest.Validate()
+	if err != nil {
+		log.Printf("invalid purchase filter request:%+v\n", filterRequest)
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewPurchaseUseCaseInteractor(
+		mysql.NewItemRepositoryImpl(),
+		mysql.NewPurchaseRepositoryImpl(),
+		nil,
+		nil,
+		nil,
+		hdl.allocationStrategy,
+	)
+
+	payloadFilter := converter.ConvertPurchaseFilterRequestToPayload(itemID, filterRequest)
+	purchases, err := uc.List(
+		r.Context(),
+		payloadFilter,
+		converter.ConvertPaginationRequestToPayload(paginationRequest),
+	)
+	if err != nil {
+		log.Println("failed to get purchases")
+		return
+	}
+
+	// the purchases are paged by the cursor
+	if paginationRequest.Cursor != nil {
+		hdl.WriteResponse(w, http.StatusOK, converter.ConvertPurchasePayloadsToCursorPage(purchases, paginationRequest.Limit))
+		return
+	}
+
+	// count the purchases matched the filter unless it's skipped
+	var total *int64
+	if !paginationRequest.SkipTotal {
+		count, errCount := uc.Count(r.Context(), payloadFilter)
+		if errCount != nil {
+			err = errCount
+			log.Println("failed to count purchases")
+			return
+		}
+		total = &count
+	}
+
+	// success
+	hdl.WriteResponse(w, http.StatusOK, converter.ConvertPurchasePayloadsToPage(purchases, paginationRequest, total, r.URL))
+}
+
+// GetPurchase get a purchase by id
+func (hdl *PurchaseHandler) GetPurchase(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	purchaseID, err := parsePurchaseID(r)
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewPurchaseUseCaseInteractor(
+		nil,
+		mysql.NewPurchaseRepositoryImpl(),
+		nil,
+		nil,
+		nil,
+		hdl.allocationStrategy,
+	)
+
+	purchase, err := uc.GetPurchase(r.Context(), purchaseID)
+	if err != nil {
+		log.Printf("failed to get purchase:%d\n", purchaseID)
+		return
+	}
+
+	// success
+	resp := converter.ConvertPurchasePayloadToResponse(purchase)
+	hdl.WriteResponse(w, http.StatusOK, resp)
+}
+
+// Refund refund a purchase and return the quantity to the stock of item
+func (hdl *PurchaseHandler) Refund(w http.ResponseWriter, r *http.Request) {
+	var (
+		req presenter.RefundPurchaseRequest
+		err error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	purchaseID, err := parsePurchaseID(r)
+	if err != nil {
+		return
+	}
+
+	// decoding request body to struct, the body is optional
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil && !errors.Is(errDecode, io.EOF) {
+		log.Printf("failed to decode request refund purchase:%s\n", errDecode.Error())
+		err = payload.Error{
+			Message: "failed to decode refund purchase request",
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return
+	}
+
+	// validate refund purchase request
+	err = req.Validate()
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewPurchaseUseCaseInteractor(
+		mysql.NewItemRepositoryImpl(),
+		mysql.NewPurchaseRepositoryImpl(),
+		mysql.NewInventoryMovementRepositoryImpl(),
+		mysql.NewLocationStockRepositoryImpl(),
+		mysql.NewTransactionManagerImpl(),
+		hdl.allocationStrategy,
+	)
+
+	// execute use case
+	purchase, err := uc.RefundPurchase(r.Context(), converter.ConvertRefundPurchaseRequestToPayload(purchaseID, req))
+	if err != nil {
+		log.Printf("failed to refund purchase:%d\n", purchaseID)
+		return
+	}
+
+	// success
+	resp := converter.ConvertPurchasePayloadToResponse(purchase)
+	hdl.WriteResponse(w, http.StatusOK, resp)
+}
+
+// parsePurchaseID get purchase id from url param
+func parsePurchaseID(r *http.Request) (valueobject.PurchaseID, error) {
+	purchaseIDStr := chi.URLParam(r, "purchase_id")
+	if purchaseIDStr == "" {
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidPurchaseID,
+			Message: "not found purchase_id",
+			Param:   nil,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+	}
+
+	purchaseID, err := strconv.ParseUint(purchaseIDStr, 10, 64)
+	if err != nil {
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidPurchaseID,
+			Message: "failed to parse purchase_id",
+			Param:   purchaseIDStr,
+			Type:    payload.ErrorTypeInvalidArgument,
+		}
+	}
+
+	return valueobject.PurchaseID(purchaseID), nil
+}
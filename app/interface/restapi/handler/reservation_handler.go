@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/external/persistence/mysql"
+	"github.com/tuanna7593/gosample/app/interface/restapi/converter"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/interactor"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+type ReservationHandler struct {
+	BaseHandler
+	allocationStrategy valueobject.AllocationStrategy
+}
+
+// NewReservationHandler create a new handler for Reservations
+func NewReservationHandler(allocationStrategy valueobject.AllocationStrategy) *ReservationHandler {
+	return &ReservationHandler{
+		allocationStrategy: allocationStrategy,
+	}
+}
+
+// Reserve hold the stock of an item until the reservation is confirmed or expired
+func (hdl *ReservationHandler) Reserve(w http.ResponseWriter, r *http.Request) {
+	var (
+		req presenter.ReserveItemRequest
+		err error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	itemID, err := parseItemID(r)
+	if err != nil {
+		return
+	}
+
+	// decoding request body to struct
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		log.Printf("failed to decode request reserve item:%s\n", errDecode.Error())
+		err = payload.Error{
+			Message: "failed to decode reserve item request",
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return
+	}
+
+	// validate reserve item request
+	err = req.Validate()
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewReservationUseCaseInteractor(
+		mysql.NewItemRepositoryImpl(),
+		nil,
+		mysql.NewReservationRepositoryImpl(),
+		mysql.NewInventoryMovementRepositoryImpl(),
+		mysql.NewLocationStockRepositoryImpl(),
+		mysql.NewTransactionManagerImpl(),
+		hdl.allocationStrategy,
+	)
+
+	// execute use case
+	reservation, err := uc.Reserve(r.Context(), converter.ConvertReserveItemRequestToPayload(itemID, req))
+	if err != nil {
+		log.Printf("failed to reserve item:%d\n", itemID)
+		return
+	}
+
+	// success
+	resp := converter.ConvertReservationPayloadToResponse(reservation)
+	hdl.WriteResponse(w, http.StatusCreated, resp)
+}
+
+// GetReservation get a reservation by id
+func (hdl *ReservationHandler) GetReservation(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	reservationID, err := parseReservationID(r)
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewReservationUseCaseInteractor(
+		nil,
+		nil,
+		mysql.NewReservationRepositoryImpl(),
+		nil,
+		nil,
+		nil,
+		hdl.allocationStrategy,
+	)
+
+	reservation, err := uc.GetReservation(r.Context(), reservationID)
+	if err != nil {
+		log.Printf("failed to get reservation:%d\n", reservationID)
+		return
+	}
+
+	// success
+	resp := converter.ConvertReservationPayloadToResponse(reservation)
+	hdl.WriteResponse(w, http.StatusOK, resp)
+}
+
+// Confirm turn a held reservation into a purchase
+func (hdl *ReservationHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	reservationID, err := parseReservationID(r)
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewReservationUseCaseInteractor(
+		mysql.NewItemRepositoryImpl(),
+		mysql.NewPurchaseRepositoryImpl(),
+		mysql.NewReservationRepositoryImpl(),
+		nil,
+		nil,
+		mysql.NewTransactionManagerImpl(),
+		hdl.allocationStrategy,
+	)
+
+	// execute use case
+	purchase, err := uc.Confirm(r.Context(), reservationID)
+	if err != nil {
+		log.Printf("failed to confirm reservation:%d\n", reservationID)
+		return
+	}
+
+	// success
+	resp := converter.ConvertPurchasePayloadToResponse(purchase)
+	hdl.WriteResponse(w, http.StatusCreated, resp)
+}
+
+// Cancel release a held reservation
+func (hdl *ReservationHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	reservationID, err := parseReservationID(r)
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewReservationUseCaseInteractor(
+		mysql.NewItemRepositoryImpl(),
+		mysql.NewPurchaseRepositoryImpl(),
+		mysql.NewReservationRepositoryImpl(),
+		mysql.NewInventoryMovementRepositoryImpl(),
+		mysql.NewLocationStockRepositoryImpl(),
+		mysql.NewTransactionManagerImpl(),
+		hdl.allocationStrategy,
+	)
+
+	// execute use case
+	reservation, err := uc.Cancel(r.Context(), reservationID)
+	if err != nil {
+		log.Printf("failed to cancel reservation:%d\n", reservationID)
+		return
+	}
+
+	// success
+	resp := converter.ConvertReservationPayloadToResponse(reservation)
+	hdl.WriteResponse(w, http.StatusOK, resp)
+}
+
+// parseReservationID get reservation id from url param
+func parseReservationID(r *http.Request) (valueobject.ReservationID, error) {
+	reservationIDStr := chi.URLParam(r, "reservation_id")
+	if reservationIDStr == "" {
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidReservationID,
+			Message: "not found reservation_id",
+			Param:   nil,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+	}
+
+	reservationID, err := strconv.ParseUint(reservationIDStr, 10, 64)
+	if err != nil {
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidReservationID,
+			Message: "failed to parse reservation_id",
+			Param:   reservationIDStr,
+			Type:    payload.ErrorTypeInvalidArgument,
+		}
+	}
+
+	return valueobject.ReservationID(reservationID), nil
+}
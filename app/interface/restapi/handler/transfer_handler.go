@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/external/persistence/mysql"
+	"github.com/tuanna7593/gosample/app/interface/restapi/converter"
+	"github.com/tuanna7593/gosample/app/interface/restapi/presenter"
+	"github.com/tuanna7593/gosample/app/usecase/interactor"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+type TransferHandler struct {
+	BaseHandler
+	allocationStrategy valueobject.AllocationStrategy
+}
+
+// NewTransferHandler create a new handler for Transfers
+func NewTransferHandler(allocationStrategy valueobject.AllocationStrategy) *TransferHandler {
+	return &TransferHandler{
+		allocationStrategy: allocationStrategy,
+	}
+}
+
+// Transfer send the stock of an item from a location to another
+func (hdl *TransferHandler) Transfer(w http.ResponseWriter, r *http.Request) {
+	var (
+		req presenter.TransferItemRequest
+		err error
+	)
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	itemID, err := parseItemID(r)
+	if err != nil {
+		return
+	}
+
+	// decoding request body to struct
+	if errDecode := json.NewDecoder(r.Body).Decode(&req); errDecode != nil {
+		log.Printf("failed to decode request transfer item:%s\n", errDecode.Error())
+		err = payload.Error{
+			Message: "failed to decode transfer item request",
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return
+	}
+
+	// validate transfer item request
+	err = req.Validate()
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewTransferUseCaseInteractor(
+		mysql.NewItemRepositoryImpl(),
+		nil,
+		mysql.NewInventoryMovementRepositoryImpl(),
+		mysql.NewLocationRepositoryImpl(),
+		mysql.NewLocationStockRepositoryImpl(),
+		mysql.NewTransferRepositoryImpl(),
+		mysql.NewTransactionManagerImpl(),
+		hdl.allocationStrategy,
+	)
+
+	// execute use case
+	transfer, err := uc.Transfer(r.Context(), converter.ConvertTransferItemRequestToPayload(itemID, req))
+	if err != nil {
+		log.Printf("failed to transfer item:%d\n", itemID)
+		return
+	}
+
+	// success
+	resp := converter.ConvertTransferPayloadToResponse(transfer)
+	hdl.WriteResponse(w, http.StatusCreated, resp)
+}
+
+// GetTransfer get a transfer by id
+func (hdl *TransferHandler) GetTransfer(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	transferID, err := parseTransferID(r)
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewTransferUseCaseInteractor(
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		mysql.NewTransferRepositoryImpl(),
+		nil,
+		hdl.allocationStrategy,
+	)
+
+	transfer, err := uc.GetTransfer(r.Context(), transferID)
+	if err != nil {
+		log.Printf("failed to get transfer:%d\n", transferID)
+		return
+	}
+
+	// success
+	resp := converter.ConvertTransferPayloadToResponse(transfer)
+	hdl.WriteResponse(w, http.StatusOK, resp)
+}
+
+// Receive confirm the receipt of a transfer at its destination location
+func (hdl *TransferHandler) Receive(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	defer func() {
+		hdl.SetError(w, err)
+	}()
+
+	transferID, err := parseTransferID(r)
+	if err != nil {
+		return
+	}
+
+	// init usecase
+	uc := interactor.NewTransferUseCaseInteractor(
+		mysql.NewItemRepositoryImpl(),
+		mysql.NewPurchaseRepositoryImpl(),
+		mysql.NewInventoryMovementRepositoryImpl(),
+		nil,
+		mysql.NewLocationStockRepositoryImpl(),
+		mysql.NewTransferRepositoryImpl(),
+		mysql.NewTransactionManagerImpl(),
+		hdl.allocationStrategy,
+	)
+
+	// execute use case
+	transfer, err := uc.Receive(r.Context(), transferID)
+	if err != nil {
+		log.Printf("failed to receive transfer:%d\n", transferID)
+		return
+	}
+
+	// success
+	resp := converter.ConvertTransferPayloadToResponse(transfer)
+	hdl.WriteResponse(w, http.StatusOK, resp)
+}
+
+// parseTransferID get transfer id from url param
+func parseTransferID(r *http.Request) (valueobject.TransferID, error) {
+	transferIDStr := chi.URLParam(r, "transfer_id")
+	if transferIDStr == "" {
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidTransferID,
+			Message: "not found transfer_id",
+			Param:   nil,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+	}
+
+	transferID, err := strconv.ParseUint(transferIDStr, 10, 64)
+	if err != nil {
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidTransferID,
+			Message: "failed to parse transfer_id",
+			Param:   transferIDStr,
+			Type:    payload.ErrorTypeInvalidArgument,
+		}
+	}
+
+	return valueobject.TransferID(transferID), nil
+}
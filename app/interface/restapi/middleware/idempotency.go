@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/tuanna7593/gosample/app/external/persistence/mysql"
+	"github.com/tuanna7593/gosample/app/interface/restapi/handler"
+	"github.com/tuanna7593/gosample/app/usecase/interactor"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+const (
+	// IdempotencyKeyHeader the header of idempotency key sent by clients
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader the header marks the response is a replay
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// Idempotency replay the stored response when a request is retried with the same Idempotency-Key,
+// requests without the header are passed through
+func Idempotency(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		base := handler.BaseHandler{}
+		if len(key) > maxIdempotencyKeyLength {
+			base.SetError(w, payload.Error{
+				Code:    payload.ErrCodeInvalidIdempotencyKey,
+				Message: "'Idempotency-Key' should not be longer than 255 characters",
+				Param:   key,
+				Type:    payload.ErrorTypeInvalidArgument,
+			})
+			return
+		}
+
+		// read the body to hash it, then restore it for the next handler
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Printf("failed to read request body:%s\n", err.Error())
+			base.SetError(w, payload.Error{
+				Message: "failed to read request body",
+				Type:    payload.ErrorTypeBadRequest,
+			})
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		req := payload.IdempotencyRequest{
+			Key:         key,
+			RequestHash: hashRequest(r, body),
+			RequestedAt: time.Now(),
+		}
+
+		// init usecase
+		uc := interactor.NewIdempotencyUseCaseInteractor(mysql.NewIdempotencyRepositoryImpl())
+
+		stored, err := uc.Start(r.Context(), req)
+		if err != nil {
+			base.SetError(w, err)
+			return
+		}
+
+		if stored != nil {
+			// replay the original response
+			w.Header().Set("Content-Type", "application/json")
+			for name, values := range stored.Header {
+				w.Header()[name] = values
+			}
+			w.Header().Set(IdempotentReplayedHeader, "true")
+			w.WriteHeader(stored.StatusCode)
+			if _, err := w.Write(stored.Body); err != nil {
+				log.Printf("failed to write replayed response:%s\n", err.Error())
+			}
+			return
+		}
+
+		// the outer recoverer skips the release below, so release the key before passing the panic on
+		defer func() {
+			if rec := recover(); rec != nil {
+				if err := uc.Release(r.Context(), req); err != nil {
+					log.Printf("failed to release idempotency key:%s - %v\n", key, err)
+				}
+				panic(rec)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r)
+
+		// the server errors are not stored so the client can retry
+		if recorder.statusCode == 0 || recorder.statusCode >= http.StatusInternalServerError {
+			if err := uc.Release(r.Context(), req); err != nil {
+				log.Printf("failed to release idempotency key:%s - %v\n", key, err)
+			}
+			return
+		}
+
+		err = uc.Complete(r.Context(), req, payload.IdempotentResponse{
+			StatusCode: recorder.statusCode,
+			Header:     recorder.header,
+			Body:       recorder.body.Bytes(),
+		})
+		if err != nil {
+			log.Printf("failed to store response of idempotency key:%s - %v\n", key, err)
+		}
+	})
+}
+
+// hashRequest identify a request by its method, path and body
+func hashRequest(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder keep the status code, headers and body written by the next handler
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	header     http.Header
+	body       bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(statusCode int) {
+	if rec.statusCode == 0 {
+		rec.statusCode = statusCode
+		// the headers changed after this are not sent
+		rec.header = rec.ResponseWriter.Header().Clone()
+	}
+	rec.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if rec.statusCode == 0 {
+		rec.statusCode = http.StatusOK
+		rec.header = rec.ResponseWriter.Header().Clone()
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
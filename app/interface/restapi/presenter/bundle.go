@@ -0,0 +1,111 @@
+package presenter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+const maxBundleNameLength = 255
+
+// CreateBundleRequest the presenter for create Bundles, an item is at most one component of a bundle
+type CreateBundleRequest struct {
+	Name       string                   `json:"name"`
+	Components []BundleComponentRequest `json:"components"`
+}
+
+type BundleComponentRequest struct {
+	ItemID   valueobject.ItemID `json:"item_id"`
+	Quantity uint64             `json:"quantity"`
+}
+
+// Validate check the request is valid
+func (p CreateBundleRequest) Validate() error {
+	errs := payload.Errors{}
+	if name := strings.TrimSpace(p.Name); name == "" || len(name) > maxBundleNameLength {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidBundleName,
+			Message: "'name' is required and should be at most 255 characters",
+			Param:   p.Name,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if len(p.Components) == 0 {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidBundleComponents,
+			Message: "'components' should have at least one component",
+			Param:   nil,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+
+	seen := make(map[valueobject.ItemID]bool, len(p.Components))
+	for i, component := range p.Components {
+		if component.ItemID == 0 {
+			errs = append(errs, payload.Error{
+				Code:    payload.ErrCodeInvalidItemID,
+				Message: fmt.Sprintf("'components[%d].item_id' should be greater than 0", i),
+				Param:   component.ItemID,
+				Type:    payload.ErrorTypeInvalidArgument,
+			})
+		} else if seen[component.ItemID] {
+			errs = append(errs, payload.Error{
+				Code:    payload.ErrCodeInvalidBundleComponents,
+				Message: fmt.Sprintf("'components[%d].item_id' is already a component of the bundle", i),
+				Param:   component.ItemID,
+				Type:    payload.ErrorTypeInvalidArgument,
+			})
+		}
+		seen[component.ItemID] = true
+
+		if component.Quantity == 0 {
+			errs = append(errs, payload.Error{
+				Code:    payload.ErrCodeInvalidBundleComponents,
+				Message: fmt.Sprintf("'components[%d].quantity' should be greater than 0", i),
+				Param:   component.Quantity,
+				Type:    payload.ErrorTypeInvalidArgument,
+			})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// BuyBundleRequest the quantity of bundles, each component is bought by its quantity times it
+type BuyBundleRequest struct {
+	Quantity uint64 `json:"quantity"`
+}
+
+// Validate check the request is valid
+func (p BuyBundleRequest) Validate() error {
+	if p.Quantity == 0 {
+		return payload.Error{
+			Code:    payload.ErrCodeInvalidBuyQuantity,
+			Message: "'quantity' should be greater than 0",
+			Param:   p.Quantity,
+			Type:    payload.ErrorTypeInvalidArgument,
+		}
+	}
+
+	return nil
+}
+
+// BundleResponse AvailableQuantity is the number of bundles which can be made from the current stock of components
+type BundleResponse struct {
+	ID                valueobject.BundleID      `json:"id"`
+	Name              string                    `json:"name"`
+	Components        []BundleComponentResponse `json:"components"`
+	AvailableQuantity uint64                    `json:"available_quantity"`
+	CreatedAt         int64                     `json:"created_at"`
+}
+
+type BundleComponentResponse struct {
+	ItemID   valueobject.ItemID `json:"item_id"`
+	Quantity uint64             `json:"quantity"`
+}
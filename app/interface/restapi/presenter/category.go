@@ -0,0 +1,72 @@
+package presenter
+
+import (
+	"strings"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+const maxCategoryNameLength = 255
+
+// CategoryRequest the presenter for create and update Categories,
+// the category is a root category when ParentID is null
+type CategoryRequest struct {
+	Name     string                  `json:"name"`
+	ParentID *valueobject.CategoryID `json:"parent_id"`
+}
+
+// Validate check the request is valid
+func (p CategoryRequest) Validate() error {
+	errs := payload.Errors{}
+	if name := strings.TrimSpace(p.Name); name == "" || len(name) > maxCategoryNameLength {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidCategoryName,
+			Message: "'name' is required and should be at most 255 characters",
+			Param:   p.Name,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if p.ParentID != nil && *p.ParentID == 0 {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidCategoryParent,
+			Message: "'parent_id' should be greater than 0",
+			Param:   *p.ParentID,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// ItemCategoriesRequest the categories of item are replaced with CategoryIDs
+type ItemCategoriesRequest struct {
+	CategoryIDs []valueobject.CategoryID `json:"category_ids"`
+}
+
+// Validate check the request is valid
+func (p ItemCategoriesRequest) Validate() error {
+	for _, categoryID := range p.CategoryIDs {
+		if categoryID == 0 {
+			return payload.Error{
+				Code:    payload.ErrCodeInvalidCategoryID,
+				Message: "'category_ids' should be greater than 0",
+				Param:   p.CategoryIDs,
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+		}
+	}
+
+	return nil
+}
+
+type CategoryResponse struct {
+	ID        valueobject.CategoryID  `json:"id"`
+	Name      string                  `json:"name"`
+	ParentID  *valueobject.CategoryID `json:"parent_id"`
+	CreatedAt int64                   `json:"created_at"`
+}
@@ -0,0 +1,108 @@
+package presenter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// RestockItemRequest the quantity is kept at the location if it is set
+type RestockItemRequest struct {
+	Quantity   uint64                  `json:"quantity"`
+	LocationID *valueobject.LocationID `json:"location_id"`
+}
+
+// Validate check the request is valid
+func (p RestockItemRequest) Validate() error {
+	errs := payload.Errors{}
+	if p.Quantity == 0 {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidRestockQuantity,
+			Message: "'quantity' should be greater than 0",
+			Param:   p.Quantity,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if err := validateLocationID(p.LocationID); err != nil {
+		errs = append(errs, *err)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// AdjustItemRequest the presenter for a manual correction of the stock of item
+type AdjustItemRequest struct {
+	Delta      int64                            `json:"delta"`
+	ReasonCode valueobject.AdjustmentReasonCode `json:"reason_code"`
+	Actor      string                           `json:"actor"`
+	Note       string                           `json:"note"`
+	LocationID *valueobject.LocationID          `json:"location_id"`
+}
+
+const (
+	maxAdjustmentActorLength = 64
+	maxAdjustmentNoteLength  = 255
+)
+
+// Validate check the request is valid
+func (p AdjustItemRequest) Validate() error {
+	errs := payload.Errors{}
+	if p.Delta == 0 {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidAdjustmentDelta,
+			Message: "'delta' should be different from 0",
+			Param:   p.Delta,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if !p.ReasonCode.IsValid() {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidAdjustmentReasonCode,
+			Message: "'reason_code' should be one of damaged, lost, found, recount, other",
+			Param:   p.ReasonCode,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if actor := strings.TrimSpace(p.Actor); actor == "" || len(actor) > maxAdjustmentActorLength {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidAdjustmentActor,
+			Message: fmt.Sprintf("'actor' is required and should be at most %d characters", maxAdjustmentActorLength),
+			Param:   p.Actor,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if len(p.Note) > maxAdjustmentNoteLength {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidAdjustmentNote,
+			Message: fmt.Sprintf("'note' should be at most %d characters", maxAdjustmentNoteLength),
+			Param:   nil,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if err := validateLocationID(p.LocationID); err != nil {
+		errs = append(errs, *err)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+type InventoryMovement struct {
+	ID         valueobject.InventoryMovementID  `json:"id"`
+	ItemID     valueobject.ItemID               `json:"item_id"`
+	Reason     valueobject.MovementReason       `json:"reason"`
+	Delta      int64                            `json:"delta"`
+	ReasonCode valueobject.AdjustmentReasonCode `json:"reason_code,omitempty"`
+	Actor      string                           `json:"actor,omitempty"`
+	Note       string                           `json:"note,omitempty"`
+	MovedAt    int64                            `json:"moved_at"`
+}
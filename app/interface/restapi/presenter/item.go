@@ -1,7 +1,15 @@
 package presenter
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
 	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -11,24 +19,44 @@ import (
 	"github.com/tuanna7593/gosample/app/usecase/payload"
 )
 
+// itemSKUPattern the sku of item is used in the lookup url so it only has url safe characters
+var itemSKUPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+const (
+	maxItemNameLength        = 255
+	maxItemDescriptionLength = 2000
+	maxItemSearchQueryLength = 255
+
+	// itemAttributeQueryPrefix the prefix of the query of the attribute filter like attr.color=red
+	itemAttributeQueryPrefix = "attr."
+)
+
 // CreateItemRequest the presenter for create Items
 type CreateItemRequest struct {
+	SKU             string          `json:"sku"`
+	Name            string          `json:"name"`
+	Description     string          `json:"description"`
 	TotalStockValue uint64          `json:"total_stock_value" validate:"min=1"`
 	SellingPrice    decimal.Decimal `json:"selling_price" validate:"monetary"`
+	// ReorderThreshold is optional, the low stock alert is disabled when it is 0
+	ReorderThreshold uint64 `json:"reorder_threshold"`
+	// Backorderable is optional, the item is bought even it is out of stock when it is true
+	Backorderable bool `json:"backorderable"`
+	// ProductID is optional, the item is created as a variant of the product when it is set
+	ProductID *valueobject.ProductID `json:"product_id"`
 }
 
 // Validate check the request is valid
 func (p CreateItemRequest) Validate() error {
-	v := validator.New()
-	v.RegisterCustomTypeFunc(validateDecimalType, decimal.Decimal{})
-	if err := v.RegisterValidation("monetary", validateMonetary); err != nil {
+	v, err := newItemValidator()
+	if err != nil {
 		return err
 	}
 
+	errs := payload.Errors{}
 	if err := v.Struct(p); err != nil {
 		switch e := err.(type) {
 		case validator.ValidationErrors:
-			errs := make(payload.Errors, 0, len(e))
 			for _, ee := range e {
 				switch f := ee.Field(); {
 				case f == "TotalStockValue":
@@ -47,15 +75,276 @@ func (p CreateItemRequest) Validate() error {
 					})
 				}
 			}
-			return errs
 		default:
 			return err
 		}
 	}
 
+	if p.ProductID != nil && *p.ProductID == 0 {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidProductID,
+			Message: "'product_id' should be greater than 0",
+			Param:   *p.ProductID,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if err := ValidateSKU(p.SKU); err != nil {
+		errs = append(errs, *err)
+	}
+	if name := strings.TrimSpace(p.Name); name == "" || len(name) > maxItemNameLength {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidItemName,
+			Message: "'name' is required and should be at most 255 characters",
+			Param:   p.Name,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if len(p.Description) > maxItemDescriptionLength {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidItemDescription,
+			Message: "'description' should be at most 2000 characters",
+			Param:   p.Description,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// ValidateSKU check the sku of item is valid
+func ValidateSKU(sku string) *payload.Error {
+	if itemSKUPattern.MatchString(sku) {
+		return nil
+	}
+
+	return &payload.Error{
+		Code:    payload.ErrCodeInvalidSKU,
+		Message: "'sku' should be 1 to 64 letters, digits, '-' or '_'",
+		Param:   sku,
+		Type:    payload.ErrorTypeInvalidArgument,
+	}
+}
+
+// updateItemFields the fields which can be updated, the other fields of item like the sku and name are rejected
+var updateItemFields = map[string]bool{
+	"total_stock_value": true,
+	"selling_price":     true,
+	"reorder_threshold": true,
+	"backorderable":     true,
+}
+
+// UpdateItemRequest the presenter for update Items.
+// PUT requires every field, PATCH follows JSON merge-patch semantics:
+// absent fields are left unchanged and null is rejected since no field is nullable.
+// ReorderThreshold and Backorderable are optional for both PUT and PATCH.
+// The unknown fields are rejected instead of being ignored silently.
+type UpdateItemRequest struct {
+	TotalStockValue  *uint64          `json:"total_stock_value" validate:"omitempty,min=1"`
+	SellingPrice     *decimal.Decimal `json:"selling_price" validate:"omitempty,monetary"`
+	ReorderThreshold *uint64          `json:"reorder_threshold"`
+	Backorderable    *bool            `json:"backorderable"`
+	Partial          bool             `json:"-"`
+}
+
+// Decode decode the request body to update item request
+func (p *UpdateItemRequest) Decode(body io.Reader) error {
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(body).Decode(&fields); err != nil {
+		log.Printf("failed to decode request update item:%s\n", err.Error())
+		return payload.Error{
+			Message: "failed to decode update item request",
+			Type:    payload.ErrorTypeBadRequest,
+		}
+	}
+
+	// the unknown fields are reported in the name order
+	unknownKeys := make([]string, 0)
+	for key := range fields {
+		if !updateItemFields[key] {
+			unknownKeys = append(unknownKeys, key)
+		}
+	}
+	if len(unknownKeys) > 0 {
+		sort.Strings(unknownKeys)
+		errs := make(payload.Errors, len(unknownKeys))
+		for i, key := range unknownKeys {
+			errs[i] = payload.Error{
+				Code:    payload.ErrCodeUnknownItemField,
+				Message: fmt.Sprintf("'%s' cannot be updated", key),
+				Param:   key,
+				Type:    payload.ErrorTypeBadRequest,
+			}
+		}
+		return errs
+	}
+
+	for key, value := range fields {
+		if string(value) != "null" {
+			continue
+		}
+
+		switch key {
+		case "total_stock_value":
+			return payload.Error{
+				Code:    payload.ErrCodeInvalidTotalStockValue,
+				Message: "'total_stock_value' cannot be null",
+				Param:   nil,
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+		case "selling_price":
+			return payload.Error{
+				Code:    payload.ErrCodeInvalidSellingPrice,
+				Message: "'selling_price' cannot be null",
+				Param:   nil,
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+		case "reorder_threshold":
+			return payload.Error{
+				Code:    payload.ErrCodeInvalidReorderThreshold,
+				Message: "'reorder_threshold' cannot be null",
+				Param:   nil,
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+		case "backorderable":
+			return payload.Error{
+				Code:    payload.ErrCodeInvalidBackorderable,
+				Message: "'backorderable' cannot be null",
+				Param:   nil,
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+		}
+	}
+
+	if raw, ok := fields["total_stock_value"]; ok {
+		var totalStockValue uint64
+		if err := json.Unmarshal(raw, &totalStockValue); err != nil {
+			return payload.Error{
+				Code:    payload.ErrCodeInvalidTotalStockValue,
+				Message: "'total_stock_value' should be an integer and greater than 0",
+				Param:   string(raw),
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+		}
+		p.TotalStockValue = &totalStockValue
+	}
+
+	if raw, ok := fields["selling_price"]; ok {
+		var sellingPrice decimal.Decimal
+		if err := json.Unmarshal(raw, &sellingPrice); err != nil {
+			return payload.Error{
+				Code:    payload.ErrCodeInvalidSellingPrice,
+				Message: "'selling_price' should be a positive decimal value to two decimal places",
+				Param:   string(raw),
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+		}
+		p.SellingPrice = &sellingPrice
+	}
+
+	if raw, ok := fields["reorder_threshold"]; ok {
+		var reorderThreshold uint64
+		if err := json.Unmarshal(raw, &reorderThreshold); err != nil {
+			return payload.Error{
+				Code:    payload.ErrCodeInvalidReorderThreshold,
+				Message: "'reorder_threshold' should be an integer and greater than or equal to 0",
+				Param:   string(raw),
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+		}
+		p.ReorderThreshold = &reorderThreshold
+	}
+
+	if raw, ok := fields["backorderable"]; ok {
+		var backorderable bool
+		if err := json.Unmarshal(raw, &backorderable); err != nil {
+			return payload.Error{
+				Code:    payload.ErrCodeInvalidBackorderable,
+				Message: "'backorderable' should be a boolean",
+				Param:   string(raw),
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+		}
+		p.Backorderable = &backorderable
+	}
+
+	return nil
+}
+
+// Validate check the request is valid
+func (p UpdateItemRequest) Validate() error {
+	errs := payload.Errors{}
+	if !p.Partial {
+		if p.TotalStockValue == nil {
+			errs = append(errs, payload.Error{
+				Code:    payload.ErrCodeInvalidTotalStockValue,
+				Message: "'total_stock_value' is required",
+				Param:   nil,
+				Type:    payload.ErrorTypeInvalidArgument,
+			})
+		}
+		if p.SellingPrice == nil {
+			errs = append(errs, payload.Error{
+				Code:    payload.ErrCodeInvalidSellingPrice,
+				Message: "'selling_price' is required",
+				Param:   nil,
+				Type:    payload.ErrorTypeInvalidArgument,
+			})
+		}
+	}
+
+	v, err := newItemValidator()
+	if err != nil {
+		return err
+	}
+
+	if err := v.Struct(p); err != nil {
+		switch e := err.(type) {
+		case validator.ValidationErrors:
+			for _, ee := range e {
+				switch f := ee.Field(); {
+				case f == "TotalStockValue":
+					errs = append(errs, payload.Error{
+						Code:    payload.ErrCodeInvalidTotalStockValue,
+						Message: "'total_stock_value' should be greater than 0",
+						Param:   *p.TotalStockValue,
+						Type:    payload.ErrorTypeInvalidArgument,
+					})
+				case f == "SellingPrice":
+					errs = append(errs, payload.Error{
+						Code:    payload.ErrCodeInvalidSellingPrice,
+						Message: "'selling_price' should be a positive decimal value to two decimal places",
+						Param:   *p.SellingPrice,
+						Type:    payload.ErrorTypeInvalidArgument,
+					})
+				}
+			}
+		default:
+			return err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
 	return nil
 }
 
+// newItemValidator create a validator with the custom rules of item
+func newItemValidator() (*validator.Validate, error) {
+	v := validator.New()
+	v.RegisterCustomTypeFunc(validateDecimalType, decimal.Decimal{})
+	if err := v.RegisterValidation("monetary", validateMonetary); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
 func validateDecimalType(field reflect.Value) interface{} {
 	if valuer, ok := field.Interface().(decimal.Decimal); ok {
 		val, err := valuer.Value()
@@ -89,27 +378,335 @@ func validateMonetary(fl validator.FieldLevel) bool {
 }
 
 type ItemResponse struct {
-	ID                valueobject.ItemID `json:"id"`
-	PlacedAt          int64              `json:"placed_at"`
-	TotalStockValue   uint64             `json:"total_stock_value"`
-	CurrentStockValue uint64             `json:"current_stock_value"`
-	SellingPrice      decimal.Decimal    `json:"selling_price"`
+	ID                 valueobject.ItemID `json:"id"`
+	SKU                string             `json:"sku"`
+	Name               string             `json:"name"`
+	Description        string             `json:"description"`
+	PlacedAt           int64              `json:"placed_at"`
+	TotalStockValue    uint64             `json:"total_stock_value"`
+	CurrentStockValue  uint64             `json:"current_stock_value"`
+	ReservedStockValue uint64             `json:"reserved_stock_value"`
+	// the current stock is split into the located stock and the unassigned stock
+	LocatedStockValue    uint64 `json:"located_stock_value"`
+	UnassignedStockValue uint64 `json:"unassigned_stock_value"`
+	InTransitStockValue  uint64 `json:"in_transit_stock_value"`
+	// BackorderedStockValue the quantity of the backordered purchases which wait for the stock
+	BackorderedStockValue uint64                      `json:"backordered_stock_value"`
+	Backorderable         bool                        `json:"backorderable"`
+	ReorderThreshold      uint64                      `json:"reorder_threshold"`
+	Locations             []ItemLocationStockResponse `json:"locations,omitempty"`
+	SellingPrice          decimal.Decimal             `json:"selling_price"`
+	Version               uint64                      `json:"version"`
+	// ProductID the parent product of the variant, it is null when the item is not a variant
+	ProductID *valueobject.ProductID `json:"product_id"`
 }
 
+// BuyItemRequest the stock is taken from the location if it is set
 type BuyItemRequest struct {
-	Quantity uint64 `json:"quantity" validate:"min=1"`
+	Quantity   uint64                  `json:"quantity"`
+	LocationID *valueobject.LocationID `json:"location_id"`
 }
 
 // Validate check the request is valid
 func (p BuyItemRequest) Validate() error {
-	if err := validator.New().Struct(p); err != nil {
-		return payload.Error{
+	errs := payload.Errors{}
+	if p.Quantity == 0 {
+		errs = append(errs, payload.Error{
 			Code:    payload.ErrCodeInvalidBuyQuantity,
 			Message: "'quantity' should be greater than 0",
 			Param:   p.Quantity,
 			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if err := validateLocationID(p.LocationID); err != nil {
+		errs = append(errs, *err)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// ItemFilterRequest the price range, the created time range in unix seconds and the sort of items,
+// zero value is ignored. Sort is a comma separated list of fields, a field prefixed by '-' is sorted descending.
+// Every 'tag' query and 'attr.<key>=<value>' query must be matched by the items
+type ItemFilterRequest struct {
+	MinPrice    *decimal.Decimal
+	MaxPrice    *decimal.Decimal
+	InStock     bool
+	CreatedFrom int64
+	CreatedTo   int64
+	// ProductID the variants of the product
+	ProductID valueobject.ProductID
+	// CategoryID the items in the category or its descendants
+	CategoryID valueobject.CategoryID
+	Tags       []string
+	Attributes []valueobject.ItemAttribute
+	Sorts      []valueobject.ItemSort
+	// paged the items are paged by the cursor which has its own order so they cannot be sorted
+	paged bool
+}
+
+func (p *ItemFilterRequest) Parse(qs url.Values) error {
+	minPrice, err := parseQueryPrice(qs, "min_price")
+	if err != nil {
+		return err
+	}
+	p.MinPrice = minPrice
+
+	maxPrice, err := parseQueryPrice(qs, "max_price")
+	if err != nil {
+		return err
+	}
+	p.MaxPrice = maxPrice
+
+	if inStockStr := qs.Get("in_stock"); inStockStr != "" {
+		inStock, err := strconv.ParseBool(inStockStr)
+		if err != nil {
+			log.Printf("failed to parse in_stock query to bool:%s\n", inStockStr)
+			return payload.Error{
+				Code:    payload.ErrCodeInvalidInStock,
+				Message: "'in_stock' should be a boolean",
+				Param:   inStockStr,
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+		}
+		p.InStock = inStock
+	}
+
+	p.CreatedFrom, err = parseQueryUnix(qs, "created_from")
+	if err != nil {
+		return err
+	}
+
+	p.CreatedTo, err = parseQueryUnix(qs, "created_to")
+	if err != nil {
+		return err
+	}
+
+	if productIDStr := qs.Get("product_id"); productIDStr != "" {
+		productID, err := strconv.ParseUint(productIDStr, 10, 64)
+		if err != nil || productID == 0 {
+			log.Printf("failed to parse product_id query to uint64:%s\n", productIDStr)
+			return payload.Error{
+				Code:    payload.ErrCodeInvalidProductID,
+				Message: "'product_id' should be greater than 0",
+				Param:   productIDStr,
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+		}
+		p.ProductID = valueobject.ProductID(productID)
+	}
+
+	if categoryIDStr := qs.Get("category_id"); categoryIDStr != "" {
+		categoryID, err := strconv.ParseUint(categoryIDStr, 10, 64)
+		if err != nil || categoryID == 0 {
+			log.Printf("failed to parse category_id query to uint64:%s\n", categoryIDStr)
+			return payload.Error{
+				Code:    payload.ErrCodeInvalidCategoryID,
+				Message: "'category_id' should be greater than 0",
+				Param:   categoryIDStr,
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+		}
+		p.CategoryID = valueobject.CategoryID(categoryID)
+	}
+
+	p.Tags = qs["tag"]
+
+	// the attributes are sorted by key so the query of the same filter is the same
+	var attributeKeys []string
+	for key := range qs {
+		if strings.HasPrefix(key, itemAttributeQueryPrefix) {
+			attributeKeys = append(attributeKeys, key)
+		}
+	}
+	sort.Strings(attributeKeys)
+	for _, key := range attributeKeys {
+		p.Attributes = append(p.Attributes, valueobject.ItemAttribute{
+			Key:   strings.TrimPrefix(key, itemAttributeQueryPrefix),
+			Value: qs.Get(key),
+		})
+	}
+
+	if sortStr := qs.Get("sort"); sortStr != "" {
+		fields := strings.Split(sortStr, ",")
+		p.Sorts = make([]valueobject.ItemSort, len(fields))
+		for i, field := range fields {
+			field = strings.TrimSpace(field)
+			p.Sorts[i] = valueobject.ItemSort{
+				Field:      valueobject.ItemSortField(strings.TrimPrefix(field, "-")),
+				Descending: strings.HasPrefix(field, "-"),
+			}
+		}
+	}
+
+	_, p.paged = qs["cursor"]
+
+	return nil
+}
+
+// Validate check the request is valid
+func (p ItemFilterRequest) Validate() error {
+	errs := payload.Errors{}
+	if (p.MinPrice != nil && p.MinPrice.IsNegative()) ||
+		(p.MaxPrice != nil && p.MaxPrice.IsNegative()) ||
+		(p.MinPrice != nil && p.MaxPrice != nil && p.MinPrice.GreaterThan(*p.MaxPrice)) {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidPriceRange,
+			Message: "'min_price' and 'max_price' should not be negative and 'min_price' should not be greater than 'max_price'",
+			Param:   map[string]*decimal.Decimal{"min_price": p.MinPrice, "max_price": p.MaxPrice},
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if p.CreatedFrom < 0 || p.CreatedTo < 0 || (p.CreatedFrom > 0 && p.CreatedTo > 0 && p.CreatedFrom > p.CreatedTo) {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidDateRange,
+			Message: "'created_from' and 'created_to' should be positive and 'created_from' should not be after 'created_to'",
+			Param:   map[string]int64{"created_from": p.CreatedFrom, "created_to": p.CreatedTo},
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+
+	if p.paged && len(p.Sorts) > 0 {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidSort,
+			Message: "'sort' cannot be used with 'cursor', the items are paged by the cursor in the created_at order",
+			Param:   p.Sorts,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+
+	for _, tag := range p.Tags {
+		if err := ValidateItemTag(tag); err != nil {
+			errs = append(errs, *err)
+			break
+		}
+	}
+
+	for _, attribute := range p.Attributes {
+		if err := ValidateItemAttributeKey(attribute.Key); err != nil {
+			errs = append(errs, *err)
+			break
+		}
+		if err := validateItemAttributeValue(attribute.Value); err != nil {
+			errs = append(errs, *err)
+			break
+		}
+	}
+
+	sorted := make(map[valueobject.ItemSortField]bool, len(p.Sorts))
+	for _, sort := range p.Sorts {
+		if !sort.Field.IsValid() || sorted[sort.Field] {
+			errs = append(errs, payload.Error{
+				Code:    payload.ErrCodeInvalidSort,
+				Message: "'sort' should be a list of distinct fields in selling_price, created_at and current_stock_value",
+				Param:   sort.Field,
+				Type:    payload.ErrorTypeInvalidArgument,
+			})
+			break
+		}
+		sorted[sort.Field] = true
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// parseQueryPrice parse the decimal query of key, nil is returned when the query is absent
+func parseQueryPrice(qs url.Values, key string) (*decimal.Decimal, error) {
+	priceStr := qs.Get(key)
+	if priceStr == "" {
+		return nil, nil
+	}
+
+	price, err := decimal.NewFromString(priceStr)
+	if err != nil {
+		log.Printf("failed to parse %s query to decimal:%s\n", key, priceStr)
+		return nil, payload.Error{
+			Code:    payload.ErrCodeInvalidPriceRange,
+			Message: fmt.Sprintf("'%s' should be a decimal value", key),
+			Param:   priceStr,
+			Type:    payload.ErrorTypeInvalidArgument,
 		}
 	}
 
+	return &price, nil
+}
+
+// parseQueryUnix parse the unix timestamp query of key, 0 is returned when the query is absent
+func parseQueryUnix(qs url.Values, key string) (int64, error) {
+	unixStr := qs.Get(key)
+	if unixStr == "" {
+		return 0, nil
+	}
+
+	unix, err := strconv.ParseInt(unixStr, 10, 64)
+	if err != nil {
+		log.Printf("failed to parse %s query to int64:%s\n", key, unixStr)
+		return 0, payload.Error{
+			Code:    payload.ErrCodeInvalidDateRange,
+			Message: fmt.Sprintf("'%s' should be an unix timestamp", key),
+			Param:   unixStr,
+			Type:    payload.ErrorTypeInvalidArgument,
+		}
+	}
+
+	return unix, nil
+}
+
+// ItemSearchRequest the words of Query are matched as the prefixes of the words in the name and description of items
+type ItemSearchRequest struct {
+	Query string
+	// paged the items are ordered by relevance so they cannot be paged by the cursor
+	paged bool
+}
+
+func (p *ItemSearchRequest) Parse(qs url.Values) {
+	p.Query = strings.TrimSpace(qs.Get("q"))
+	_, p.paged = qs["cursor"]
+}
+
+// Validate check the request is valid
+func (p ItemSearchRequest) Validate() error {
+	errs := payload.Errors{}
+	if len(valueobject.SplitSearchWords(p.Query)) == 0 || len(p.Query) > maxItemSearchQueryLength {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidSearchQuery,
+			Message: "'q' is required, it should have a letter or digit and be at most 255 characters",
+			Param:   p.Query,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if p.paged {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidCursor,
+			Message: "'cursor' cannot be used with 'q', the items are ordered by relevance",
+			Param:   p.Query,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
 	return nil
 }
+
+// ItemSearchHitResponse the matched words are wrapped in <em> in the HTML escaped highlight
+type ItemSearchHitResponse struct {
+	Item      ItemResponse          `json:"item"`
+	Score     float64               `json:"score"`
+	Highlight ItemHighlightResponse `json:"highlight"`
+}
+
+type ItemHighlightResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
@@ -0,0 +1,77 @@
+package presenter
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// itemLabelPattern the tags and attribute keys are used in the url and the query string,
+// so they only have lower case letters, digits, '-' or '_'
+var itemLabelPattern = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+const maxItemAttributeValueLength = 255
+
+// ItemAttributeRequest the presenter for set the attribute of Items, the key is in the url
+type ItemAttributeRequest struct {
+	Value string `json:"value"`
+}
+
+// Validate check the request is valid
+func (p ItemAttributeRequest) Validate() error {
+	if err := validateItemAttributeValue(p.Value); err != nil {
+		return *err
+	}
+
+	return nil
+}
+
+type ItemAttributeResponse struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ValidateItemTag check the tag of item is valid
+func ValidateItemTag(tag string) *payload.Error {
+	if itemLabelPattern.MatchString(tag) {
+		return nil
+	}
+
+	return &payload.Error{
+		Code:    payload.ErrCodeInvalidTag,
+		Message: "'tag' should be 1 to 64 lower case letters, digits, '-' or '_'",
+		Param:   tag,
+		Type:    payload.ErrorTypeInvalidArgument,
+	}
+}
+
+// ValidateItemAttributeKey check the attribute key of item is valid
+func ValidateItemAttributeKey(key string) *payload.Error {
+	if itemLabelPattern.MatchString(key) {
+		return nil
+	}
+
+	return &payload.Error{
+		Code:    payload.ErrCodeInvalidAttributeKey,
+		Message: "'key' should be 1 to 64 lower case letters, digits, '-' or '_'",
+		Param:   key,
+		Type:    payload.ErrorTypeInvalidArgument,
+	}
+}
+
+// validateItemAttributeValue the value is free-form text without control characters
+func validateItemAttributeValue(value string) *payload.Error {
+	trimmed := strings.TrimSpace(value)
+	if trimmed != "" && len(trimmed) <= maxItemAttributeValueLength && strings.IndexFunc(trimmed, unicode.IsControl) < 0 {
+		return nil
+	}
+
+	return &payload.Error{
+		Code:    payload.ErrCodeInvalidAttributeValue,
+		Message: "'value' is required, it should be at most 255 characters without control characters",
+		Param:   value,
+		Type:    payload.ErrorTypeInvalidArgument,
+	}
+}
@@ -0,0 +1,73 @@
+package presenter
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// locationCodePattern the code of location is short and readable like "HN-01"
+var locationCodePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+const maxLocationNameLength = 255
+
+// CreateLocationRequest the presenter for create Locations
+type CreateLocationRequest struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// Validate check the request is valid
+func (p CreateLocationRequest) Validate() error {
+	errs := payload.Errors{}
+	if !locationCodePattern.MatchString(p.Code) {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidLocationCode,
+			Message: "'code' should be 1 to 32 letters, digits, '-' or '_'",
+			Param:   p.Code,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if name := strings.TrimSpace(p.Name); name == "" || len(name) > maxLocationNameLength {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidLocationName,
+			Message: "'name' is required and should be at most 255 characters",
+			Param:   p.Name,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+type LocationResponse struct {
+	ID        valueobject.LocationID `json:"id"`
+	Code      string                 `json:"code"`
+	Name      string                 `json:"name"`
+	CreatedAt int64                  `json:"created_at"`
+}
+
+type ItemLocationStockResponse struct {
+	LocationID        valueobject.LocationID `json:"location_id"`
+	CurrentStockValue uint64                 `json:"current_stock_value"`
+}
+
+// validateLocationID check the optional location id of a request
+func validateLocationID(locationID *valueobject.LocationID) *payload.Error {
+	if locationID == nil || *locationID > 0 {
+		return nil
+	}
+
+	return &payload.Error{
+		Code:    payload.ErrCodeInvalidLocationID,
+		Message: "'location_id' should be greater than 0",
+		Param:   *locationID,
+		Type:    payload.ErrorTypeInvalidArgument,
+	}
+}
@@ -0,0 +1,73 @@
+package presenter
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// CreateOrderRequest the presenter for checkout many items at once
+type CreateOrderRequest struct {
+	Lines []OrderLineRequest `json:"lines"`
+}
+
+type OrderLineRequest struct {
+	ItemID   valueobject.ItemID `json:"item_id"`
+	Quantity uint64             `json:"quantity"`
+}
+
+// Validate check the request is valid
+func (p CreateOrderRequest) Validate() error {
+	if len(p.Lines) == 0 {
+		return payload.Error{
+			Code:    payload.ErrCodeInvalidOrderLines,
+			Message: "'lines' should have at least one line",
+			Param:   nil,
+			Type:    payload.ErrorTypeInvalidArgument,
+		}
+	}
+
+	errs := payload.Errors{}
+	for i, line := range p.Lines {
+		if line.ItemID == 0 {
+			errs = append(errs, payload.Error{
+				Code:    payload.ErrCodeInvalidItemID,
+				Message: fmt.Sprintf("'lines[%d].item_id' should be greater than 0", i),
+				Param:   line.ItemID,
+				Type:    payload.ErrorTypeInvalidArgument,
+			})
+		}
+		if line.Quantity == 0 {
+			errs = append(errs, payload.Error{
+				Code:    payload.ErrCodeInvalidBuyQuantity,
+				Message: fmt.Sprintf("'lines[%d].quantity' should be greater than 0", i),
+				Param:   line.Quantity,
+				Type:    payload.ErrorTypeInvalidArgument,
+			})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+type Order struct {
+	ID         valueobject.OrderID `json:"id"`
+	PlacedAt   int64               `json:"placed_at"`
+	TotalPrice decimal.Decimal     `json:"total_price"`
+	Lines      []OrderLine         `json:"lines"`
+}
+
+type OrderLine struct {
+	ItemID     valueobject.ItemID     `json:"item_id"`
+	PurchaseID valueobject.PurchaseID `json:"purchase_id"`
+	Quantity   uint64                 `json:"quantity"`
+	UnitPrice  decimal.Decimal        `json:"unit_price"`
+	LineTotal  decimal.Decimal        `json:"line_total"`
+}
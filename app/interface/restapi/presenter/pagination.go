@@ -1,18 +1,27 @@
 package presenter
 
 import (
+	"encoding/base64"
+	"fmt"
 	"log"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
 	"github.com/tuanna7593/gosample/app/usecase/payload"
 )
 
+// PaginationRequest the rows are paged by the cursor instead of the page when the cursor query is present,
+// an empty cursor starts from the first row. SkipTotal skip counting the rows of the listings which return the total
 type PaginationRequest struct {
-	Page  int64 `validate:"min=1"`
-	Limit int64 `validate:"min=1"`
+	Page      int64 `validate:"min=1"`
+	Limit     int64 `validate:"min=1"`
+	Cursor    *valueobject.Cursor
+	SkipTotal bool
 }
 
 func (p *PaginationRequest) Valiate() error {
@@ -81,5 +90,137 @@ func (p *PaginationRequest) Parse(qs url.Values) error {
 		p.Limit = limit
 	}
 
+	if includeTotalStr := qs.Get("include_total"); includeTotalStr != "" {
+		includeTotal, err := strconv.ParseBool(includeTotalStr)
+		if err != nil {
+			log.Printf("failed to parse include_total query to bool:%s\n", includeTotalStr)
+			return payload.Error{
+				Code:    payload.ErrCodeInvalidIncludeTotal,
+				Message: "'include_total' should be a boolean",
+				Param:   includeTotalStr,
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+		}
+		p.SkipTotal = !includeTotal
+	}
+
+	if _, ok := qs["cursor"]; ok {
+		cursorStr := qs.Get("cursor")
+		cursor, err := DecodeCursor(cursorStr)
+		if err != nil {
+			log.Printf("failed to decode cursor query:%s - %v\n", cursorStr, err)
+			return payload.Error{
+				Code:    payload.ErrCodeInvalidCursor,
+				Message: "'cursor' should be the next_cursor of the previous page",
+				Param:   cursorStr,
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+		}
+		p.Cursor = &cursor
+	}
+
 	return nil
 }
+
+// EncodeCursor encode the cursor to an opaque string which is safe in the url
+func EncodeCursor(cursor valueobject.Cursor) string {
+	raw := fmt.Sprintf("%d:%d", cursor.CreatedAt.UnixNano(), cursor.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor decode the string encoded by EncodeCursor, an empty string is the zero cursor
+func DecodeCursor(s string) (valueobject.Cursor, error) {
+	if s == "" {
+		return valueobject.Cursor{}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return valueobject.Cursor{}, err
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 2 {
+		return valueobject.Cursor{}, fmt.Errorf("malformed cursor:%s", raw)
+	}
+
+	createdAt, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return valueobject.Cursor{}, err
+	}
+
+	id, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return valueobject.Cursor{}, err
+	}
+
+	return valueobject.Cursor{
+		CreatedAt: time.Unix(0, createdAt),
+		ID:        id,
+	}, nil
+}
+
+// CursorPage the response of a page which is paged by the cursor,
+// NextCursor is empty when there is no more row
+type CursorPage struct {
+	Data       interface{} `json:"data"`
+	NextCursor string      `json:"next_cursor"`
+}
+
+// NewCursorPage create the cursor page of data, next is nil when there is no more row
+func NewCursorPage(data interface{}, next *valueobject.Cursor) CursorPage {
+	page := CursorPage{
+		Data: data,
+	}
+	if next != nil {
+		page.NextCursor = EncodeCursor(*next)
+	}
+
+	return page
+}
+
+// PageResponse the envelope of a page which is paged by the page and limit,
+// Total is omitted when the count is skipped. Next and Prev are the links to the adjacent pages
+type PageResponse struct {
+	Data  interface{} `json:"data"`
+	Page  int64       `json:"page"`
+	Limit int64       `json:"limit"`
+	Total *int64      `json:"total,omitempty"`
+	Next  *string     `json:"next"`
+	Prev  *string     `json:"prev"`
+}
+
+// NewPageResponse create the envelope of data which has count rows of the page of pagination.
+// The next page exists when the total is over the page, or when the page is full if the total is skipped
+func NewPageResponse(data interface{}, count int, pagination PaginationRequest, total *int64, u *url.URL) PageResponse {
+	resp := PageResponse{
+		Data:  data,
+		Page:  pagination.Page,
+		Limit: pagination.Limit,
+		Total: total,
+	}
+
+	hasNext := int64(count) == pagination.Limit
+	if total != nil {
+		hasNext = pagination.Page*pagination.Limit < *total
+	}
+	if hasNext {
+		next := pageLink(u, pagination.Page+1)
+		resp.Next = &next
+	}
+	if pagination.Page > 1 {
+		prev := pageLink(u, pagination.Page-1)
+		resp.Prev = &prev
+	}
+
+	return resp
+}
+
+// pageLink the link of the request u with the page replaced
+func pageLink(u *url.URL, page int64) string {
+	link := *u
+	qs := link.Query()
+	qs.Set("page", strconv.FormatInt(page, 10))
+	link.RawQuery = qs.Encode()
+	return link.RequestURI()
+}
@@ -0,0 +1,55 @@
+package presenter
+
+import (
+	"strings"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// CreateProductRequest the presenter for create Products,
+// the variants are created as items with the product_id of the product
+type CreateProductRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Validate check the request is valid
+func (p CreateProductRequest) Validate() error {
+	errs := payload.Errors{}
+	if name := strings.TrimSpace(p.Name); name == "" || len(name) > maxItemNameLength {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidProductName,
+			Message: "'name' is required and should be at most 255 characters",
+			Param:   p.Name,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if len(p.Description) > maxItemDescriptionLength {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidProductDescription,
+			Message: "'description' should be at most 2000 characters",
+			Param:   p.Description,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// ProductResponse the stock values are the sums of the stock of the variants
+type ProductResponse struct {
+	ID                    valueobject.ProductID `json:"id"`
+	Name                  string                `json:"name"`
+	Description           string                `json:"description"`
+	TotalStockValue       uint64                `json:"total_stock_value"`
+	CurrentStockValue     uint64                `json:"current_stock_value"`
+	ReservedStockValue    uint64                `json:"reserved_stock_value"`
+	BackorderedStockValue uint64                `json:"backordered_stock_value"`
+	Variants              []ItemResponse        `json:"variants"`
+	CreatedAt             int64                 `json:"created_at"`
+}
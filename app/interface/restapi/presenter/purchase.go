@@ -1,12 +1,95 @@
 package presenter
 
 import (
+	"log"
+	"net/url"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+
 	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
 )
 
 type Purchase struct {
-	ID       valueobject.PurchaseID `json:"id"`
-	ItemID   valueobject.ItemID     `json:"item_id"`
-	Quantity uint64                 `json:"quantity"`
-	BoughtAt int64                  `json:"bought_at"`
+	ID               valueobject.PurchaseID `json:"id"`
+	ItemID           valueobject.ItemID     `json:"item_id"`
+	Quantity         uint64                 `json:"quantity"`
+	BoughtAt         int64                  `json:"bought_at"`
+	RefundedQuantity uint64                 `json:"refunded_quantity"`
+	RefundedAt       *int64                 `json:"refunded_at"`
+	// a backordered purchase is fulfilled by a restock of item
+	Status      valueobject.PurchaseStatus `json:"status"`
+	FulfilledAt *int64                     `json:"fulfilled_at"`
+}
+
+// RefundPurchaseRequest the remaining quantity is refunded when quantity is omitted
+type RefundPurchaseRequest struct {
+	Quantity *uint64 `json:"quantity" validate:"omitempty,min=1"`
+}
+
+// Validate check the request is valid
+func (p RefundPurchaseRequest) Validate() error {
+	if err := validator.New().Struct(p); err != nil {
+		return payload.Error{
+			Code:    payload.ErrCodeInvalidRefundQuantity,
+			Message: "'quantity' should be greater than 0",
+			Param:   *p.Quantity,
+			Type:    payload.ErrorTypeInvalidArgument,
+		}
+	}
+
+	return nil
+}
+
+// PurchaseFilterRequest the date range of bought time in unix seconds, zero value is ignored
+type PurchaseFilterRequest struct {
+	From int64
+	To   int64
+}
+
+func (p *PurchaseFilterRequest) Parse(qs url.Values) error {
+	if fromStr := qs.Get("from"); fromStr != "" {
+		from, err := strconv.ParseInt(fromStr, 10, 64)
+		if err != nil {
+			log.Printf("failed to parse from query to int64:%s\n", fromStr)
+			return payload.Error{
+				Code:    payload.ErrCodeInvalidDateRange,
+				Message: "'from' should be an unix timestamp",
+				Param:   fromStr,
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+		}
+		p.From = from
+	}
+
+	if toStr := qs.Get("to"); toStr != "" {
+		to, err := strconv.ParseInt(toStr, 10, 64)
+		if err != nil {
+			log.Printf("failed to parse to query to int64:%s\n", toStr)
+			return payload.Error{
+				Code:    payload.ErrCodeInvalidDateRange,
+				Message: "'to' should be an unix timestamp",
+				Param:   toStr,
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+		}
+		p.To = to
+	}
+
+	return nil
+}
+
+// Validate check the request is valid
+func (p PurchaseFilterRequest) Validate() error {
+	if p.From < 0 || p.To < 0 || (p.From > 0 && p.To > 0 && p.From > p.To) {
+		return payload.Error{
+			Code:    payload.ErrCodeInvalidDateRange,
+			Message: "'from' and 'to' should be positive and 'from' should not be after 'to'",
+			Param:   p,
+			Type:    payload.ErrorTypeInvalidArgument,
+		}
+	}
+
+	return nil
 }
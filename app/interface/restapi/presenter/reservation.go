@@ -0,0 +1,63 @@
+package presenter
+
+import (
+	"github.com/go-playground/validator/v10"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+const (
+	// DefaultReservationTTLSeconds the stock is held for 15 minutes when ttl_seconds is omitted
+	DefaultReservationTTLSeconds uint64 = 900
+	MaxReservationTTLSeconds     uint64 = 86400
+)
+
+// ReserveItemRequest the presenter for holding the stock of an item
+type ReserveItemRequest struct {
+	Quantity   uint64  `json:"quantity" validate:"min=1"`
+	TTLSeconds *uint64 `json:"ttl_seconds" validate:"omitempty,min=1,max=86400"`
+}
+
+// Validate check the request is valid
+func (p ReserveItemRequest) Validate() error {
+	if err := validator.New().Struct(p); err != nil {
+		switch e := err.(type) {
+		case validator.ValidationErrors:
+			errs := make(payload.Errors, 0, len(e))
+			for _, ee := range e {
+				switch f := ee.Field(); {
+				case f == "Quantity":
+					errs = append(errs, payload.Error{
+						Code:    payload.ErrCodeInvalidBuyQuantity,
+						Message: "'quantity' should be greater than 0",
+						Param:   p.Quantity,
+						Type:    payload.ErrorTypeInvalidArgument,
+					})
+				case f == "TTLSeconds":
+					errs = append(errs, payload.Error{
+						Code:    payload.ErrCodeInvalidReservationTTL,
+						Message: "'ttl_seconds' should be between 1 and 86400",
+						Param:   *p.TTLSeconds,
+						Type:    payload.ErrorTypeInvalidArgument,
+					})
+				}
+			}
+			return errs
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+type Reservation struct {
+	ID         valueobject.ReservationID     `json:"id"`
+	ItemID     valueobject.ItemID            `json:"item_id"`
+	Quantity   uint64                        `json:"quantity"`
+	Status     valueobject.ReservationStatus `json:"status"`
+	ReservedAt int64                         `json:"reserved_at"`
+	ExpiresAt  int64                         `json:"expires_at"`
+	PurchaseID *valueobject.PurchaseID       `json:"purchase_id"`
+}
@@ -0,0 +1,67 @@
+package presenter
+
+import (
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// TransferItemRequest the presenter for moving the stock of an item between locations
+type TransferItemRequest struct {
+	FromLocationID valueobject.LocationID `json:"from_location_id"`
+	ToLocationID   valueobject.LocationID `json:"to_location_id"`
+	Quantity       uint64                 `json:"quantity"`
+}
+
+// Validate check the request is valid
+func (p TransferItemRequest) Validate() error {
+	errs := payload.Errors{}
+	if p.FromLocationID == 0 {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidLocationID,
+			Message: "'from_location_id' should be greater than 0",
+			Param:   p.FromLocationID,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if p.ToLocationID == 0 {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidLocationID,
+			Message: "'to_location_id' should be greater than 0",
+			Param:   p.ToLocationID,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if p.FromLocationID != 0 && p.FromLocationID == p.ToLocationID {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeSameTransferLocation,
+			Message: "'to_location_id' should be different from 'from_location_id'",
+			Param:   p.ToLocationID,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+	if p.Quantity == 0 {
+		errs = append(errs, payload.Error{
+			Code:    payload.ErrCodeInvalidTransferQuantity,
+			Message: "'quantity' should be greater than 0",
+			Param:   p.Quantity,
+			Type:    payload.ErrorTypeInvalidArgument,
+		})
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+type Transfer struct {
+	ID             valueobject.TransferID     `json:"id"`
+	ItemID         valueobject.ItemID         `json:"item_id"`
+	FromLocationID valueobject.LocationID     `json:"from_location_id"`
+	ToLocationID   valueobject.LocationID     `json:"to_location_id"`
+	Quantity       uint64                     `json:"quantity"`
+	Status         valueobject.TransferStatus `json:"status"`
+	SentAt         int64                      `json:"sent_at"`
+	ReceivedAt     *int64                     `json:"received_at"`
+}
@@ -0,0 +1,41 @@
+package converter
+
+import (
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertCreateBundleRequestToEntity(request payload.CreateBundleRequest) entity.Bundle {
+	components := make([]entity.BundleComponent, len(request.Components))
+	for i, component := range request.Components {
+		components[i] = entity.BundleComponent{
+			ItemID:   component.ItemID,
+			Quantity: component.Quantity,
+		}
+	}
+
+	return entity.Bundle{
+		Name:       request.Name,
+		Components: components,
+	}
+}
+
+// ConvertBundleEntityToPayload the availability of bundle is derived from the component items by id
+func ConvertBundleEntityToPayload(ent entity.Bundle, items map[valueobject.ItemID]entity.Item) payload.Bundle {
+	components := make([]payload.BundleComponent, len(ent.Components))
+	for i, component := range ent.Components {
+		components[i] = payload.BundleComponent{
+			ItemID:   component.ItemID,
+			Quantity: component.Quantity,
+		}
+	}
+
+	return payload.Bundle{
+		ID:                ent.ID,
+		Name:              ent.Name,
+		Components:        components,
+		AvailableQuantity: ent.AvailableQuantity(items),
+		CreatedAt:         ent.CreatedAt,
+	}
+}
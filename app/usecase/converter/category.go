@@ -0,0 +1,31 @@
+package converter
+
+import (
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertCreateCategoryRequestToEntity(request payload.CreateCategoryRequest) entity.Category {
+	return entity.Category{
+		Name:     request.Name,
+		ParentID: request.ParentID,
+	}
+}
+
+func ConvertCategoryEntityToPayload(ent entity.Category) payload.Category {
+	return payload.Category{
+		ID:        ent.ID,
+		Name:      ent.Name,
+		ParentID:  ent.ParentID,
+		CreatedAt: ent.CreatedAt,
+	}
+}
+
+func ConvertCategoryEntitiesToPayload(ents []entity.Category) []payload.Category {
+	categories := make([]payload.Category, len(ents))
+	for i := range ents {
+		categories[i] = ConvertCategoryEntityToPayload(ents[i])
+	}
+
+	return categories
+}
@@ -0,0 +1,19 @@
+package converter
+
+import (
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertInventoryMovementEntityToPayload(ent entity.InventoryMovement) payload.InventoryMovement {
+	return payload.InventoryMovement{
+		ID:         ent.ID,
+		ItemID:     ent.ItemID,
+		Reason:     ent.Reason,
+		Delta:      ent.Delta,
+		ReasonCode: ent.ReasonCode,
+		Actor:      ent.Actor,
+		Note:       ent.Note,
+		MovedAt:    ent.CreatedAt,
+	}
+}
@@ -2,25 +2,60 @@ package converter
 
 import (
 	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
 	"github.com/tuanna7593/gosample/app/usecase/payload"
 )
 
 // ConvertCreateItemRequestToEntity convert create item request payload to item entity
 func ConvertCreateItemRequestToEntity(request payload.CreateItemRequest) entity.Item {
 	return entity.Item{
+		ProductID:         request.ProductID,
+		SKU:               request.SKU,
+		Name:              request.Name,
+		Description:       request.Description,
 		TotalStockValue:   request.TotalStockValue,
 		CurrentStockValue: request.TotalStockValue,
+		ReorderThreshold:  request.ReorderThreshold,
+		Backorderable:     request.Backorderable,
 		SellingPrice:      request.SellingPrice,
+		Version:           1,
+	}
+}
+
+// ConvertItemFilterPayloadToValueObject convert item filter payload to value object
+func ConvertItemFilterPayloadToValueObject(pl payload.ItemFilter) valueobject.ItemFilter {
+	return valueobject.ItemFilter{
+		MinSellingPrice: pl.MinSellingPrice,
+		MaxSellingPrice: pl.MaxSellingPrice,
+		InStockOnly:     pl.InStockOnly,
+		CreatedFrom:     pl.CreatedFrom,
+		CreatedTo:       pl.CreatedTo,
+		ProductID:       pl.ProductID,
+		Tags:            pl.Tags,
+		Attributes:      pl.Attributes,
+		Sorts:           pl.Sorts,
 	}
 }
 
 // ConvertItemEntityToPayload convert item entity to payload
 func ConvertItemEntityToPayload(item entity.Item) payload.Item {
 	return payload.Item{
-		ID:                item.ID,
-		PlacedAt:          item.CreatedAt,
-		TotalStockValue:   item.TotalStockValue,
-		CurrentStockValue: item.CurrentStockValue,
-		SellingPrice:      item.SellingPrice,
+		ID:                    item.ID,
+		ProductID:             item.ProductID,
+		PlacedAt:              item.CreatedAt,
+		SKU:                   item.SKU,
+		Name:                  item.Name,
+		Description:           item.Description,
+		TotalStockValue:       item.TotalStockValue,
+		CurrentStockValue:     item.CurrentStockValue,
+		ReservedStockValue:    item.ReservedStockValue,
+		LocatedStockValue:     item.LocatedStockValue,
+		UnassignedStockValue:  item.UnassignedStockValue(),
+		InTransitStockValue:   item.InTransitStockValue,
+		BackorderedStockValue: item.BackorderedStockValue,
+		Backorderable:         item.Backorderable,
+		ReorderThreshold:      item.ReorderThreshold,
+		SellingPrice:          item.SellingPrice,
+		Version:               item.Version,
 	}
 }
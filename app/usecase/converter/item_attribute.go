@@ -0,0 +1,31 @@
+package converter
+
+import (
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertItemTagEntitiesToPayload(ents []entity.ItemTag) []string {
+	tags := make([]string, len(ents))
+	for i := range ents {
+		tags[i] = ents[i].Tag
+	}
+
+	return tags
+}
+
+func ConvertItemAttributeEntityToPayload(ent entity.ItemAttribute) payload.ItemAttribute {
+	return payload.ItemAttribute{
+		Key:   ent.Key,
+		Value: ent.Value,
+	}
+}
+
+func ConvertItemAttributeEntitiesToPayload(ents []entity.ItemAttribute) []payload.ItemAttribute {
+	attributes := make([]payload.ItemAttribute, len(ents))
+	for i := range ents {
+		attributes[i] = ConvertItemAttributeEntityToPayload(ents[i])
+	}
+
+	return attributes
+}
@@ -24,6 +24,7 @@ func TestConvertCreateItemRequestToEntity(t *testing.T) {
 			TotalStockValue:   5,
 			CurrentStockValue: 5,
 			SellingPrice:      decimal.NewFromFloat(1.555),
+			Version:           1,
 		}
 
 		if diff := cmp.Diff(itemEnt, want); diff != "" {
@@ -40,16 +41,21 @@ func TestConvertItemEntityToPayload(t *testing.T) {
 			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
 			TotalStockValue:   4,
 			CurrentStockValue: 3,
+			LocatedStockValue: 1,
 			SellingPrice:      decimal.NewFromFloat(1.44),
+			Version:           2,
 		}
 
 		itemPayload := ConvertItemEntityToPayload(itemEnt)
 		want := payload.Item{
-			ID:                valueobject.ItemID(1),
-			PlacedAt:          time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
-			TotalStockValue:   4,
-			CurrentStockValue: 3,
-			SellingPrice:      decimal.NewFromFloat(1.44),
+			ID:                   valueobject.ItemID(1),
+			PlacedAt:             time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalStockValue:      4,
+			CurrentStockValue:    3,
+			LocatedStockValue:    1,
+			UnassignedStockValue: 2,
+			SellingPrice:         decimal.NewFromFloat(1.44),
+			Version:              2,
 		}
 
 		if diff := cmp.Diff(itemPayload, want); diff != "" {
@@ -0,0 +1,35 @@
+package converter
+
+import (
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertCreateLocationRequestToEntity(request payload.CreateLocationRequest) entity.Location {
+	return entity.Location{
+		Code: request.Code,
+		Name: request.Name,
+	}
+}
+
+func ConvertLocationEntityToPayload(ent entity.Location) payload.Location {
+	return payload.Location{
+		ID:        ent.ID,
+		Code:      ent.Code,
+		Name:      ent.Name,
+		CreatedAt: ent.CreatedAt,
+	}
+}
+
+// ConvertLocationStockEntitiesToPayload convert the location stocks of an item to payload
+func ConvertLocationStockEntitiesToPayload(stocks []entity.LocationStock) []payload.ItemLocationStock {
+	locations := make([]payload.ItemLocationStock, len(stocks))
+	for i := range stocks {
+		locations[i] = payload.ItemLocationStock{
+			LocationID:        stocks[i].LocationID,
+			CurrentStockValue: stocks[i].CurrentStockValue,
+		}
+	}
+
+	return locations
+}
@@ -0,0 +1,26 @@
+package converter
+
+import (
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertOrderEntityToPayload(ent entity.Order) payload.Order {
+	lines := make([]payload.OrderLine, len(ent.Lines))
+	for i, line := range ent.Lines {
+		lines[i] = payload.OrderLine{
+			ItemID:     line.ItemID,
+			PurchaseID: line.PurchaseID,
+			Quantity:   line.Quantity,
+			UnitPrice:  line.UnitPrice,
+			LineTotal:  line.LineTotal(),
+		}
+	}
+
+	return payload.Order{
+		ID:         ent.ID,
+		PlacedAt:   ent.CreatedAt,
+		TotalPrice: ent.TotalPrice,
+		Lines:      lines,
+	}
+}
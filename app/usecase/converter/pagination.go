@@ -7,7 +7,8 @@ import (
 
 func ConvertPaginationPayloadToValueObject(pl payload.PaginationRequest) valueobject.PaginationRequest {
 	return valueobject.PaginationRequest{
-		Page:  pl.Page,
-		Limit: pl.Limit,
+		Page:   pl.Page,
+		Limit:  pl.Limit,
+		Cursor: pl.Cursor,
 	}
 }
@@ -0,0 +1,34 @@
+package converter
+
+import (
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertCreateProductRequestToEntity(request payload.CreateProductRequest) entity.Product {
+	return entity.Product{
+		Name:        request.Name,
+		Description: request.Description,
+	}
+}
+
+// ConvertProductEntityToPayload the stock of product is aggregated from its variants
+func ConvertProductEntityToPayload(ent entity.Product, variants []entity.Item) payload.Product {
+	stock := entity.NewProductStock(variants)
+	variantPayloads := make([]payload.Item, len(variants))
+	for i := range variants {
+		variantPayloads[i] = ConvertItemEntityToPayload(variants[i])
+	}
+
+	return payload.Product{
+		ID:                    ent.ID,
+		Name:                  ent.Name,
+		Description:           ent.Description,
+		TotalStockValue:       stock.TotalStockValue,
+		CurrentStockValue:     stock.CurrentStockValue,
+		ReservedStockValue:    stock.ReservedStockValue,
+		BackorderedStockValue: stock.BackorderedStockValue,
+		Variants:              variantPayloads,
+		CreatedAt:             ent.CreatedAt,
+	}
+}
@@ -2,14 +2,27 @@ package converter
 
 import (
 	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
 	"github.com/tuanna7593/gosample/app/usecase/payload"
 )
 
+func ConvertPurchaseFilterPayloadToValueObject(pl payload.PurchaseFilter) valueobject.PurchaseFilter {
+	return valueobject.PurchaseFilter{
+		ItemID:      pl.ItemID,
+		CreatedFrom: pl.CreatedFrom,
+		CreatedTo:   pl.CreatedTo,
+	}
+}
+
 func ConvertPurchaseEntityToPayload(ent entity.Purchase) payload.Purchase {
 	return payload.Purchase{
-		ID:       ent.ID,
-		ItemID:   ent.ItemID,
-		Quantity: ent.Quantity,
-		BoughtAt: ent.CreatedAt,
+		ID:               ent.ID,
+		ItemID:           ent.ItemID,
+		Quantity:         ent.Quantity,
+		BoughtAt:         ent.CreatedAt,
+		RefundedQuantity: ent.RefundedQuantity,
+		RefundedAt:       ent.RefundedAt,
+		Status:           ent.Status,
+		FulfilledAt:      ent.FulfilledAt,
 	}
 }
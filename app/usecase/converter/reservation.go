@@ -0,0 +1,18 @@
+package converter
+
+import (
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertReservationEntityToPayload(ent entity.Reservation) payload.Reservation {
+	return payload.Reservation{
+		ID:         ent.ID,
+		ItemID:     ent.ItemID,
+		Quantity:   ent.Quantity,
+		Status:     ent.Status,
+		ReservedAt: ent.CreatedAt,
+		ExpiresAt:  ent.ExpiresAt,
+		PurchaseID: ent.PurchaseID,
+	}
+}
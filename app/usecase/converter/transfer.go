@@ -0,0 +1,30 @@
+package converter
+
+import (
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func ConvertTransferRequestToEntity(request payload.TransferRequest) entity.Transfer {
+	return entity.Transfer{
+		ItemID:         request.ItemID,
+		FromLocationID: request.FromLocationID,
+		ToLocationID:   request.ToLocationID,
+		Quantity:       request.Quantity,
+		Status:         valueobject.TransferStatusInTransit,
+	}
+}
+
+func ConvertTransferEntityToPayload(ent entity.Transfer) payload.Transfer {
+	return payload.Transfer{
+		ID:             ent.ID,
+		ItemID:         ent.ItemID,
+		FromLocationID: ent.FromLocationID,
+		ToLocationID:   ent.ToLocationID,
+		Quantity:       ent.Quantity,
+		Status:         ent.Status,
+		SentAt:         ent.CreatedAt,
+		ReceivedAt:     ent.ReceivedAt,
+	}
+}
@@ -0,0 +1,95 @@
+package interactor
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// fulfillQueuedBackorders fulfill the queued backorders of item after its current stock is raised,
+// raised holds the stock values of item after the raise. The fulfilled quantity is taken from the stock of item
+// by the allocation strategy, nothing is changed when the first backorder in the queue cannot be fulfilled
+func fulfillQueuedBackorders(
+	ctx context.Context,
+	itemRepo repository.ItemRepository,
+	purchaseRepo repository.PurchaseRepository,
+	movementRepo repository.InventoryMovementRepository,
+	stockRepo repository.LocationStockRepository,
+	strategy valueobject.AllocationStrategy,
+	item *entity.Item,
+	raised entity.Item,
+) error {
+	if raised.BackorderedStockValue == 0 {
+		return nil
+	}
+
+	fulfilled, err := fulfillBackorders(ctx, purchaseRepo, movementRepo, raised)
+	if err != nil || fulfilled == 0 {
+		return err
+	}
+
+	located, err := takeStock(ctx, stockRepo, strategy, raised, fulfilled, nil)
+	if err != nil {
+		return err
+	}
+
+	err = itemRepo.Updates(ctx, item, map[string]interface{}{
+		"current_stock_value":     raised.CurrentStockValue - fulfilled,
+		"located_stock_value":     located,
+		"backordered_stock_value": raised.BackorderedStockValue - fulfilled,
+	})
+	if err != nil {
+		log.Printf("failed to fulfill backorders of item:%d\n", item.ID)
+		return err
+	}
+
+	return nil
+}
+
+// fulfillBackorders fulfill the backordered purchases of item in FIFO order with its current stock,
+// it stops at the first purchase which cannot be fulfilled so a later purchase never jumps the queue.
+// The fulfilled quantity is returned, the caller takes it from the stock of item.
+func fulfillBackorders(
+	ctx context.Context,
+	purchaseRepo repository.PurchaseRepository,
+	movementRepo repository.InventoryMovementRepository,
+	item entity.Item,
+) (uint64, error) {
+	purchases, err := purchaseRepo.ListBackorderedByItemForUpdate(ctx, item.ID)
+	if err != nil {
+		log.Printf("failed to get backordered purchases of item:%d\n", item.ID)
+		return 0, err
+	}
+
+	var fulfilled uint64
+	for _, purchase := range purchases {
+		if fulfilled+purchase.Quantity > item.CurrentStockValue {
+			break
+		}
+
+		err = purchaseRepo.Updates(ctx, &purchase, map[string]interface{}{
+			"status":       valueobject.PurchaseStatusFulfilled,
+			"fulfilled_at": time.Now(),
+		})
+		if err != nil {
+			log.Printf("failed to fulfill purchase:%d\n", purchase.ID)
+			return 0, err
+		}
+
+		err = createInventoryMovement(
+			ctx, movementRepo,
+			item.ID, valueobject.MovementReasonPurchase, -int64(purchase.Quantity),
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		fulfilled += purchase.Quantity
+	}
+
+	return fulfilled, nil
+}
@@ -0,0 +1,283 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase"
+	"github.com/tuanna7593/gosample/app/usecase/converter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// BundleUseCaseImpl implementation of Bundle usecase
+type BundleUseCaseImpl struct {
+	bundleRepository repository.BundleRepository
+	itemRepository   repository.ItemRepository
+	// orderUseCase a bundle is bought as an order of its components
+	orderUseCase OrderUseCaseImpl
+}
+
+// NewBundleUseCaseInteractor create new instance of Bundle interactor
+func NewBundleUseCaseInteractor(
+	bundleRepo repository.BundleRepository,
+	itemRepo repository.ItemRepository,
+	purchaseRepo repository.PurchaseRepository,
+	orderRepo repository.OrderRepository,
+	movementRepo repository.InventoryMovementRepository,
+	locationStockRepo repository.LocationStockRepository,
+	txManager repository.TransactionManager,
+	allocationStrategy valueobject.AllocationStrategy,
+	stockAlertNotifier repository.StockAlertNotifier,
+) usecase.BundleUseCase {
+	return &BundleUseCaseImpl{
+		bundleRepository: bundleRepo,
+		itemRepository:   itemRepo,
+		orderUseCase: OrderUseCaseImpl{
+			itemRepository:              itemRepo,
+			purchaseRepository:          purchaseRepo,
+			orderRepository:             orderRepo,
+			inventoryMovementRepository: movementRepo,
+			locationStockRepository:     locationStockRepo,
+			txManager:                   txManager,
+			allocationStrategy:          allocationStrategy,
+			stockAlertNotifier:          stockAlertNotifier,
+		},
+	}
+}
+
+// Create create a new bundle, every component must be an item which is not archived
+func (uc BundleUseCaseImpl) Create(ctx context.Context, req payload.CreateBundleRequest) (payload.Bundle, error) {
+	itemIDs := make([]valueobject.ItemID, len(req.Components))
+	for i, component := range req.Components {
+		itemIDs[i] = component.ItemID
+	}
+	items, err := uc.getItems(ctx, itemIDs)
+	if err != nil {
+		return payload.Bundle{}, err
+	}
+
+	// check every component so the client gets all the invalid components at once
+	errs := payload.Errors{}
+	for _, component := range req.Components {
+		item, ok := items[component.ItemID]
+		switch {
+		case !ok:
+			errs = append(errs, newBundleComponentError(
+				payload.ErrCodeNotFoundItem,
+				fmt.Sprintf("not found component item:%d", component.ItemID),
+				component.ItemID,
+			))
+		case item.IsArchived():
+			errs = append(errs, newBundleComponentError(
+				payload.ErrCodeArchivedItem,
+				fmt.Sprintf("the component item has been archived:%d", component.ItemID),
+				component.ItemID,
+			))
+		}
+	}
+	if len(errs) > 0 {
+		return payload.Bundle{}, errs
+	}
+
+	bundle := converter.ConvertCreateBundleRequestToEntity(req)
+	err = uc.bundleRepository.Create(ctx, &bundle)
+	if err != nil {
+		log.Printf("failed to create bundle:%+v\n", bundle)
+		return payload.Bundle{}, err
+	}
+
+	return converter.ConvertBundleEntityToPayload(bundle, items), nil
+}
+
+// GetBundle get a bundle by id, its available quantity is derived from the current stock of its components
+func (uc BundleUseCaseImpl) GetBundle(ctx context.Context, bundleID valueobject.BundleID) (payload.Bundle, error) {
+	bundle, err := uc.getBundle(ctx, bundleID)
+	if err != nil {
+		return payload.Bundle{}, err
+	}
+
+	itemIDs := make([]valueobject.ItemID, len(bundle.Components))
+	for i, component := range bundle.Components {
+		itemIDs[i] = component.ItemID
+	}
+	items, err := uc.getItems(ctx, itemIDs)
+	if err != nil {
+		return payload.Bundle{}, err
+	}
+
+	return converter.ConvertBundleEntityToPayload(bundle, items), nil
+}
+
+// BuyBundle buy the components of bundle in one transaction,
+// the stock is not changed when any component cannot be bought
+func (uc BundleUseCaseImpl) BuyBundle(ctx context.Context, req payload.BuyBundleRequest) (payload.Order, error) {
+	bundle, err := uc.getBundle(ctx, req.BundleID)
+	if err != nil {
+		return payload.Order{}, err
+	}
+
+	// the components of a bundle are different items so each of them is a line of the order
+	lines := make([]payload.OrderLineRequest, len(bundle.Components))
+	requestQuantities := make(map[valueobject.ItemID]uint64, len(bundle.Components))
+	itemIDs := make([]valueobject.ItemID, len(bundle.Components))
+	for i, component := range bundle.Components {
+		// the wrapped quantity would pass the stock check without taking the stock
+		if req.Quantity > math.MaxUint64/component.Quantity {
+			err = newBundleComponentError(
+				payload.ErrCodeInvalidBuyQuantity,
+				fmt.Sprintf(
+					"the quantity of component overflows - bundle:%d - item:%d - request quantity:%d",
+					bundle.ID, component.ItemID, req.Quantity,
+				),
+				component.ItemID,
+			)
+			return payload.Order{}, err
+		}
+
+		lines[i] = payload.OrderLineRequest{
+			ItemID:   component.ItemID,
+			Quantity: component.Quantity * req.Quantity,
+		}
+		requestQuantities[component.ItemID] = lines[i].Quantity
+		itemIDs[i] = component.ItemID
+	}
+
+	// always lock the items in the same order to avoid deadlock with orders
+	sort.Slice(itemIDs, func(i, j int) bool {
+		return itemIDs[i] < itemIDs[j]
+	})
+
+	// start transaction and assign tx to repositories
+	uc.orderUseCase.begin()
+
+	defer func() {
+		if err != nil {
+			log.Printf("found error - rollback transaction:%v\n", err)
+			uc.orderUseCase.txManager.Rollback()
+		}
+	}()
+
+	// find and lock the component items until the transaction ends
+	items, err := uc.orderUseCase.lockItems(ctx, itemIDs)
+	if err != nil {
+		return payload.Order{}, err
+	}
+
+	// check every component so the client gets all the components which cannot be bought at once
+	errs := payload.Errors{}
+	for _, line := range lines {
+		item := items[line.ItemID]
+		switch {
+		case reflect.DeepEqual(item, entity.Item{}):
+			errs = append(errs, newBundleComponentError(
+				payload.ErrCodeNotFoundItem,
+				fmt.Sprintf("not found component - bundle:%d - item:%d", bundle.ID, line.ItemID),
+				line.ItemID,
+			))
+		case item.IsArchived():
+			errs = append(errs, newBundleComponentError(
+				payload.ErrCodeArchivedItem,
+				fmt.Sprintf("the component has been archived - bundle:%d - item:%d", bundle.ID, line.ItemID),
+				line.ItemID,
+			))
+		case item.BackordersQueued():
+			errs = append(errs, newBundleComponentError(
+				payload.ErrCodeBackordersQueued,
+				fmt.Sprintf(
+					"the stock of component is held for backorders - bundle:%d - item:%d - backordered quantity:%d",
+					bundle.ID, line.ItemID, item.BackorderedStockValue,
+				),
+				line.ItemID,
+			))
+		case item.CurrentStockValue < line.Quantity:
+			errs = append(errs, newBundleComponentError(
+				payload.ErrCodeOutOfStock,
+				fmt.Sprintf(
+					"the component out of stock - bundle:%d - item:%d - current quantity:%d - request quantity:%d",
+					bundle.ID, line.ItemID, item.CurrentStockValue, line.Quantity,
+				),
+				line.ItemID,
+			))
+		}
+	}
+	if len(errs) > 0 {
+		err = errs
+		return payload.Order{}, err
+	}
+
+	order, alerts, err := uc.orderUseCase.fulfil(ctx, items, itemIDs, requestQuantities, lines)
+	if err != nil {
+		return payload.Order{}, err
+	}
+
+	// commit transaction
+	errCommit := uc.orderUseCase.txManager.Commit()
+	if errCommit != nil {
+		log.Printf("failed to commit transaction:%+v\n", errCommit)
+		return payload.Order{}, errCommit
+	}
+
+	notifyLowStock(ctx, uc.orderUseCase.stockAlertNotifier, alerts)
+
+	return converter.ConvertOrderEntityToPayload(order), nil
+}
+
+func (uc BundleUseCaseImpl) getBundle(ctx context.Context, bundleID valueobject.BundleID) (entity.Bundle, error) {
+	bundle, err := uc.bundleRepository.GetByID(ctx, bundleID)
+	if err != nil {
+		log.Printf("failed to get bundle:%d\n", bundleID)
+		return entity.Bundle{}, err
+	}
+
+	if reflect.DeepEqual(bundle, entity.Bundle{}) {
+		msg := fmt.Sprintf("not found bundle:%d", bundleID)
+		log.Println(msg)
+		return entity.Bundle{}, payload.Error{
+			Code:    payload.ErrCodeNotFoundBundle,
+			Message: msg,
+			Param:   bundleID,
+			Type:    payload.ErrorTypeNotFound,
+		}
+	}
+
+	return bundle, nil
+}
+
+// getItems get the component items by id, the items which are not found are left out
+func (uc BundleUseCaseImpl) getItems(
+	ctx context.Context,
+	itemIDs []valueobject.ItemID,
+) (map[valueobject.ItemID]entity.Item, error) {
+	items := make(map[valueobject.ItemID]entity.Item, len(itemIDs))
+	for _, itemID := range itemIDs {
+		item, err := uc.itemRepository.GetByID(ctx, itemID)
+		if err != nil {
+			log.Printf("failed to get item:%d\n", itemID)
+			return nil, err
+		}
+
+		if !reflect.DeepEqual(item, entity.Item{}) {
+			items[itemID] = item
+		}
+	}
+
+	return items, nil
+}
+
+// newBundleComponentError create the error of a component which cannot be put in or bought with a bundle
+func newBundleComponentError(code payload.ErrorCode, msg string, itemID valueobject.ItemID) payload.Error {
+	log.Println(msg)
+	return payload.Error{
+		Code:    code,
+		Message: msg,
+		Param:   itemID,
+		Type:    payload.ErrorTypeBadRequest,
+	}
+}
@@ -0,0 +1,553 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/shopspring/decimal"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository/mock"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestBundleUseCaseImpl_Create(t *testing.T) {
+	t.Run("#1: Some components are not found or archived", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+
+		uc := BundleUseCaseImpl{
+			itemRepository: mItemRepo,
+		}
+		ctx := context.Background()
+		deletedAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{ID: valueobject.ItemID(1)}, nil)
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(2)).Return(entity.Item{}, nil)
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(3)).Return(entity.Item{
+			ID:        valueobject.ItemID(3),
+			DeletedAt: &deletedAt,
+		}, nil)
+
+		_, err := uc.Create(ctx, payload.CreateBundleRequest{
+			Name: "Starter kit",
+			Components: []payload.BundleComponent{
+				{ItemID: valueobject.ItemID(1), Quantity: 1},
+				{ItemID: valueobject.ItemID(2), Quantity: 1},
+				{ItemID: valueobject.ItemID(3), Quantity: 2},
+			},
+		})
+		wannaErr := payload.Errors{
+			{
+				Code:    payload.ErrCodeNotFoundItem,
+				Message: "not found component item:2",
+				Param:   valueobject.ItemID(2),
+				Type:    payload.ErrorTypeBadRequest,
+			},
+			{
+				Code:    payload.ErrCodeArchivedItem,
+				Message: "the component item has been archived:3",
+				Param:   valueobject.ItemID(3),
+				Type:    payload.ErrorTypeBadRequest,
+			},
+		}
+		if diff := cmp.Diff(err, error(wannaErr)); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mBundleRepo := mock.NewMockBundleRepository(mockCtrl)
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+
+		uc := BundleUseCaseImpl{
+			bundleRepository: mBundleRepo,
+			itemRepository:   mItemRepo,
+		}
+		ctx := context.Background()
+		createdAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{
+			ID:                valueobject.ItemID(1),
+			CurrentStockValue: 5,
+		}, nil)
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(2)).Return(entity.Item{
+			ID:                valueobject.ItemID(2),
+			CurrentStockValue: 7,
+		}, nil)
+		mBundleRepo.EXPECT().Create(ctx, &entity.Bundle{
+			Name: "Starter kit",
+			Components: []entity.BundleComponent{
+				{ItemID: valueobject.ItemID(1), Quantity: 1},
+				{ItemID: valueobject.ItemID(2), Quantity: 3},
+			},
+		}).DoAndReturn(func(_ context.Context, b *entity.Bundle) error {
+			b.ID = valueobject.BundleID(1)
+			b.CreatedAt = createdAt
+			return nil
+		})
+
+		got, err := uc.Create(ctx, payload.CreateBundleRequest{
+			Name: "Starter kit",
+			Components: []payload.BundleComponent{
+				{ItemID: valueobject.ItemID(1), Quantity: 1},
+				{ItemID: valueobject.ItemID(2), Quantity: 3},
+			},
+		})
+		if err != nil {
+			t.Errorf("uc.Create() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Bundle{
+			ID:   valueobject.BundleID(1),
+			Name: "Starter kit",
+			Components: []payload.BundleComponent{
+				{ItemID: valueobject.ItemID(1), Quantity: 1},
+				{ItemID: valueobject.ItemID(2), Quantity: 3},
+			},
+			AvailableQuantity: 2,
+			CreatedAt:         createdAt,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestBundleUseCaseImpl_GetBundle(t *testing.T) {
+	t.Run("#1: Not found bundle", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mBundleRepo := mock.NewMockBundleRepository(mockCtrl)
+
+		uc := BundleUseCaseImpl{
+			bundleRepository: mBundleRepo,
+		}
+		ctx := context.Background()
+		mBundleRepo.EXPECT().GetByID(ctx, valueobject.BundleID(1)).Return(entity.Bundle{}, nil)
+
+		_, err := uc.GetBundle(ctx, valueobject.BundleID(1))
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundBundle,
+			Message: "not found bundle:1",
+			Param:   valueobject.BundleID(1),
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.GetBundle() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Unavailable when a component is archived", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mBundleRepo := mock.NewMockBundleRepository(mockCtrl)
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+
+		uc := BundleUseCaseImpl{
+			bundleRepository: mBundleRepo,
+			itemRepository:   mItemRepo,
+		}
+		ctx := context.Background()
+		deletedAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		mBundleRepo.EXPECT().GetByID(ctx, valueobject.BundleID(1)).Return(entity.Bundle{
+			ID:   valueobject.BundleID(1),
+			Name: "Starter kit",
+			Components: []entity.BundleComponent{
+				{BundleID: valueobject.BundleID(1), ItemID: valueobject.ItemID(1), Quantity: 1},
+				{BundleID: valueobject.BundleID(1), ItemID: valueobject.ItemID(2), Quantity: 2},
+			},
+		}, nil)
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{
+			ID:                valueobject.ItemID(1),
+			CurrentStockValue: 5,
+		}, nil)
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(2)).Return(entity.Item{
+			ID:                valueobject.ItemID(2),
+			CurrentStockValue: 8,
+			DeletedAt:         &deletedAt,
+		}, nil)
+
+		got, err := uc.GetBundle(ctx, valueobject.BundleID(1))
+		if err != nil {
+			t.Errorf("uc.GetBundle() return an error:%v - want:nil", err)
+			return
+		}
+
+		if got.AvailableQuantity != 0 {
+			t.Errorf("AvailableQuantity of bundle:%d - want:0", got.AvailableQuantity)
+		}
+	})
+
+	t.Run("#3: Unavailable when the backorders of a component are queued", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mBundleRepo := mock.NewMockBundleRepository(mockCtrl)
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+
+		uc := BundleUseCaseImpl{
+			bundleRepository: mBundleRepo,
+			itemRepository:   mItemRepo,
+		}
+		ctx := context.Background()
+		mBundleRepo.EXPECT().GetByID(ctx, valueobject.BundleID(1)).Return(entity.Bundle{
+			ID:   valueobject.BundleID(1),
+			Name: "Starter kit",
+			Components: []entity.BundleComponent{
+				{BundleID: valueobject.BundleID(1), ItemID: valueobject.ItemID(1), Quantity: 1},
+				{BundleID: valueobject.BundleID(1), ItemID: valueobject.ItemID(2), Quantity: 2},
+			},
+		}, nil)
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{
+			ID:                valueobject.ItemID(1),
+			CurrentStockValue: 5,
+		}, nil)
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(2)).Return(entity.Item{
+			ID:                    valueobject.ItemID(2),
+			CurrentStockValue:     8,
+			BackorderedStockValue: 3,
+			Backorderable:         true,
+		}, nil)
+
+		got, err := uc.GetBundle(ctx, valueobject.BundleID(1))
+		if err != nil {
+			t.Errorf("uc.GetBundle() return an error:%v - want:nil", err)
+			return
+		}
+
+		if got.AvailableQuantity != 0 {
+			t.Errorf("AvailableQuantity of bundle:%d - want:0", got.AvailableQuantity)
+		}
+	})
+}
+
+func TestBundleUseCaseImpl_BuyBundle(t *testing.T) {
+	t.Run("#1: Not found bundle", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mBundleRepo := mock.NewMockBundleRepository(mockCtrl)
+
+		uc := BundleUseCaseImpl{
+			bundleRepository: mBundleRepo,
+		}
+		ctx := context.Background()
+		mBundleRepo.EXPECT().GetByID(ctx, valueobject.BundleID(1)).Return(entity.Bundle{}, nil)
+
+		_, err := uc.BuyBundle(ctx, payload.BuyBundleRequest{BundleID: valueobject.BundleID(1), Quantity: 1})
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundBundle,
+			Message: "not found bundle:1",
+			Param:   valueobject.BundleID(1),
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.BuyBundle() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Some components out of stock", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mBundleRepo := mock.NewMockBundleRepository(mockCtrl)
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mOrderRepo := mock.NewMockOrderRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := BundleUseCaseImpl{
+			bundleRepository: mBundleRepo,
+			itemRepository:   mItemRepo,
+			orderUseCase: OrderUseCaseImpl{
+				itemRepository:              mItemRepo,
+				purchaseRepository:          mPurchaseRepo,
+				orderRepository:             mOrderRepo,
+				inventoryMovementRepository: mMovementRepo,
+				locationStockRepository:     mLocationStockRepo,
+				txManager:                   mTxManager,
+			},
+		}
+		ctx := context.Background()
+		mBundleRepo.EXPECT().GetByID(ctx, valueobject.BundleID(1)).Return(entity.Bundle{
+			ID: valueobject.BundleID(1),
+			Components: []entity.BundleComponent{
+				{BundleID: valueobject.BundleID(1), ItemID: valueobject.ItemID(3), Quantity: 2},
+				{BundleID: valueobject.BundleID(1), ItemID: valueobject.ItemID(1), Quantity: 1},
+				{BundleID: valueobject.BundleID(1), ItemID: valueobject.ItemID(2), Quantity: 3},
+			},
+		}, nil)
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mOrderRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		gomock.InOrder(
+			mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(1)).Return(entity.Item{
+				ID:                valueobject.ItemID(1),
+				CurrentStockValue: 5,
+			}, nil),
+			mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(2)).Return(entity.Item{
+				ID:                valueobject.ItemID(2),
+				CurrentStockValue: 5,
+			}, nil),
+			mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(3)).Return(entity.Item{
+				ID:                valueobject.ItemID(3),
+				CurrentStockValue: 3,
+			}, nil),
+		)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.BuyBundle(ctx, payload.BuyBundleRequest{BundleID: valueobject.BundleID(1), Quantity: 2})
+		wannaErr := payload.Errors{
+			{
+				Code:    payload.ErrCodeOutOfStock,
+				Message: "the component out of stock - bundle:1 - item:3 - current quantity:3 - request quantity:4",
+				Param:   valueobject.ItemID(3),
+				Type:    payload.ErrorTypeBadRequest,
+			},
+			{
+				Code:    payload.ErrCodeOutOfStock,
+				Message: "the component out of stock - bundle:1 - item:2 - current quantity:5 - request quantity:6",
+				Param:   valueobject.ItemID(2),
+				Type:    payload.ErrorTypeBadRequest,
+			},
+		}
+		if diff := cmp.Diff(err, error(wannaErr)); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#3: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mBundleRepo := mock.NewMockBundleRepository(mockCtrl)
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mOrderRepo := mock.NewMockOrderRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := BundleUseCaseImpl{
+			bundleRepository: mBundleRepo,
+			itemRepository:   mItemRepo,
+			orderUseCase: OrderUseCaseImpl{
+				itemRepository:              mItemRepo,
+				purchaseRepository:          mPurchaseRepo,
+				orderRepository:             mOrderRepo,
+				inventoryMovementRepository: mMovementRepo,
+				locationStockRepository:     mLocationStockRepo,
+				txManager:                   mTxManager,
+			},
+		}
+		ctx := context.Background()
+		item1 := entity.Item{
+			ID:                valueobject.ItemID(1),
+			CurrentStockValue: 5,
+			SellingPrice:      decimal.NewFromFloat(10.25),
+		}
+		item2 := entity.Item{
+			ID:                valueobject.ItemID(2),
+			CurrentStockValue: 6,
+			SellingPrice:      decimal.NewFromFloat(1.5),
+		}
+		createdAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+
+		mBundleRepo.EXPECT().GetByID(ctx, valueobject.BundleID(1)).Return(entity.Bundle{
+			ID: valueobject.BundleID(1),
+			Components: []entity.BundleComponent{
+				{BundleID: valueobject.BundleID(1), ItemID: valueobject.ItemID(2), Quantity: 3},
+				{BundleID: valueobject.BundleID(1), ItemID: valueobject.ItemID(1), Quantity: 1},
+			},
+		}, nil)
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mOrderRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		gomock.InOrder(
+			mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(1)).Return(item1, nil),
+			mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(2)).Return(item2, nil),
+		)
+		mItemRepo.EXPECT().Updates(ctx, &item1, map[string]interface{}{
+			"current_stock_value": uint64(3),
+		}).Return(nil)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonPurchase,
+			Delta:  -2,
+		}).Return(nil)
+		mItemRepo.EXPECT().Updates(ctx, &item2, map[string]interface{}{
+			"current_stock_value": uint64(0),
+		}).Return(nil)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(2),
+			Reason: valueobject.MovementReasonPurchase,
+			Delta:  -6,
+		}).Return(nil)
+		gomock.InOrder(
+			mPurchaseRepo.EXPECT().Create(ctx, &entity.Purchase{ItemID: valueobject.ItemID(2), Quantity: 6, Status: valueobject.PurchaseStatusFulfilled}).
+				DoAndReturn(func(_ context.Context, p *entity.Purchase) error {
+					p.ID = valueobject.PurchaseID(11)
+					return nil
+				}),
+			mPurchaseRepo.EXPECT().Create(ctx, &entity.Purchase{ItemID: valueobject.ItemID(1), Quantity: 2, Status: valueobject.PurchaseStatusFulfilled}).
+				DoAndReturn(func(_ context.Context, p *entity.Purchase) error {
+					p.ID = valueobject.PurchaseID(12)
+					return nil
+				}),
+		)
+		mOrderRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, o *entity.Order) error {
+			o.ID = valueobject.OrderID(1)
+			o.CreatedAt = createdAt
+			return nil
+		})
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.BuyBundle(ctx, payload.BuyBundleRequest{BundleID: valueobject.BundleID(1), Quantity: 2})
+		if err != nil {
+			t.Errorf("uc.BuyBundle() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Order{
+			ID:         valueobject.OrderID(1),
+			PlacedAt:   createdAt,
+			TotalPrice: decimal.NewFromFloat(29.5),
+			Lines: []payload.OrderLine{
+				{
+					ItemID:     valueobject.ItemID(2),
+					PurchaseID: valueobject.PurchaseID(11),
+					Quantity:   6,
+					UnitPrice:  decimal.NewFromFloat(1.5),
+					LineTotal:  decimal.NewFromFloat(9),
+				},
+				{
+					ItemID:     valueobject.ItemID(1),
+					PurchaseID: valueobject.PurchaseID(12),
+					Quantity:   2,
+					UnitPrice:  decimal.NewFromFloat(10.25),
+					LineTotal:  decimal.NewFromFloat(20.5),
+				},
+			},
+		}
+		if diff := cmp.Diff(got, want, cmp.Comparer(func(x, y decimal.Decimal) bool {
+			return x.Equal(y)
+		})); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#4: Backorders of a component are queued", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mBundleRepo := mock.NewMockBundleRepository(mockCtrl)
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mOrderRepo := mock.NewMockOrderRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := BundleUseCaseImpl{
+			bundleRepository: mBundleRepo,
+			itemRepository:   mItemRepo,
+			orderUseCase: OrderUseCaseImpl{
+				itemRepository:              mItemRepo,
+				purchaseRepository:          mPurchaseRepo,
+				orderRepository:             mOrderRepo,
+				inventoryMovementRepository: mMovementRepo,
+				locationStockRepository:     mLocationStockRepo,
+				txManager:                   mTxManager,
+			},
+		}
+		ctx := context.Background()
+		mBundleRepo.EXPECT().GetByID(ctx, valueobject.BundleID(1)).Return(entity.Bundle{
+			ID: valueobject.BundleID(1),
+			Components: []entity.BundleComponent{
+				{BundleID: valueobject.BundleID(1), ItemID: valueobject.ItemID(1), Quantity: 1},
+				{BundleID: valueobject.BundleID(1), ItemID: valueobject.ItemID(2), Quantity: 2},
+			},
+		}, nil)
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mOrderRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		gomock.InOrder(
+			mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(1)).Return(entity.Item{
+				ID:                    valueobject.ItemID(1),
+				CurrentStockValue:     5,
+				BackorderedStockValue: 6,
+				Backorderable:         true,
+			}, nil),
+			mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(2)).Return(entity.Item{
+				ID:                valueobject.ItemID(2),
+				CurrentStockValue: 5,
+			}, nil),
+		)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.BuyBundle(ctx, payload.BuyBundleRequest{BundleID: valueobject.BundleID(1), Quantity: 1})
+		wannaErr := payload.Errors{
+			{
+				Code:    payload.ErrCodeBackordersQueued,
+				Message: "the stock of component is held for backorders - bundle:1 - item:1 - backordered quantity:6",
+				Param:   valueobject.ItemID(1),
+				Type:    payload.ErrorTypeBadRequest,
+			},
+		}
+		if diff := cmp.Diff(err, error(wannaErr)); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#5: Quantity of component overflows", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mBundleRepo := mock.NewMockBundleRepository(mockCtrl)
+
+		uc := BundleUseCaseImpl{
+			bundleRepository: mBundleRepo,
+		}
+		ctx := context.Background()
+		mBundleRepo.EXPECT().GetByID(ctx, valueobject.BundleID(1)).Return(entity.Bundle{
+			ID: valueobject.BundleID(1),
+			Components: []entity.BundleComponent{
+				{BundleID: valueobject.BundleID(1), ItemID: valueobject.ItemID(1), Quantity: 2},
+			},
+		}, nil)
+
+		_, err := uc.BuyBundle(ctx, payload.BuyBundleRequest{BundleID: valueobject.BundleID(1), Quantity: 1 << 63})
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeInvalidBuyQuantity,
+			Message: "the quantity of component overflows - bundle:1 - item:1 - request quantity:9223372036854775808",
+			Param:   valueobject.ItemID(1),
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.BuyBundle() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+}
@@ -0,0 +1,281 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase"
+	"github.com/tuanna7593/gosample/app/usecase/converter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// CategoryUseCaseImpl implementation of Category usecase
+type CategoryUseCaseImpl struct {
+	categoryRepository     repository.CategoryRepository
+	itemCategoryRepository repository.ItemCategoryRepository
+	itemRepository         repository.ItemRepository
+	txManager              repository.TransactionManager
+}
+
+// NewCategoryUseCaseInteractor create new instance of Category interactor
+func NewCategoryUseCaseInteractor(
+	categoryRepo repository.CategoryRepository,
+	itemCategoryRepo repository.ItemCategoryRepository,
+	itemRepo repository.ItemRepository,
+	txManager repository.TransactionManager,
+) usecase.CategoryUseCase {
+	return &CategoryUseCaseImpl{
+		categoryRepository:     categoryRepo,
+		itemCategoryRepository: itemCategoryRepo,
+		itemRepository:         itemRepo,
+		txManager:              txManager,
+	}
+}
+
+// Create create a new category under the parent if it is set
+func (uc CategoryUseCaseImpl) Create(ctx context.Context, req payload.CreateCategoryRequest) (payload.Category, error) {
+	if req.ParentID != nil {
+		parent, err := uc.categoryRepository.GetByID(ctx, *req.ParentID)
+		if err != nil {
+			log.Printf("failed to get category:%d\n", *req.ParentID)
+			return payload.Category{}, err
+		}
+
+		if reflect.DeepEqual(parent, entity.Category{}) {
+			return payload.Category{}, newNotFoundCategoryError(*req.ParentID)
+		}
+	}
+
+	category := converter.ConvertCreateCategoryRequestToEntity(req)
+	err := uc.categoryRepository.Create(ctx, &category)
+	if err != nil {
+		log.Printf("failed to create category:%+v\n", category)
+		return payload.Category{}, err
+	}
+
+	return converter.ConvertCategoryEntityToPayload(category), nil
+}
+
+// List get all the categories
+func (uc CategoryUseCaseImpl) List(ctx context.Context) ([]payload.Category, error) {
+	categories, err := uc.categoryRepository.List(ctx)
+	if err != nil {
+		log.Println("failed to get categories")
+		return nil, err
+	}
+
+	return converter.ConvertCategoryEntitiesToPayload(categories), nil
+}
+
+// GetCategory get a category by id
+func (uc CategoryUseCaseImpl) GetCategory(ctx context.Context, categoryID valueobject.CategoryID) (payload.Category, error) {
+	category, err := uc.categoryRepository.GetByID(ctx, categoryID)
+	if err != nil {
+		log.Printf("failed to get category:%d\n", categoryID)
+		return payload.Category{}, err
+	}
+
+	if reflect.DeepEqual(category, entity.Category{}) {
+		return payload.Category{}, newNotFoundCategoryError(categoryID)
+	}
+
+	return converter.ConvertCategoryEntityToPayload(category), nil
+}
+
+// UpdateCategory the category cannot be moved under itself or its descendants so the taxonomy stays a tree,
+// the categories are locked until the update so the concurrent moves cannot make a cycle together
+func (uc CategoryUseCaseImpl) UpdateCategory(ctx context.Context, req payload.UpdateCategoryRequest) (payload.Category, error) {
+	// start transaction and assign tx to repositories
+	uc.txManager.Begin()
+	uc.categoryRepository.AssignTx(uc.txManager)
+
+	var err error
+	defer func() {
+		if err != nil {
+			log.Printf("found error - rollback transaction:%v\n", err)
+			uc.txManager.Rollback()
+		}
+	}()
+
+	categories, err := uc.categoryRepository.ListForUpdate(ctx)
+	if err != nil {
+		log.Println("failed to get categories")
+		return payload.Category{}, err
+	}
+
+	category, ok := entity.Categories(categories).Find(req.CategoryID)
+	if !ok {
+		err = newNotFoundCategoryError(req.CategoryID)
+		return payload.Category{}, err
+	}
+
+	if req.ParentID != nil {
+		if _, ok := entity.Categories(categories).Find(*req.ParentID); !ok {
+			err = newNotFoundCategoryError(*req.ParentID)
+			return payload.Category{}, err
+		}
+
+		for _, descendantID := range entity.Categories(categories).DescendantIDs(req.CategoryID) {
+			if descendantID != *req.ParentID {
+				continue
+			}
+
+			msg := fmt.Sprintf("the category cannot be moved under itself or its descendants - category:%d - parent:%d",
+				req.CategoryID, *req.ParentID)
+			log.Println(msg)
+			err = payload.Error{
+				Code:    payload.ErrCodeInvalidCategoryParent,
+				Message: msg,
+				Param:   *req.ParentID,
+				Type:    payload.ErrorTypeInvalidArgument,
+			}
+			return payload.Category{}, err
+		}
+	}
+
+	err = uc.categoryRepository.Updates(ctx, &category, map[string]interface{}{
+		"name":      req.Name,
+		"parent_id": req.ParentID,
+	})
+	if err != nil {
+		log.Printf("failed to update category:%d\n", req.CategoryID)
+		return payload.Category{}, err
+	}
+	category.Name = req.Name
+	category.ParentID = req.ParentID
+
+	// commit transaction
+	errCommit := uc.txManager.Commit()
+	if errCommit != nil {
+		log.Printf("failed to commit transaction:%+v\n", errCommit)
+		return payload.Category{}, errCommit
+	}
+
+	return converter.ConvertCategoryEntityToPayload(category), nil
+}
+
+// DeleteCategory the children of category must be moved or deleted first
+func (uc CategoryUseCaseImpl) DeleteCategory(ctx context.Context, categoryID valueobject.CategoryID) error {
+	categories, err := uc.categoryRepository.List(ctx)
+	if err != nil {
+		log.Println("failed to get categories")
+		return err
+	}
+
+	category, ok := entity.Categories(categories).Find(categoryID)
+	if !ok {
+		return newNotFoundCategoryError(categoryID)
+	}
+
+	if entity.Categories(categories).HasChildren(categoryID) {
+		msg := fmt.Sprintf("the category has children:%d", categoryID)
+		log.Println(msg)
+		return payload.Error{
+			Code:    payload.ErrCodeCategoryHasChildren,
+			Message: msg,
+			Param:   categoryID,
+			Type:    payload.ErrorTypeConflict,
+		}
+	}
+
+	err = uc.categoryRepository.Delete(ctx, &category)
+	if err != nil {
+		log.Printf("failed to delete category:%d\n", categoryID)
+		return err
+	}
+
+	return nil
+}
+
+// AssignItem the item is unassigned from all the categories when CategoryIDs is empty,
+// the categories are returned in the id order
+func (uc CategoryUseCaseImpl) AssignItem(ctx context.Context, req payload.ItemCategoriesRequest) ([]payload.Category, error) {
+	err := checkItemExists(ctx, uc.itemRepository, req.ItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := uc.categoryRepository.List(ctx)
+	if err != nil {
+		log.Println("failed to get categories")
+		return nil, err
+	}
+
+	// the duplicated categories are assigned once
+	assigned := make(map[valueobject.CategoryID]entity.Category, len(req.CategoryIDs))
+	for _, categoryID := range req.CategoryIDs {
+		category, ok := entity.Categories(categories).Find(categoryID)
+		if !ok {
+			return nil, newNotFoundCategoryError(categoryID)
+		}
+		assigned[categoryID] = category
+	}
+	categoryIDs := make([]valueobject.CategoryID, 0, len(assigned))
+	for categoryID := range assigned {
+		categoryIDs = append(categoryIDs, categoryID)
+	}
+	sort.Slice(categoryIDs, func(i, j int) bool {
+		return categoryIDs[i] < categoryIDs[j]
+	})
+
+	// start transaction
+	uc.txManager.Begin()
+
+	// assign tx to repositories
+	uc.itemCategoryRepository.AssignTx(uc.txManager)
+
+	err = uc.itemCategoryRepository.ReplaceByItem(ctx, req.ItemID, categoryIDs)
+	if err != nil {
+		log.Printf("failed to assign item:%d to categories:%v\n", req.ItemID, categoryIDs)
+		uc.txManager.Rollback()
+		return nil, err
+	}
+
+	// commit transaction
+	errCommit := uc.txManager.Commit()
+	if errCommit != nil {
+		log.Printf("failed to commit transaction:%+v\n", errCommit)
+		return nil, errCommit
+	}
+
+	categoryResps := make([]payload.Category, len(categoryIDs))
+	for i, categoryID := range categoryIDs {
+		categoryResps[i] = converter.ConvertCategoryEntityToPayload(assigned[categoryID])
+	}
+
+	return categoryResps, nil
+}
+
+// ListByItem get the categories of an item
+func (uc CategoryUseCaseImpl) ListByItem(ctx context.Context, itemID valueobject.ItemID) ([]payload.Category, error) {
+	err := checkItemExists(ctx, uc.itemRepository, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := uc.itemCategoryRepository.ListCategoriesByItem(ctx, itemID)
+	if err != nil {
+		log.Printf("failed to get categories of item:%d\n", itemID)
+		return nil, err
+	}
+
+	return converter.ConvertCategoryEntitiesToPayload(categories), nil
+}
+
+// newNotFoundCategoryError create the not found error of category
+func newNotFoundCategoryError(categoryID valueobject.CategoryID) payload.Error {
+	msg := fmt.Sprintf("not found category:%d", categoryID)
+	log.Println(msg)
+	return payload.Error{
+		Code:    payload.ErrCodeNotFoundCategory,
+		Message: msg,
+		Param:   categoryID,
+		Type:    payload.ErrorTypeNotFound,
+	}
+}
@@ -0,0 +1,349 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository/mock"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// newTestCategories Clothes > Shirts > T-shirts and Shoes
+func newTestCategories() []entity.Category {
+	clothesID := valueobject.CategoryID(1)
+	shirtsID := valueobject.CategoryID(2)
+	return []entity.Category{
+		{ID: clothesID, Name: "Clothes"},
+		{ID: shirtsID, Name: "Shirts", ParentID: &clothesID},
+		{ID: valueobject.CategoryID(3), Name: "T-shirts", ParentID: &shirtsID},
+		{ID: valueobject.CategoryID(4), Name: "Shoes"},
+	}
+}
+
+func TestCategoryUseCaseImpl_Create(t *testing.T) {
+	t.Run("#1: Not found parent category", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mCategoryRepo := mock.NewMockCategoryRepository(mockCtrl)
+
+		uc := CategoryUseCaseImpl{
+			categoryRepository: mCategoryRepo,
+		}
+		ctx := context.Background()
+		parentID := valueobject.CategoryID(9)
+		mCategoryRepo.EXPECT().GetByID(ctx, parentID).Return(entity.Category{}, nil)
+
+		_, err := uc.Create(ctx, payload.CreateCategoryRequest{Name: "Shirts", ParentID: &parentID})
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundCategory,
+			Message: "not found category:9",
+			Param:   parentID,
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Create() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mCategoryRepo := mock.NewMockCategoryRepository(mockCtrl)
+
+		uc := CategoryUseCaseImpl{
+			categoryRepository: mCategoryRepo,
+		}
+		ctx := context.Background()
+		parentID := valueobject.CategoryID(1)
+		createdAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		mCategoryRepo.EXPECT().GetByID(ctx, parentID).Return(entity.Category{ID: parentID, Name: "Clothes"}, nil)
+		mCategoryRepo.EXPECT().Create(ctx, &entity.Category{Name: "Shirts", ParentID: &parentID}).
+			DoAndReturn(func(ctx context.Context, category *entity.Category) error {
+				category.ID = valueobject.CategoryID(2)
+				category.CreatedAt = createdAt
+				return nil
+			})
+
+		got, err := uc.Create(ctx, payload.CreateCategoryRequest{Name: "Shirts", ParentID: &parentID})
+		if err != nil {
+			t.Errorf("uc.Create() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Category{
+			ID:        valueobject.CategoryID(2),
+			Name:      "Shirts",
+			ParentID:  &parentID,
+			CreatedAt: createdAt,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestCategoryUseCaseImpl_UpdateCategory(t *testing.T) {
+	t.Run("#1: Move the category under its descendant", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mCategoryRepo := mock.NewMockCategoryRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := CategoryUseCaseImpl{
+			categoryRepository: mCategoryRepo,
+			txManager:          mTxManager,
+		}
+		ctx := context.Background()
+		parentID := valueobject.CategoryID(3)
+		mTxManager.EXPECT().Begin()
+		mCategoryRepo.EXPECT().AssignTx(mTxManager)
+		mCategoryRepo.EXPECT().ListForUpdate(ctx).Return(newTestCategories(), nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.UpdateCategory(ctx, payload.UpdateCategoryRequest{
+			CategoryID: valueobject.CategoryID(1),
+			Name:       "Clothes",
+			ParentID:   &parentID,
+		})
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeInvalidCategoryParent,
+			Message: "the category cannot be moved under itself or its descendants - category:1 - parent:3",
+			Param:   parentID,
+			Type:    payload.ErrorTypeInvalidArgument,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.UpdateCategory() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Not found category", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mCategoryRepo := mock.NewMockCategoryRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := CategoryUseCaseImpl{
+			categoryRepository: mCategoryRepo,
+			txManager:          mTxManager,
+		}
+		ctx := context.Background()
+		mTxManager.EXPECT().Begin()
+		mCategoryRepo.EXPECT().AssignTx(mTxManager)
+		mCategoryRepo.EXPECT().ListForUpdate(ctx).Return(newTestCategories(), nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.UpdateCategory(ctx, payload.UpdateCategoryRequest{
+			CategoryID: valueobject.CategoryID(9),
+			Name:       "Hats",
+		})
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundCategory,
+			Message: "not found category:9",
+			Param:   valueobject.CategoryID(9),
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.UpdateCategory() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#3: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mCategoryRepo := mock.NewMockCategoryRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := CategoryUseCaseImpl{
+			categoryRepository: mCategoryRepo,
+			txManager:          mTxManager,
+		}
+		ctx := context.Background()
+		parentID := valueobject.CategoryID(4)
+		categories := newTestCategories()
+		mTxManager.EXPECT().Begin()
+		mCategoryRepo.EXPECT().AssignTx(mTxManager)
+		mCategoryRepo.EXPECT().ListForUpdate(ctx).Return(categories, nil)
+		mCategoryRepo.EXPECT().Updates(ctx, &categories[2], map[string]interface{}{
+			"name":      "Sneakers",
+			"parent_id": &parentID,
+		}).Return(nil)
+		mTxManager.EXPECT().Commit()
+
+		got, err := uc.UpdateCategory(ctx, payload.UpdateCategoryRequest{
+			CategoryID: valueobject.CategoryID(3),
+			Name:       "Sneakers",
+			ParentID:   &parentID,
+		})
+		if err != nil {
+			t.Errorf("uc.UpdateCategory() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Category{
+			ID:       valueobject.CategoryID(3),
+			Name:     "Sneakers",
+			ParentID: &parentID,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestCategoryUseCaseImpl_DeleteCategory(t *testing.T) {
+	t.Run("#1: Category has children", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mCategoryRepo := mock.NewMockCategoryRepository(mockCtrl)
+
+		uc := CategoryUseCaseImpl{
+			categoryRepository: mCategoryRepo,
+		}
+		ctx := context.Background()
+		mCategoryRepo.EXPECT().List(ctx).Return(newTestCategories(), nil)
+
+		err := uc.DeleteCategory(ctx, valueobject.CategoryID(2))
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeCategoryHasChildren,
+			Message: "the category has children:2",
+			Param:   valueobject.CategoryID(2),
+			Type:    payload.ErrorTypeConflict,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.DeleteCategory() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mCategoryRepo := mock.NewMockCategoryRepository(mockCtrl)
+
+		uc := CategoryUseCaseImpl{
+			categoryRepository: mCategoryRepo,
+		}
+		ctx := context.Background()
+		categories := newTestCategories()
+		mCategoryRepo.EXPECT().List(ctx).Return(categories, nil)
+		mCategoryRepo.EXPECT().Delete(ctx, &categories[2]).Return(nil)
+
+		err := uc.DeleteCategory(ctx, valueobject.CategoryID(3))
+		if err != nil {
+			t.Errorf("uc.DeleteCategory() return an error:%v - want:nil", err)
+		}
+	})
+}
+
+func TestCategoryUseCaseImpl_AssignItem(t *testing.T) {
+	t.Run("#1: Not found category", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mCategoryRepo := mock.NewMockCategoryRepository(mockCtrl)
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+
+		uc := CategoryUseCaseImpl{
+			categoryRepository: mCategoryRepo,
+			itemRepository:     mItemRepo,
+		}
+		ctx := context.Background()
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{ID: valueobject.ItemID(1)}, nil)
+		mCategoryRepo.EXPECT().List(ctx).Return(newTestCategories(), nil)
+
+		_, err := uc.AssignItem(ctx, payload.ItemCategoriesRequest{
+			ItemID:      valueobject.ItemID(1),
+			CategoryIDs: []valueobject.CategoryID{3, 9},
+		})
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundCategory,
+			Message: "not found category:9",
+			Param:   valueobject.CategoryID(9),
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.AssignItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Archived item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+
+		uc := CategoryUseCaseImpl{
+			itemRepository: mItemRepo,
+		}
+		ctx := context.Background()
+		deletedAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{
+			ID:        valueobject.ItemID(1),
+			DeletedAt: &deletedAt,
+		}, nil)
+
+		_, err := uc.AssignItem(ctx, payload.ItemCategoriesRequest{
+			ItemID:      valueobject.ItemID(1),
+			CategoryIDs: []valueobject.CategoryID{3},
+		})
+		wannaErr := newNotFoundItemError(valueobject.ItemID(1))
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.AssignItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#3: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mCategoryRepo := mock.NewMockCategoryRepository(mockCtrl)
+		mItemCategoryRepo := mock.NewMockItemCategoryRepository(mockCtrl)
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := CategoryUseCaseImpl{
+			categoryRepository:     mCategoryRepo,
+			itemCategoryRepository: mItemCategoryRepo,
+			itemRepository:         mItemRepo,
+			txManager:              mTxManager,
+		}
+		ctx := context.Background()
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{ID: valueobject.ItemID(1)}, nil)
+		mCategoryRepo.EXPECT().List(ctx).Return(newTestCategories(), nil)
+		mTxManager.EXPECT().Begin()
+		mItemCategoryRepo.EXPECT().AssignTx(mTxManager)
+		mItemCategoryRepo.EXPECT().ReplaceByItem(ctx, valueobject.ItemID(1), []valueobject.CategoryID{3, 4}).Return(nil)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.AssignItem(ctx, payload.ItemCategoriesRequest{
+			ItemID:      valueobject.ItemID(1),
+			CategoryIDs: []valueobject.CategoryID{4, 3, 4},
+		})
+		if err != nil {
+			t.Errorf("uc.AssignItem() return an error:%v - want:nil", err)
+			return
+		}
+
+		shirtsID := valueobject.CategoryID(2)
+		want := []payload.Category{
+			{ID: valueobject.CategoryID(3), Name: "T-shirts", ParentID: &shirtsID},
+			{ID: valueobject.CategoryID(4), Name: "Shoes"},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
@@ -0,0 +1,202 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/usecase"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+const (
+	// idempotencyInProgressTTL the key of a request which never completes, e.g. the process crashed, is taken over after it
+	idempotencyInProgressTTL = 5 * time.Minute
+	// idempotencyCompletedTTL the stored response is replayed during it
+	idempotencyCompletedTTL = 24 * time.Hour
+	purgeExpiredBatchSize   = 1000
+)
+
+// IdempotencyUseCaseImpl implementation of Idempotency usecase
+type IdempotencyUseCaseImpl struct {
+	idempotencyRepository repository.IdempotencyRepository
+}
+
+// NewIdempotencyUseCaseInteractor create new instance of Idempotency interactor
+func NewIdempotencyUseCaseInteractor(idempotencyRepo repository.IdempotencyRepository) usecase.IdempotencyUseCase {
+	return &IdempotencyUseCaseImpl{
+		idempotencyRepository: idempotencyRepo,
+	}
+}
+
+// Start reserve the key for the first request, replay the stored response for the retried requests
+func (uc IdempotencyUseCaseImpl) Start(ctx context.Context, req payload.IdempotencyRequest) (*payload.IdempotentResponse, error) {
+	newRecord := entity.IdempotencyRecord{
+		Key:         req.Key,
+		RequestHash: req.RequestHash,
+		ExpiresAt:   req.RequestedAt.Add(idempotencyInProgressTTL),
+	}
+	created, err := uc.idempotencyRepository.CreateIfNotExists(ctx, &newRecord)
+	if err != nil {
+		log.Printf("failed to create idempotency record:%s\n", req.Key)
+		return nil, err
+	}
+
+	if created {
+		return nil, nil
+	}
+
+	record, err := uc.idempotencyRepository.GetByKey(ctx, req.Key)
+	if err != nil {
+		log.Printf("failed to get idempotency record:%s\n", req.Key)
+		return nil, err
+	}
+
+	if reflect.DeepEqual(record, entity.IdempotencyRecord{}) {
+		// the first request has just failed and released the key
+		return nil, newIdempotencyKeyInProgressError(req.Key)
+	}
+
+	if record.IsExpired(req.RequestedAt) {
+		// the stale key is handled as a new one
+		takenOver, err := uc.idempotencyRepository.TakeOverExpired(ctx, &newRecord, req.RequestedAt)
+		if err != nil {
+			log.Printf("failed to take over idempotency record:%s\n", req.Key)
+			return nil, err
+		}
+
+		if !takenOver {
+			// another request has just taken it over
+			return nil, newIdempotencyKeyInProgressError(req.Key)
+		}
+		return nil, nil
+	}
+
+	if record.RequestHash != req.RequestHash {
+		msg := fmt.Sprintf("the idempotency key has been used for another request:%s", req.Key)
+		log.Println(msg)
+		return nil, payload.Error{
+			Code:    payload.ErrCodeIdempotencyKeyReused,
+			Message: msg,
+			Param:   req.Key,
+			Type:    payload.ErrorTypeUnprocessable,
+		}
+	}
+
+	if !record.IsCompleted() {
+		return nil, newIdempotencyKeyInProgressError(req.Key)
+	}
+
+	var header map[string][]string
+	if record.ResponseHeaders != "" {
+		if err := json.Unmarshal([]byte(record.ResponseHeaders), &header); err != nil {
+			log.Printf("failed to decode response headers of idempotency record:%s\n", req.Key)
+			return nil, err
+		}
+	}
+
+	return &payload.IdempotentResponse{
+		StatusCode: record.ResponseStatus,
+		Header:     header,
+		Body:       []byte(record.ResponseBody),
+	}, nil
+}
+
+// Complete store the response of request to replay it later
+func (uc IdempotencyUseCaseImpl) Complete(
+	ctx context.Context,
+	req payload.IdempotencyRequest,
+	resp payload.IdempotentResponse,
+) error {
+	record, err := uc.idempotencyRepository.GetByKey(ctx, req.Key)
+	if err != nil {
+		log.Printf("failed to get idempotency record:%s\n", req.Key)
+		return err
+	}
+
+	if reflect.DeepEqual(record, entity.IdempotencyRecord{}) {
+		return fmt.Errorf("not found idempotency record:%s", req.Key)
+	}
+
+	if record.IsCompleted() || record.RequestHash != req.RequestHash {
+		// the key has expired and been taken over by another request
+		return fmt.Errorf("idempotency record has been taken over:%s", req.Key)
+	}
+
+	header, err := json.Marshal(resp.Header)
+	if err != nil {
+		log.Printf("failed to encode response headers of idempotency record:%s\n", req.Key)
+		return err
+	}
+
+	updateValues := map[string]interface{}{
+		"response_status":  resp.StatusCode,
+		"response_headers": string(header),
+		"response_body":    string(resp.Body),
+		"expires_at":       req.RequestedAt.Add(idempotencyCompletedTTL),
+	}
+	err = uc.idempotencyRepository.Updates(ctx, &record, updateValues)
+	if err != nil {
+		log.Printf("failed to store response of idempotency record:%s\n", req.Key)
+		return err
+	}
+
+	return nil
+}
+
+// Release remove the idempotency record
+func (uc IdempotencyUseCaseImpl) Release(ctx context.Context, req payload.IdempotencyRequest) error {
+	record, err := uc.idempotencyRepository.GetByKey(ctx, req.Key)
+	if err != nil {
+		log.Printf("failed to get idempotency record:%s\n", req.Key)
+		return err
+	}
+
+	if reflect.DeepEqual(record, entity.IdempotencyRecord{}) ||
+		record.IsCompleted() || record.RequestHash != req.RequestHash {
+		// the key has expired and been taken over by another request
+		return nil
+	}
+
+	err = uc.idempotencyRepository.Delete(ctx, &record)
+	if err != nil {
+		log.Printf("failed to delete idempotency record:%s\n", req.Key)
+		return err
+	}
+
+	return nil
+}
+
+// PurgeExpired remove the expired keys batch by batch until no expired key is left
+func (uc IdempotencyUseCaseImpl) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	var purged int64
+	for {
+		deleted, err := uc.idempotencyRepository.DeleteExpired(ctx, now, purgeExpiredBatchSize)
+		if err != nil {
+			log.Println("failed to delete expired idempotency records")
+			return purged, err
+		}
+
+		purged += deleted
+		if deleted < purgeExpiredBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// newIdempotencyKeyInProgressError create the error of the request being processed
+func newIdempotencyKeyInProgressError(key string) payload.Error {
+	msg := fmt.Sprintf("the request with idempotency key is being processed:%s", key)
+	log.Println(msg)
+	return payload.Error{
+		Code:    payload.ErrCodeIdempotencyKeyInProgress,
+		Message: msg,
+		Param:   key,
+		Type:    payload.ErrorTypeConflict,
+	}
+}
@@ -0,0 +1,439 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository/mock"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestIdempotencyUseCaseImpl_Start(t *testing.T) {
+	now := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+
+	t.Run("#1: First request reserves the key", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mIdempotencyRepo := mock.NewMockIdempotencyRepository(mockCtrl)
+
+		uc := IdempotencyUseCaseImpl{
+			idempotencyRepository: mIdempotencyRepo,
+		}
+		ctx := context.Background()
+		req := payload.IdempotencyRequest{
+			Key:         "key-1",
+			RequestHash: "hash",
+			RequestedAt: now,
+		}
+		record := entity.IdempotencyRecord{
+			Key:         "key-1",
+			RequestHash: "hash",
+			ExpiresAt:   now.Add(idempotencyInProgressTTL),
+		}
+		mIdempotencyRepo.EXPECT().CreateIfNotExists(ctx, &record).Return(true, nil)
+
+		got, err := uc.Start(ctx, req)
+		if err != nil {
+			t.Errorf("uc.Start() return an error:%v - want:nil", err)
+			return
+		}
+
+		if got != nil {
+			t.Errorf("uc.Start() return a response:%+v - want:nil", got)
+		}
+	})
+
+	t.Run("#2: Replay the stored response", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mIdempotencyRepo := mock.NewMockIdempotencyRepository(mockCtrl)
+
+		uc := IdempotencyUseCaseImpl{
+			idempotencyRepository: mIdempotencyRepo,
+		}
+		ctx := context.Background()
+		req := payload.IdempotencyRequest{
+			Key:         "key-1",
+			RequestHash: "hash",
+			RequestedAt: now,
+		}
+		record := entity.IdempotencyRecord{
+			Key:         "key-1",
+			RequestHash: "hash",
+			ExpiresAt:   now.Add(idempotencyInProgressTTL),
+		}
+		storedRecord := entity.IdempotencyRecord{
+			ID:              valueobject.IdempotencyRecordID(1),
+			Key:             "key-1",
+			RequestHash:     "hash",
+			ResponseStatus:  201,
+			ResponseHeaders: `{"Etag":["\"1\""]}`,
+			ResponseBody:    `{"id":1}`,
+			ExpiresAt:       now.Add(idempotencyCompletedTTL),
+		}
+		mIdempotencyRepo.EXPECT().CreateIfNotExists(ctx, &record).Return(false, nil)
+		mIdempotencyRepo.EXPECT().GetByKey(ctx, "key-1").Return(storedRecord, nil)
+
+		got, err := uc.Start(ctx, req)
+		if err != nil {
+			t.Errorf("uc.Start() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := &payload.IdempotentResponse{
+			StatusCode: 201,
+			Header:     map[string][]string{"Etag": {`"1"`}},
+			Body:       []byte(`{"id":1}`),
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#3: Key has been used for another request", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mIdempotencyRepo := mock.NewMockIdempotencyRepository(mockCtrl)
+
+		uc := IdempotencyUseCaseImpl{
+			idempotencyRepository: mIdempotencyRepo,
+		}
+		ctx := context.Background()
+		req := payload.IdempotencyRequest{
+			Key:         "key-1",
+			RequestHash: "other-hash",
+			RequestedAt: now,
+		}
+		storedRecord := entity.IdempotencyRecord{
+			ID:             valueobject.IdempotencyRecordID(1),
+			Key:            "key-1",
+			RequestHash:    "hash",
+			ResponseStatus: 201,
+			ExpiresAt:      now.Add(idempotencyCompletedTTL),
+		}
+		mIdempotencyRepo.EXPECT().CreateIfNotExists(ctx, gomock.Any()).Return(false, nil)
+		mIdempotencyRepo.EXPECT().GetByKey(ctx, "key-1").Return(storedRecord, nil)
+
+		_, err := uc.Start(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeIdempotencyKeyReused,
+			Message: "the idempotency key has been used for another request:key-1",
+			Param:   "key-1",
+			Type:    payload.ErrorTypeUnprocessable,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Start() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#4: First request is being processed", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mIdempotencyRepo := mock.NewMockIdempotencyRepository(mockCtrl)
+
+		uc := IdempotencyUseCaseImpl{
+			idempotencyRepository: mIdempotencyRepo,
+		}
+		ctx := context.Background()
+		req := payload.IdempotencyRequest{
+			Key:         "key-1",
+			RequestHash: "hash",
+			RequestedAt: now,
+		}
+		storedRecord := entity.IdempotencyRecord{
+			ID:          valueobject.IdempotencyRecordID(1),
+			Key:         "key-1",
+			RequestHash: "hash",
+			ExpiresAt:   now.Add(time.Minute),
+		}
+		mIdempotencyRepo.EXPECT().CreateIfNotExists(ctx, gomock.Any()).Return(false, nil)
+		mIdempotencyRepo.EXPECT().GetByKey(ctx, "key-1").Return(storedRecord, nil)
+
+		_, err := uc.Start(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeIdempotencyKeyInProgress,
+			Message: "the request with idempotency key is being processed:key-1",
+			Param:   "key-1",
+			Type:    payload.ErrorTypeConflict,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Start() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#5: Failed to create record", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mIdempotencyRepo := mock.NewMockIdempotencyRepository(mockCtrl)
+
+		uc := IdempotencyUseCaseImpl{
+			idempotencyRepository: mIdempotencyRepo,
+		}
+		ctx := context.Background()
+		wannaErr := errors.New("failed to create record")
+		mIdempotencyRepo.EXPECT().CreateIfNotExists(ctx, gomock.Any()).Return(false, wannaErr)
+
+		_, err := uc.Start(ctx, payload.IdempotencyRequest{Key: "key-1", RequestHash: "hash"})
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Start() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#6: Take over the expired key of a request which never completed", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mIdempotencyRepo := mock.NewMockIdempotencyRepository(mockCtrl)
+
+		uc := IdempotencyUseCaseImpl{
+			idempotencyRepository: mIdempotencyRepo,
+		}
+		ctx := context.Background()
+		req := payload.IdempotencyRequest{
+			Key:         "key-1",
+			RequestHash: "hash",
+			RequestedAt: now,
+		}
+		record := entity.IdempotencyRecord{
+			Key:         "key-1",
+			RequestHash: "hash",
+			ExpiresAt:   now.Add(idempotencyInProgressTTL),
+		}
+		storedRecord := entity.IdempotencyRecord{
+			ID:          valueobject.IdempotencyRecordID(1),
+			Key:         "key-1",
+			RequestHash: "hash",
+			ExpiresAt:   now.Add(-time.Minute),
+		}
+		mIdempotencyRepo.EXPECT().CreateIfNotExists(ctx, &record).Return(false, nil)
+		mIdempotencyRepo.EXPECT().GetByKey(ctx, "key-1").Return(storedRecord, nil)
+		mIdempotencyRepo.EXPECT().TakeOverExpired(ctx, &record, now).Return(true, nil)
+
+		got, err := uc.Start(ctx, req)
+		if err != nil {
+			t.Errorf("uc.Start() return an error:%v - want:nil", err)
+			return
+		}
+
+		if got != nil {
+			t.Errorf("uc.Start() return a response:%+v - want:nil", got)
+		}
+	})
+
+	t.Run("#7: Another request has taken over the expired key", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mIdempotencyRepo := mock.NewMockIdempotencyRepository(mockCtrl)
+
+		uc := IdempotencyUseCaseImpl{
+			idempotencyRepository: mIdempotencyRepo,
+		}
+		ctx := context.Background()
+		req := payload.IdempotencyRequest{
+			Key:         "key-1",
+			RequestHash: "hash",
+			RequestedAt: now,
+		}
+		storedRecord := entity.IdempotencyRecord{
+			ID:             valueobject.IdempotencyRecordID(1),
+			Key:            "key-1",
+			RequestHash:    "hash",
+			ResponseStatus: 201,
+			ExpiresAt:      now.Add(-time.Minute),
+		}
+		mIdempotencyRepo.EXPECT().CreateIfNotExists(ctx, gomock.Any()).Return(false, nil)
+		mIdempotencyRepo.EXPECT().GetByKey(ctx, "key-1").Return(storedRecord, nil)
+		mIdempotencyRepo.EXPECT().TakeOverExpired(ctx, gomock.Any(), now).Return(false, nil)
+
+		_, err := uc.Start(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeIdempotencyKeyInProgress,
+			Message: "the request with idempotency key is being processed:key-1",
+			Param:   "key-1",
+			Type:    payload.ErrorTypeConflict,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Start() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+}
+
+func TestIdempotencyUseCaseImpl_Complete(t *testing.T) {
+	now := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mIdempotencyRepo := mock.NewMockIdempotencyRepository(mockCtrl)
+
+		uc := IdempotencyUseCaseImpl{
+			idempotencyRepository: mIdempotencyRepo,
+		}
+		ctx := context.Background()
+		record := entity.IdempotencyRecord{
+			ID:          valueobject.IdempotencyRecordID(1),
+			Key:         "key-1",
+			RequestHash: "hash",
+		}
+		updateValues := map[string]interface{}{
+			"response_status":  201,
+			"response_headers": `{"Etag":["\"1\""]}`,
+			"response_body":    `{"id":1}`,
+			"expires_at":       now.Add(idempotencyCompletedTTL),
+		}
+		mIdempotencyRepo.EXPECT().GetByKey(ctx, "key-1").Return(record, nil)
+		mIdempotencyRepo.EXPECT().Updates(ctx, &record, updateValues).Return(nil)
+
+		err := uc.Complete(
+			ctx,
+			payload.IdempotencyRequest{Key: "key-1", RequestHash: "hash", RequestedAt: now},
+			payload.IdempotentResponse{
+				StatusCode: 201,
+				Header:     map[string][]string{"Etag": {`"1"`}},
+				Body:       []byte(`{"id":1}`),
+			},
+		)
+		if err != nil {
+			t.Errorf("uc.Complete() return an error:%v - want:nil", err)
+		}
+	})
+
+	t.Run("#2: Key has been taken over by another request", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mIdempotencyRepo := mock.NewMockIdempotencyRepository(mockCtrl)
+
+		uc := IdempotencyUseCaseImpl{
+			idempotencyRepository: mIdempotencyRepo,
+		}
+		ctx := context.Background()
+		record := entity.IdempotencyRecord{
+			ID:          valueobject.IdempotencyRecordID(1),
+			Key:         "key-1",
+			RequestHash: "other-hash",
+		}
+		mIdempotencyRepo.EXPECT().GetByKey(ctx, "key-1").Return(record, nil)
+
+		err := uc.Complete(
+			ctx,
+			payload.IdempotencyRequest{Key: "key-1", RequestHash: "hash", RequestedAt: now},
+			payload.IdempotentResponse{StatusCode: 201, Body: []byte(`{"id":1}`)},
+		)
+		if err == nil {
+			t.Error("uc.Complete() return nil - want an error")
+		}
+	})
+}
+
+func TestIdempotencyUseCaseImpl_Release(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mIdempotencyRepo := mock.NewMockIdempotencyRepository(mockCtrl)
+
+		uc := IdempotencyUseCaseImpl{
+			idempotencyRepository: mIdempotencyRepo,
+		}
+		ctx := context.Background()
+		record := entity.IdempotencyRecord{
+			ID:          valueobject.IdempotencyRecordID(1),
+			Key:         "key-1",
+			RequestHash: "hash",
+		}
+		mIdempotencyRepo.EXPECT().GetByKey(ctx, "key-1").Return(record, nil)
+		mIdempotencyRepo.EXPECT().Delete(ctx, &record).Return(nil)
+
+		err := uc.Release(ctx, payload.IdempotencyRequest{Key: "key-1", RequestHash: "hash"})
+		if err != nil {
+			t.Errorf("uc.Release() return an error:%v - want:nil", err)
+		}
+	})
+	t.Run("#2: Key has been taken over by another request", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mIdempotencyRepo := mock.NewMockIdempotencyRepository(mockCtrl)
+
+		uc := IdempotencyUseCaseImpl{
+			idempotencyRepository: mIdempotencyRepo,
+		}
+		ctx := context.Background()
+		record := entity.IdempotencyRecord{
+			ID:             valueobject.IdempotencyRecordID(1),
+			Key:            "key-1",
+			RequestHash:    "hash",
+			ResponseStatus: 201,
+		}
+		mIdempotencyRepo.EXPECT().GetByKey(ctx, "key-1").Return(record, nil)
+
+		err := uc.Release(ctx, payload.IdempotencyRequest{Key: "key-1", RequestHash: "hash"})
+		if err != nil {
+			t.Errorf("uc.Release() return an error:%v - want:nil", err)
+		}
+	})
+}
+
+func TestIdempotencyUseCaseImpl_PurgeExpired(t *testing.T) {
+	now := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+
+	t.Run("#1: Purge batch by batch until no expired key is left", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mIdempotencyRepo := mock.NewMockIdempotencyRepository(mockCtrl)
+
+		uc := IdempotencyUseCaseImpl{
+			idempotencyRepository: mIdempotencyRepo,
+		}
+		ctx := context.Background()
+		gomock.InOrder(
+			mIdempotencyRepo.EXPECT().DeleteExpired(ctx, now, purgeExpiredBatchSize).Return(int64(purgeExpiredBatchSize), nil),
+			mIdempotencyRepo.EXPECT().DeleteExpired(ctx, now, purgeExpiredBatchSize).Return(int64(2), nil),
+		)
+
+		got, err := uc.PurgeExpired(ctx, now)
+		if err != nil {
+			t.Errorf("uc.PurgeExpired() return an error:%v - want:nil", err)
+			return
+		}
+
+		if got != purgeExpiredBatchSize+2 {
+			t.Errorf("uc.PurgeExpired() return:%d - want:%d", got, purgeExpiredBatchSize+2)
+		}
+	})
+
+	t.Run("#2: Failed to delete expired records", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mIdempotencyRepo := mock.NewMockIdempotencyRepository(mockCtrl)
+
+		uc := IdempotencyUseCaseImpl{
+			idempotencyRepository: mIdempotencyRepo,
+		}
+		ctx := context.Background()
+		wannaErr := errors.New("failed to delete")
+		mIdempotencyRepo.EXPECT().DeleteExpired(ctx, now, purgeExpiredBatchSize).Return(int64(0), wannaErr)
+
+		_, err := uc.PurgeExpired(ctx, now)
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.PurgeExpired() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+}
@@ -0,0 +1,342 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase"
+	"github.com/tuanna7593/gosample/app/usecase/converter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// InventoryUseCaseImpl implementation of Inventory usecase
+type InventoryUseCaseImpl struct {
+	itemRepository              repository.ItemRepository
+	purchaseRepository          repository.PurchaseRepository
+	inventoryMovementRepository repository.InventoryMovementRepository
+	locationRepository          repository.LocationRepository
+	locationStockRepository     repository.LocationStockRepository
+	txManager                   repository.TransactionManager
+	allocationStrategy          valueobject.AllocationStrategy
+}
+
+// NewInventoryUseCaseInteractor create new instance of Inventory interactor
+func NewInventoryUseCaseInteractor(
+	itemRepo repository.ItemRepository,
+	purchaseRepo repository.PurchaseRepository,
+	movementRepo repository.InventoryMovementRepository,
+	locationRepo repository.LocationRepository,
+	locationStockRepo repository.LocationStockRepository,
+	txManager repository.TransactionManager,
+	allocationStrategy valueobject.AllocationStrategy,
+) usecase.InventoryUseCase {
+	return &InventoryUseCaseImpl{
+		itemRepository:              itemRepo,
+		purchaseRepository:          purchaseRepo,
+		inventoryMovementRepository: movementRepo,
+		locationRepository:          locationRepo,
+		locationStockRepository:     locationStockRepo,
+		txManager:                   txManager,
+		allocationStrategy:          allocationStrategy,
+	}
+}
+
+// Restock add the quantity to both the total stock and the current stock of item,
+// the backordered purchases of item are fulfilled with the restocked stock
+func (uc InventoryUseCaseImpl) Restock(ctx context.Context, req payload.RestockRequest) (payload.Item, error) {
+	// start transaction
+	uc.txManager.Begin()
+
+	// assign tx to repositories
+	uc.itemRepository.AssignTx(uc.txManager)
+	uc.purchaseRepository.AssignTx(uc.txManager)
+	uc.inventoryMovementRepository.AssignTx(uc.txManager)
+	uc.locationStockRepository.AssignTx(uc.txManager)
+
+	var err error
+	defer func() {
+		if err != nil {
+			log.Printf("found error - rollback transaction:%v\n", err)
+			uc.txManager.Rollback()
+		}
+	}()
+
+	// find and lock item until the transaction ends
+	item, err := uc.itemRepository.GetByIDForUpdate(ctx, req.ItemID)
+	if err != nil {
+		log.Printf("failed to get item:%d\n", req.ItemID)
+		return payload.Item{}, err
+	}
+
+	if reflect.DeepEqual(item, entity.Item{}) || item.IsArchived() {
+		err = newNotFoundItemError(req.ItemID)
+		return payload.Item{}, err
+	}
+
+	err = checkItemVersion(item, req.ExpectedVersion)
+	if err != nil {
+		return payload.Item{}, err
+	}
+
+	// restocked the stock of item after the restock, the backorders are fulfilled from it
+	restocked := item
+	restocked.TotalStockValue += req.Quantity
+	restocked.CurrentStockValue += req.Quantity
+	updateValues := map[string]interface{}{
+		"total_stock_value":   restocked.TotalStockValue,
+		"current_stock_value": restocked.CurrentStockValue,
+	}
+	if req.LocationID != nil {
+		_, err = getLocation(ctx, uc.locationRepository, *req.LocationID)
+		if err != nil {
+			return payload.Item{}, err
+		}
+
+		located, errPut := putStock(ctx, uc.locationStockRepository, item, req.Quantity, *req.LocationID)
+		if errPut != nil {
+			err = errPut
+			return payload.Item{}, err
+		}
+		restocked.LocatedStockValue = located
+		updateValues["located_stock_value"] = located
+	}
+	err = uc.itemRepository.Updates(ctx, &item, updateValues)
+	if err != nil {
+		log.Printf("failed to restock item:%d\n", item.ID)
+		return payload.Item{}, err
+	}
+
+	err = createInventoryMovement(
+		ctx, uc.inventoryMovementRepository,
+		item.ID, valueobject.MovementReasonRestock, int64(req.Quantity),
+	)
+	if err != nil {
+		return payload.Item{}, err
+	}
+
+	err = fulfillQueuedBackorders(
+		ctx, uc.itemRepository, uc.purchaseRepository, uc.inventoryMovementRepository,
+		uc.locationStockRepository, uc.allocationStrategy, &item, restocked,
+	)
+	if err != nil {
+		return payload.Item{}, err
+	}
+
+	itemPayload, err := convertItemWithLocations(ctx, uc.locationStockRepository, item)
+	if err != nil {
+		return payload.Item{}, err
+	}
+
+	// commit transaction
+	errCommit := uc.txManager.Commit()
+	if errCommit != nil {
+		log.Printf("failed to commit transaction:%+v\n", errCommit)
+		return payload.Item{}, errCommit
+	}
+
+	return itemPayload, nil
+}
+
+// Adjust add a signed delta to both the total stock and the current stock of item,
+// the stock which has been sold or reserved cannot be adjusted so the current stock never goes below zero
+func (uc InventoryUseCaseImpl) Adjust(ctx context.Context, req payload.AdjustmentRequest) (payload.Item, error) {
+	// start transaction
+	uc.txManager.Begin()
+
+	// assign tx to repositories
+	uc.itemRepository.AssignTx(uc.txManager)
+	uc.purchaseRepository.AssignTx(uc.txManager)
+	uc.inventoryMovementRepository.AssignTx(uc.txManager)
+	uc.locationStockRepository.AssignTx(uc.txManager)
+
+	var err error
+	defer func() {
+		if err != nil {
+			log.Printf("found error - rollback transaction:%v\n", err)
+			uc.txManager.Rollback()
+		}
+	}()
+
+	// find and lock item until the transaction ends
+	item, err := uc.itemRepository.GetByIDForUpdate(ctx, req.ItemID)
+	if err != nil {
+		log.Printf("failed to get item:%d\n", req.ItemID)
+		return payload.Item{}, err
+	}
+
+	if reflect.DeepEqual(item, entity.Item{}) || item.IsArchived() {
+		err = newNotFoundItemError(req.ItemID)
+		return payload.Item{}, err
+	}
+
+	err = checkItemVersion(item, req.ExpectedVersion)
+	if err != nil {
+		return payload.Item{}, err
+	}
+
+	if req.Delta < 0 && uint64(-req.Delta) > item.CurrentStockValue {
+		msg := fmt.Sprintf(
+			"the adjustment exceeds the current stock - item:%d - current quantity:%d - delta:%d",
+			item.ID, item.CurrentStockValue, req.Delta,
+		)
+		log.Println(msg)
+		err = payload.Error{
+			Code:    payload.ErrCodeAdjustmentBelowZero,
+			Message: msg,
+			Param:   req.Delta,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return payload.Item{}, err
+	}
+
+	if req.LocationID != nil {
+		_, err = getLocation(ctx, uc.locationRepository, *req.LocationID)
+		if err != nil {
+			return payload.Item{}, err
+		}
+	}
+
+	// the stock is removed from the requested location or by the allocation strategy,
+	// and it is only added to a location when the location is requested
+	located := item.LocatedStockValue
+	switch {
+	case req.Delta < 0:
+		located, err = takeStock(
+			ctx, uc.locationStockRepository, uc.allocationStrategy,
+			item, uint64(-req.Delta), req.LocationID,
+		)
+	case req.LocationID != nil:
+		located, err = putStock(ctx, uc.locationStockRepository, item, uint64(req.Delta), *req.LocationID)
+	}
+	if err != nil {
+		return payload.Item{}, err
+	}
+
+	// the sold and reserved stock are kept, so total stock moves together with current stock,
+	// adjusted holds the stock of item after the adjustment
+	adjusted := item
+	adjusted.TotalStockValue = uint64(int64(item.TotalStockValue) + req.Delta)
+	adjusted.CurrentStockValue = uint64(int64(item.CurrentStockValue) + req.Delta)
+	adjusted.LocatedStockValue = located
+	updateValues := map[string]interface{}{
+		"total_stock_value":   adjusted.TotalStockValue,
+		"current_stock_value": adjusted.CurrentStockValue,
+	}
+	if located != item.LocatedStockValue {
+		updateValues["located_stock_value"] = located
+	}
+	err = uc.itemRepository.Updates(ctx, &item, updateValues)
+	if err != nil {
+		log.Printf("failed to adjust item:%d\n", item.ID)
+		return payload.Item{}, err
+	}
+
+	movement := entity.InventoryMovement{
+		ItemID:     item.ID,
+		Reason:     valueobject.MovementReasonAdjustment,
+		Delta:      req.Delta,
+		ReasonCode: req.ReasonCode,
+		Actor:      req.Actor,
+		Note:       req.Note,
+	}
+	err = uc.inventoryMovementRepository.Create(ctx, &movement)
+	if err != nil {
+		log.Printf("failed to create inventory movement:%+v\n", movement)
+		return payload.Item{}, err
+	}
+
+	// the added stock is taken by the queued backorders first
+	if req.Delta > 0 {
+		err = fulfillQueuedBackorders(
+			ctx, uc.itemRepository, uc.purchaseRepository, uc.inventoryMovementRepository,
+			uc.locationStockRepository, uc.allocationStrategy, &item, adjusted,
+		)
+		if err != nil {
+			return payload.Item{}, err
+		}
+	}
+
+	itemPayload, err := convertItemWithLocations(ctx, uc.locationStockRepository, item)
+	if err != nil {
+		return payload.Item{}, err
+	}
+
+	// commit transaction
+	errCommit := uc.txManager.Commit()
+	if errCommit != nil {
+		log.Printf("failed to commit transaction:%+v\n", errCommit)
+		return payload.Item{}, errCommit
+	}
+
+	return itemPayload, nil
+}
+
+// ListMovements get the stock movements of an item, the movements of archived item are still listed
+func (uc InventoryUseCaseImpl) ListMovements(
+	ctx context.Context,
+	itemID valueobject.ItemID,
+	pagination payload.PaginationRequest,
+) ([]payload.InventoryMovement, error) {
+	item, err := uc.itemRepository.GetByID(ctx, itemID)
+	if err != nil {
+		log.Printf("failed to get item:%d\n", itemID)
+		return nil, err
+	}
+
+	if reflect.DeepEqual(item, entity.Item{}) {
+		return nil, newNotFoundItemError(itemID)
+	}
+
+	paginationValueObject := converter.ConvertPaginationPayloadToValueObject(pagination)
+	movements, err := uc.inventoryMovementRepository.ListByItem(ctx, itemID, paginationValueObject)
+	if err != nil {
+		log.Printf("failed to get movements of item:%d - pagination:%+v\n", itemID, paginationValueObject)
+		return nil, err
+	}
+
+	movementResps := make([]payload.InventoryMovement, len(movements))
+	for i := range movements {
+		movementResps[i] = converter.ConvertInventoryMovementEntityToPayload(movements[i])
+	}
+
+	return movementResps, nil
+}
+
+// CountMovements count the stock movements of an item
+func (uc InventoryUseCaseImpl) CountMovements(ctx context.Context, itemID valueobject.ItemID) (int64, error) {
+	total, err := uc.inventoryMovementRepository.CountByItem(ctx, itemID)
+	if err != nil {
+		log.Printf("failed to count movements of item:%d\n", itemID)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// createInventoryMovement record a change of the current stock of item,
+// it must be called in the transaction which updates the stock
+func createInventoryMovement(
+	ctx context.Context,
+	movementRepo repository.InventoryMovementRepository,
+	itemID valueobject.ItemID,
+	reason valueobject.MovementReason,
+	delta int64,
+) error {
+	movement := entity.InventoryMovement{
+		ItemID: itemID,
+		Reason: reason,
+		Delta:  delta,
+	}
+	err := movementRepo.Create(ctx, &movement)
+	if err != nil {
+		log.Printf("failed to create inventory movement:%+v\n", movement)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,845 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository/mock"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestInventoryUseCaseImpl_Restock(t *testing.T) {
+	t.Run("#1: Not found item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := InventoryUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.RestockRequest{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 5,
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(entity.Item{}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.Restock(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundItem,
+			Message: "not found item:1",
+			Param:   req.ItemID,
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Restock() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Failed to create movement", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := InventoryUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.RestockRequest{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 5,
+		}
+		wannaErr := errors.New("failed to create movement")
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(entity.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   5,
+			CurrentStockValue: 2,
+		}, nil)
+		mItemRepo.EXPECT().Updates(ctx, gomock.Any(), gomock.Any()).Return(nil)
+		mMovementRepo.EXPECT().Create(ctx, gomock.Any()).Return(wannaErr)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.Restock(ctx, req)
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Restock() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#3: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := InventoryUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.RestockRequest{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 5,
+		}
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   5,
+			CurrentStockValue: 2,
+			Version:           1,
+		}
+		updateValues := map[string]interface{}{
+			"total_stock_value":   uint64(10),
+			"current_stock_value": uint64(7),
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, updateValues).DoAndReturn(
+			func(_ context.Context, item *entity.Item, _ map[string]interface{}) error {
+				item.TotalStockValue = 10
+				item.CurrentStockValue = 7
+				item.Version++
+				return nil
+			},
+		)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonRestock,
+			Delta:  5,
+		}).Return(nil)
+		mLocationStockRepo.EXPECT().ListByItem(ctx, valueobject.ItemID(1)).Return(nil, nil)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.Restock(ctx, req)
+		if err != nil {
+			t.Errorf("uc.Restock() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Item{
+			ID:                   valueobject.ItemID(1),
+			TotalStockValue:      10,
+			CurrentStockValue:    7,
+			UnassignedStockValue: 7,
+			Locations:            []payload.ItemLocationStock{},
+			Version:              2,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#4: Not found location", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationRepo := mock.NewMockLocationRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := InventoryUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationRepository:          mLocationRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		locationID := valueobject.LocationID(3)
+		req := payload.RestockRequest{
+			ItemID:     valueobject.ItemID(1),
+			Quantity:   5,
+			LocationID: &locationID,
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(entity.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   5,
+			CurrentStockValue: 2,
+		}, nil)
+		mLocationRepo.EXPECT().GetByID(ctx, locationID).Return(entity.Location{}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.Restock(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundLocation,
+			Message: "not found location:3",
+			Param:   locationID,
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Restock() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#5: Success at location", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationRepo := mock.NewMockLocationRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := InventoryUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationRepository:          mLocationRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		locationID := valueobject.LocationID(3)
+		req := payload.RestockRequest{
+			ItemID:     valueobject.ItemID(1),
+			Quantity:   5,
+			LocationID: &locationID,
+		}
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   5,
+			CurrentStockValue: 2,
+			LocatedStockValue: 1,
+			Version:           1,
+		}
+		stock := entity.LocationStock{
+			ID:                valueobject.LocationStockID(1),
+			ItemID:            valueobject.ItemID(1),
+			LocationID:        locationID,
+			CurrentStockValue: 1,
+		}
+		updateValues := map[string]interface{}{
+			"total_stock_value":   uint64(10),
+			"current_stock_value": uint64(7),
+			"located_stock_value": uint64(6),
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mLocationRepo.EXPECT().GetByID(ctx, locationID).Return(entity.Location{ID: locationID, Code: "HN-01"}, nil)
+		mLocationStockRepo.EXPECT().GetForUpdate(ctx, valueobject.ItemID(1), locationID).Return(stock, nil)
+		mLocationStockRepo.EXPECT().Updates(ctx, &stock, map[string]interface{}{
+			"current_stock_value": uint64(6),
+		}).Return(nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, updateValues).DoAndReturn(
+			func(_ context.Context, item *entity.Item, _ map[string]interface{}) error {
+				item.TotalStockValue = 10
+				item.CurrentStockValue = 7
+				item.LocatedStockValue = 6
+				item.Version++
+				return nil
+			},
+		)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonRestock,
+			Delta:  5,
+		}).Return(nil)
+		mLocationStockRepo.EXPECT().ListByItem(ctx, valueobject.ItemID(1)).Return([]entity.LocationStock{
+			{
+				ID:                valueobject.LocationStockID(1),
+				ItemID:            valueobject.ItemID(1),
+				LocationID:        locationID,
+				CurrentStockValue: 6,
+			},
+		}, nil)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.Restock(ctx, req)
+		if err != nil {
+			t.Errorf("uc.Restock() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Item{
+			ID:                   valueobject.ItemID(1),
+			TotalStockValue:      10,
+			CurrentStockValue:    7,
+			LocatedStockValue:    6,
+			UnassignedStockValue: 1,
+			Locations: []payload.ItemLocationStock{
+				{
+					LocationID:        locationID,
+					CurrentStockValue: 6,
+				},
+			},
+			Version: 2,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#6: Fulfill backorders in FIFO order", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := InventoryUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.RestockRequest{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 4,
+		}
+		item := entity.Item{
+			ID:                    valueobject.ItemID(1),
+			TotalStockValue:       5,
+			BackorderedStockValue: 6,
+			Backorderable:         true,
+			Version:               1,
+		}
+		restockedItem := item
+		restockedItem.TotalStockValue = 9
+		restockedItem.CurrentStockValue = 4
+		restockedItem.Version = 2
+		// the second purchase cannot be fulfilled so the third one keeps waiting even if it fits the stock
+		purchases := []entity.Purchase{
+			{ID: valueobject.PurchaseID(1), ItemID: item.ID, Quantity: 2, Status: valueobject.PurchaseStatusBackordered},
+			{ID: valueobject.PurchaseID(2), ItemID: item.ID, Quantity: 3, Status: valueobject.PurchaseStatusBackordered},
+			{ID: valueobject.PurchaseID(3), ItemID: item.ID, Quantity: 1, Status: valueobject.PurchaseStatusBackordered},
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, map[string]interface{}{
+			"total_stock_value":   uint64(9),
+			"current_stock_value": uint64(4),
+		}).DoAndReturn(
+			func(_ context.Context, item *entity.Item, _ map[string]interface{}) error {
+				item.TotalStockValue = 9
+				item.CurrentStockValue = 4
+				item.Version++
+				return nil
+			},
+		)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonRestock,
+			Delta:  4,
+		}).Return(nil)
+		mPurchaseRepo.EXPECT().ListBackorderedByItemForUpdate(ctx, item.ID).Return(purchases, nil)
+		mPurchaseRepo.EXPECT().Updates(ctx, &purchases[0], gomock.Any()).DoAndReturn(
+			func(_ context.Context, purchase *entity.Purchase, values map[string]interface{}) error {
+				if values["status"] != valueobject.PurchaseStatusFulfilled {
+					t.Errorf("purchase:%d is updated to status:%v - want:%s", purchase.ID, values["status"], valueobject.PurchaseStatusFulfilled)
+				}
+				return nil
+			},
+		)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonPurchase,
+			Delta:  -2,
+		}).Return(nil)
+		mItemRepo.EXPECT().Updates(ctx, &restockedItem, map[string]interface{}{
+			"current_stock_value":     uint64(2),
+			"located_stock_value":     uint64(0),
+			"backordered_stock_value": uint64(4),
+		}).DoAndReturn(
+			func(_ context.Context, item *entity.Item, _ map[string]interface{}) error {
+				item.CurrentStockValue = 2
+				item.BackorderedStockValue = 4
+				item.Version++
+				return nil
+			},
+		)
+		mLocationStockRepo.EXPECT().ListByItem(ctx, valueobject.ItemID(1)).Return(nil, nil)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.Restock(ctx, req)
+		if err != nil {
+			t.Errorf("uc.Restock() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Item{
+			ID:                    valueobject.ItemID(1),
+			TotalStockValue:       9,
+			CurrentStockValue:     2,
+			UnassignedStockValue:  2,
+			BackorderedStockValue: 4,
+			Backorderable:         true,
+			Locations:             []payload.ItemLocationStock{},
+			Version:               3,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestInventoryUseCaseImpl_Adjust(t *testing.T) {
+	t.Run("#1: Adjustment exceeds current stock", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := InventoryUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.AdjustmentRequest{
+			ItemID:     valueobject.ItemID(1),
+			Delta:      -3,
+			ReasonCode: valueobject.AdjustmentReasonCodeDamaged,
+			Actor:      "alice",
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(entity.Item{
+			ID:                 valueobject.ItemID(1),
+			TotalStockValue:    5,
+			CurrentStockValue:  2,
+			ReservedStockValue: 1,
+		}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.Adjust(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeAdjustmentBelowZero,
+			Message: "the adjustment exceeds the current stock - item:1 - current quantity:2 - delta:-3",
+			Param:   int64(-3),
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Adjust() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Version mismatch", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := InventoryUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		expectedVersion := uint64(1)
+		req := payload.AdjustmentRequest{
+			ItemID:          valueobject.ItemID(1),
+			Delta:           1,
+			ReasonCode:      valueobject.AdjustmentReasonCodeFound,
+			Actor:           "alice",
+			ExpectedVersion: &expectedVersion,
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(entity.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   5,
+			CurrentStockValue: 2,
+			Version:           2,
+		}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.Adjust(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeItemVersionMismatch,
+			Message: "the item has been changed - current version:2 - expected version:1",
+			Param:   uint64(1),
+			Type:    payload.ErrorTypePreconditionFailed,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Adjust() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#3: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := InventoryUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.AdjustmentRequest{
+			ItemID:     valueobject.ItemID(1),
+			Delta:      -2,
+			ReasonCode: valueobject.AdjustmentReasonCodeDamaged,
+			Actor:      "alice",
+			Note:       "broken in the warehouse",
+		}
+		item := entity.Item{
+			ID:                 valueobject.ItemID(1),
+			TotalStockValue:    5,
+			CurrentStockValue:  2,
+			ReservedStockValue: 1,
+			Version:            1,
+		}
+		updateValues := map[string]interface{}{
+			"total_stock_value":   uint64(3),
+			"current_stock_value": uint64(0),
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, updateValues).DoAndReturn(
+			func(_ context.Context, item *entity.Item, _ map[string]interface{}) error {
+				item.TotalStockValue = 3
+				item.CurrentStockValue = 0
+				item.Version++
+				return nil
+			},
+		)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID:     valueobject.ItemID(1),
+			Reason:     valueobject.MovementReasonAdjustment,
+			Delta:      -2,
+			ReasonCode: valueobject.AdjustmentReasonCodeDamaged,
+			Actor:      "alice",
+			Note:       "broken in the warehouse",
+		}).Return(nil)
+		mLocationStockRepo.EXPECT().ListByItem(ctx, valueobject.ItemID(1)).Return(nil, nil)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.Adjust(ctx, req)
+		if err != nil {
+			t.Errorf("uc.Adjust() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Item{
+			ID:                 valueobject.ItemID(1),
+			TotalStockValue:    3,
+			CurrentStockValue:  0,
+			ReservedStockValue: 1,
+			Locations:          []payload.ItemLocationStock{},
+			Version:            2,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#4: Take from locations by allocation strategy", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := InventoryUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+			allocationStrategy:          valueobject.AllocationStrategyMostStock,
+		}
+		ctx := context.Background()
+		req := payload.AdjustmentRequest{
+			ItemID:     valueobject.ItemID(1),
+			Delta:      -4,
+			ReasonCode: valueobject.AdjustmentReasonCodeLost,
+			Actor:      "alice",
+		}
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   6,
+			CurrentStockValue: 6,
+			LocatedStockValue: 5,
+			Version:           1,
+		}
+		// 1 unassigned is taken first, then 3 from the location which has the most stock
+		stocks := []entity.LocationStock{
+			{ID: valueobject.LocationStockID(1), ItemID: valueobject.ItemID(1), LocationID: valueobject.LocationID(1), CurrentStockValue: 1},
+			{ID: valueobject.LocationStockID(2), ItemID: valueobject.ItemID(1), LocationID: valueobject.LocationID(2), CurrentStockValue: 4},
+		}
+		updateValues := map[string]interface{}{
+			"total_stock_value":   uint64(2),
+			"current_stock_value": uint64(2),
+			"located_stock_value": uint64(2),
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mLocationStockRepo.EXPECT().ListByItemForUpdate(ctx, valueobject.ItemID(1)).Return(stocks, nil)
+		mLocationStockRepo.EXPECT().Updates(ctx, &stocks[1], map[string]interface{}{
+			"current_stock_value": uint64(1),
+		}).Return(nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, updateValues).DoAndReturn(
+			func(_ context.Context, item *entity.Item, _ map[string]interface{}) error {
+				item.TotalStockValue = 2
+				item.CurrentStockValue = 2
+				item.LocatedStockValue = 2
+				item.Version++
+				return nil
+			},
+		)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID:     valueobject.ItemID(1),
+			Reason:     valueobject.MovementReasonAdjustment,
+			Delta:      -4,
+			ReasonCode: valueobject.AdjustmentReasonCodeLost,
+			Actor:      "alice",
+		}).Return(nil)
+		mLocationStockRepo.EXPECT().ListByItem(ctx, valueobject.ItemID(1)).Return([]entity.LocationStock{
+			{ID: valueobject.LocationStockID(1), ItemID: valueobject.ItemID(1), LocationID: valueobject.LocationID(1), CurrentStockValue: 1},
+			{ID: valueobject.LocationStockID(2), ItemID: valueobject.ItemID(1), LocationID: valueobject.LocationID(2), CurrentStockValue: 1},
+		}, nil)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.Adjust(ctx, req)
+		if err != nil {
+			t.Errorf("uc.Adjust() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   2,
+			CurrentStockValue: 2,
+			LocatedStockValue: 2,
+			Locations: []payload.ItemLocationStock{
+				{LocationID: valueobject.LocationID(1), CurrentStockValue: 1},
+				{LocationID: valueobject.LocationID(2), CurrentStockValue: 1},
+			},
+			Version: 2,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestInventoryUseCaseImpl_ListMovements(t *testing.T) {
+	t.Run("#1: Not found item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+
+		uc := InventoryUseCaseImpl{
+			itemRepository: mItemRepo,
+		}
+		ctx := context.Background()
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{}, nil)
+
+		_, err := uc.ListMovements(ctx, valueobject.ItemID(1), payload.PaginationRequest{})
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundItem,
+			Message: "not found item:1",
+			Param:   valueobject.ItemID(1),
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.ListMovements() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+
+		uc := InventoryUseCaseImpl{
+			itemRepository:              mItemRepo,
+			inventoryMovementRepository: mMovementRepo,
+		}
+		ctx := context.Background()
+		movedAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{ID: valueobject.ItemID(1)}, nil)
+		mMovementRepo.EXPECT().ListByItem(ctx, valueobject.ItemID(1), valueobject.PaginationRequest{Page: 1, Limit: 5}).
+			Return([]entity.InventoryMovement{
+				{
+					ID:        valueobject.InventoryMovementID(2),
+					CreatedAt: movedAt,
+					ItemID:    valueobject.ItemID(1),
+					Reason:    valueobject.MovementReasonPurchase,
+					Delta:     -2,
+				},
+			}, nil)
+
+		got, err := uc.ListMovements(ctx, valueobject.ItemID(1), payload.PaginationRequest{Page: 1, Limit: 5})
+		if err != nil {
+			t.Errorf("uc.ListMovements() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := []payload.InventoryMovement{
+			{
+				ID:      valueobject.InventoryMovementID(2),
+				ItemID:  valueobject.ItemID(1),
+				Reason:  valueobject.MovementReasonPurchase,
+				Delta:   -2,
+				MovedAt: movedAt,
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestInventoryUseCaseImpl_CountMovements(t *testing.T) {
+	t.Run("#1: Failed to count movements", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+
+		uc := InventoryUseCaseImpl{
+			inventoryMovementRepository: mMovementRepo,
+		}
+		ctx := context.Background()
+		wannaErr := errors.New("failed to count")
+		mMovementRepo.EXPECT().CountByItem(ctx, valueobject.ItemID(1)).Return(int64(0), wannaErr)
+
+		_, err := uc.CountMovements(ctx, valueobject.ItemID(1))
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.CountMovements() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+
+		uc := InventoryUseCaseImpl{
+			inventoryMovementRepository: mMovementRepo,
+		}
+		ctx := context.Background()
+		mMovementRepo.EXPECT().CountByItem(ctx, valueobject.ItemID(1)).Return(int64(7), nil)
+
+		got, err := uc.CountMovements(ctx, valueobject.ItemID(1))
+		if err != nil {
+			t.Errorf("uc.CountMovements() return an error:%v - want:nil", err)
+			return
+		}
+
+		if got != 7 {
+			t.Errorf("uc.CountMovements() return:%d - want:7", got)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package interactor
+
+import (
+	"context"
+	"log"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase"
+	"github.com/tuanna7593/gosample/app/usecase/converter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// ItemAttributeUseCaseImpl implementation of ItemAttribute usecase
+type ItemAttributeUseCaseImpl struct {
+	itemRepository          repository.ItemRepository
+	itemTagRepository       repository.ItemTagRepository
+	itemAttributeRepository repository.ItemAttributeRepository
+}
+
+// NewItemAttributeUseCaseInteractor create new instance of ItemAttribute interactor
+func NewItemAttributeUseCaseInteractor(
+	itemRepo repository.ItemRepository,
+	itemTagRepo repository.ItemTagRepository,
+	itemAttributeRepo repository.ItemAttributeRepository,
+) usecase.ItemAttributeUseCase {
+	return &ItemAttributeUseCaseImpl{
+		itemRepository:          itemRepo,
+		itemTagRepository:       itemTagRepo,
+		itemAttributeRepository: itemAttributeRepo,
+	}
+}
+
+// AddTag add a tag to an item, adding a tag which the item has does nothing
+func (uc ItemAttributeUseCaseImpl) AddTag(ctx context.Context, req payload.ItemTagRequest) error {
+	err := checkItemExists(ctx, uc.itemRepository, req.ItemID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.itemTagRepository.Add(ctx, &entity.ItemTag{
+		ItemID: req.ItemID,
+		Tag:    req.Tag,
+	})
+	if err != nil {
+		log.Printf("failed to add tag:%s to item:%d\n", req.Tag, req.ItemID)
+		return err
+	}
+
+	return nil
+}
+
+// RemoveTag remove a tag from an item, removing a tag which the item doesn't have does nothing
+func (uc ItemAttributeUseCaseImpl) RemoveTag(ctx context.Context, req payload.ItemTagRequest) error {
+	err := checkItemExists(ctx, uc.itemRepository, req.ItemID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.itemTagRepository.Remove(ctx, req.ItemID, req.Tag)
+	if err != nil {
+		log.Printf("failed to remove tag:%s from item:%d\n", req.Tag, req.ItemID)
+		return err
+	}
+
+	return nil
+}
+
+// ListTags get the tags of an item in the alphabetical order
+func (uc ItemAttributeUseCaseImpl) ListTags(ctx context.Context, itemID valueobject.ItemID) ([]string, error) {
+	err := checkItemExists(ctx, uc.itemRepository, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := uc.itemTagRepository.ListByItem(ctx, itemID)
+	if err != nil {
+		log.Printf("failed to get tags of item:%d\n", itemID)
+		return nil, err
+	}
+
+	return converter.ConvertItemTagEntitiesToPayload(tags), nil
+}
+
+func (uc ItemAttributeUseCaseImpl) SetAttribute(ctx context.Context, req payload.ItemAttributeRequest) (payload.ItemAttribute, error) {
+	err := checkItemExists(ctx, uc.itemRepository, req.ItemID)
+	if err != nil {
+		return payload.ItemAttribute{}, err
+	}
+
+	attribute := entity.ItemAttribute{
+		ItemID: req.ItemID,
+		Key:    req.Key,
+		Value:  req.Value,
+	}
+	err = uc.itemAttributeRepository.Set(ctx, &attribute)
+	if err != nil {
+		log.Printf("failed to set attribute:%+v\n", attribute)
+		return payload.ItemAttribute{}, err
+	}
+
+	return converter.ConvertItemAttributeEntityToPayload(attribute), nil
+}
+
+// RemoveAttribute remove an attribute key from an item, removing a key which the item doesn't have does nothing
+func (uc ItemAttributeUseCaseImpl) RemoveAttribute(ctx context.Context, req payload.ItemAttributeRequest) error {
+	err := checkItemExists(ctx, uc.itemRepository, req.ItemID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.itemAttributeRepository.Remove(ctx, req.ItemID, req.Key)
+	if err != nil {
+		log.Printf("failed to remove attribute:%s from item:%d\n", req.Key, req.ItemID)
+		return err
+	}
+
+	return nil
+}
+
+// ListAttributes get the attributes of an item ordered by key
+func (uc ItemAttributeUseCaseImpl) ListAttributes(ctx context.Context, itemID valueobject.ItemID) ([]payload.ItemAttribute, error) {
+	err := checkItemExists(ctx, uc.itemRepository, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes, err := uc.itemAttributeRepository.ListByItem(ctx, itemID)
+	if err != nil {
+		log.Printf("failed to get attributes of item:%d\n", itemID)
+		return nil, err
+	}
+
+	return converter.ConvertItemAttributeEntitiesToPayload(attributes), nil
+}
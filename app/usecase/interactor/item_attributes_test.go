@@ -0,0 +1,158 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository/mock"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestItemAttributeUseCaseImpl_AddTag(t *testing.T) {
+	t.Run("#1: Not found item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+
+		uc := ItemAttributeUseCaseImpl{
+			itemRepository: mItemRepo,
+		}
+		ctx := context.Background()
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{}, nil)
+
+		err := uc.AddTag(ctx, payload.ItemTagRequest{ItemID: valueobject.ItemID(1), Tag: "sale"})
+		wannaErr := newNotFoundItemError(valueobject.ItemID(1))
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.AddTag() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mItemTagRepo := mock.NewMockItemTagRepository(mockCtrl)
+
+		uc := ItemAttributeUseCaseImpl{
+			itemRepository:    mItemRepo,
+			itemTagRepository: mItemTagRepo,
+		}
+		ctx := context.Background()
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{ID: valueobject.ItemID(1)}, nil)
+		mItemTagRepo.EXPECT().Add(ctx, &entity.ItemTag{ItemID: valueobject.ItemID(1), Tag: "sale"}).Return(nil)
+
+		err := uc.AddTag(ctx, payload.ItemTagRequest{ItemID: valueobject.ItemID(1), Tag: "sale"})
+		if err != nil {
+			t.Errorf("uc.AddTag() return an error:%v - want:nil", err)
+		}
+	})
+}
+
+func TestItemAttributeUseCaseImpl_SetAttribute(t *testing.T) {
+	t.Run("#1: Failed to set attribute", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mItemAttributeRepo := mock.NewMockItemAttributeRepository(mockCtrl)
+
+		uc := ItemAttributeUseCaseImpl{
+			itemRepository:          mItemRepo,
+			itemAttributeRepository: mItemAttributeRepo,
+		}
+		ctx := context.Background()
+		wannaErr := errors.New("failed to set attribute")
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{ID: valueobject.ItemID(1)}, nil)
+		mItemAttributeRepo.EXPECT().Set(ctx, gomock.Any()).Return(wannaErr)
+
+		_, err := uc.SetAttribute(ctx, payload.ItemAttributeRequest{
+			ItemID: valueobject.ItemID(1),
+			Key:    "color",
+			Value:  "red",
+		})
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.SetAttribute() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mItemAttributeRepo := mock.NewMockItemAttributeRepository(mockCtrl)
+
+		uc := ItemAttributeUseCaseImpl{
+			itemRepository:          mItemRepo,
+			itemAttributeRepository: mItemAttributeRepo,
+		}
+		ctx := context.Background()
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{ID: valueobject.ItemID(1)}, nil)
+		mItemAttributeRepo.EXPECT().Set(ctx, &entity.ItemAttribute{
+			ItemID: valueobject.ItemID(1),
+			Key:    "color",
+			Value:  "red",
+		}).Return(nil)
+
+		got, err := uc.SetAttribute(ctx, payload.ItemAttributeRequest{
+			ItemID: valueobject.ItemID(1),
+			Key:    "color",
+			Value:  "red",
+		})
+		if err != nil {
+			t.Errorf("uc.SetAttribute() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.ItemAttribute{
+			Key:   "color",
+			Value: "red",
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestItemAttributeUseCaseImpl_ListAttributes(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mItemAttributeRepo := mock.NewMockItemAttributeRepository(mockCtrl)
+
+		uc := ItemAttributeUseCaseImpl{
+			itemRepository:          mItemRepo,
+			itemAttributeRepository: mItemAttributeRepo,
+		}
+		ctx := context.Background()
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{ID: valueobject.ItemID(1)}, nil)
+		mItemAttributeRepo.EXPECT().ListByItem(ctx, valueobject.ItemID(1)).Return([]entity.ItemAttribute{
+			{ItemID: valueobject.ItemID(1), Key: "color", Value: "red"},
+			{ItemID: valueobject.ItemID(1), Key: "size", Value: "M"},
+		}, nil)
+
+		got, err := uc.ListAttributes(ctx, valueobject.ItemID(1))
+		if err != nil {
+			t.Errorf("uc.ListAttributes() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := []payload.ItemAttribute{
+			{Key: "color", Value: "red"},
+			{Key: "size", Value: "M"},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
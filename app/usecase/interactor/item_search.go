@@ -0,0 +1,92 @@
+package interactor
+
+import (
+	"context"
+	"html"
+	"log"
+	"strings"
+
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase"
+	"github.com/tuanna7593/gosample/app/usecase/converter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// ItemSearchUseCaseImpl implementation of ItemSearch usecase
+type ItemSearchUseCaseImpl struct {
+	itemSearchRepository repository.ItemSearchRepository
+}
+
+// NewItemSearchUseCaseInteractor create new instance of ItemSearch interactor
+func NewItemSearchUseCaseInteractor(itemSearchRepo repository.ItemSearchRepository) usecase.ItemSearchUseCase {
+	return &ItemSearchUseCaseImpl{
+		itemSearchRepository: itemSearchRepo,
+	}
+}
+
+// Search get the items which have a word starting with every word of the query,
+// the matched words are highlighted in the name and description of items
+func (uc ItemSearchUseCaseImpl) Search(
+	ctx context.Context,
+	req payload.ItemSearchRequest,
+	pagination payload.PaginationRequest,
+) ([]payload.ItemSearchHit, error) {
+	query := valueobject.NewItemSearchQuery(req.Query)
+	if len(query.Terms) == 0 {
+		return []payload.ItemSearchHit{}, nil
+	}
+
+	paginationValueObject := converter.ConvertPaginationPayloadToValueObject(pagination)
+	hits, err := uc.itemSearchRepository.Search(ctx, query, paginationValueObject)
+	if err != nil {
+		log.Printf("failed to search items - query:%+v - pagination:%+v", query, paginationValueObject)
+		return nil, err
+	}
+
+	hitResps := make([]payload.ItemSearchHit, len(hits))
+	for i := range hits {
+		hitResps[i] = payload.ItemSearchHit{
+			Item:  converter.ConvertItemEntityToPayload(hits[i].Item),
+			Score: hits[i].Score,
+			Highlight: payload.ItemHighlight{
+				Name:        highlight(hits[i].Item.Name, query.Terms),
+				Description: highlight(hits[i].Item.Description, query.Terms),
+			},
+		}
+	}
+
+	return hitResps, nil
+}
+
+// highlight escape text for HTML and wrap the words starting with any of terms in <em>
+func highlight(text string, terms []string) string {
+	var b strings.Builder
+	runes := []rune(text)
+	for start := 0; start < len(runes); {
+		end := start + 1
+		isWord := valueobject.IsSearchWordRune(runes[start])
+		for end < len(runes) && valueobject.IsSearchWordRune(runes[end]) == isWord {
+			end++
+		}
+
+		segment := html.EscapeString(string(runes[start:end]))
+		if isWord && matchesAnyPrefix(strings.ToLower(string(runes[start:end])), terms) {
+			segment = "<em>" + segment + "</em>"
+		}
+		b.WriteString(segment)
+		start = end
+	}
+
+	return b.String()
+}
+
+func matchesAnyPrefix(word string, terms []string) bool {
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,130 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/shopspring/decimal"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository/mock"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/external/persistence/memory"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestItemSearchUseCaseImpl_Search(t *testing.T) {
+	t.Run("#1: Failed to search items", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemSearchRepo := mock.NewMockItemSearchRepository(mockCtrl)
+
+		uc := ItemSearchUseCaseImpl{
+			itemSearchRepository: mItemSearchRepo,
+		}
+		ctx := context.Background()
+		wannaErr := errors.New("failed to search")
+		mItemSearchRepo.EXPECT().Search(
+			ctx,
+			valueobject.ItemSearchQuery{Terms: []string{"shirt"}},
+			valueobject.PaginationRequest{Page: 1, Limit: 10},
+		).Return(nil, wannaErr)
+
+		_, err := uc.Search(ctx, payload.ItemSearchRequest{Query: "Shirt"}, payload.PaginationRequest{Page: 1, Limit: 10})
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Search() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: The query has no word", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemSearchRepo := mock.NewMockItemSearchRepository(mockCtrl)
+
+		uc := ItemSearchUseCaseImpl{
+			itemSearchRepository: mItemSearchRepo,
+		}
+		got, err := uc.Search(context.Background(), payload.ItemSearchRequest{Query: "-- *"}, payload.PaginationRequest{})
+		if err != nil {
+			t.Errorf("uc.Search() return an error:%v - want:nil", err)
+			return
+		}
+
+		if len(got) != 0 {
+			t.Errorf("uc.Search() return %d items - want none", len(got))
+		}
+	})
+
+	t.Run("#3: Rank and highlight the matched items", func(t *testing.T) {
+		t.Parallel()
+		idx := memory.NewItemSearchIndex()
+		idx.Index(entity.Item{
+			ID:           valueobject.ItemID(1),
+			SKU:          "TS-001",
+			Name:         "T-shirt",
+			Description:  "Cotton <b>shirt</b>",
+			SellingPrice: decimal.NewFromFloat(1.55),
+		})
+		idx.Index(entity.Item{
+			ID:           valueobject.ItemID(2),
+			SKU:          "SH-001",
+			Name:         "Shirt",
+			Description:  "Linen",
+			SellingPrice: decimal.NewFromFloat(2.5),
+		})
+		idx.Index(entity.Item{
+			ID:           valueobject.ItemID(3),
+			SKU:          "SK-001",
+			Name:         "Socks",
+			SellingPrice: decimal.NewFromFloat(0.5),
+		})
+
+		uc := ItemSearchUseCaseImpl{
+			itemSearchRepository: idx,
+		}
+		got, err := uc.Search(context.Background(), payload.ItemSearchRequest{Query: "shi"}, payload.PaginationRequest{Page: 1, Limit: 10})
+		if err != nil {
+			t.Errorf("uc.Search() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := []payload.ItemSearchHit{
+			{
+				Item: payload.Item{
+					ID:           valueobject.ItemID(1),
+					SKU:          "TS-001",
+					Name:         "T-shirt",
+					Description:  "Cotton <b>shirt</b>",
+					SellingPrice: decimal.NewFromFloat(1.55),
+				},
+				Score: 3,
+				Highlight: payload.ItemHighlight{
+					Name:        "T-<em>shirt</em>",
+					Description: "Cotton &lt;b&gt;<em>shirt</em>&lt;/b&gt;",
+				},
+			},
+			{
+				Item: payload.Item{
+					ID:           valueobject.ItemID(2),
+					SKU:          "SH-001",
+					Name:         "Shirt",
+					Description:  "Linen",
+					SellingPrice: decimal.NewFromFloat(2.5),
+				},
+				Score: 2,
+				Highlight: payload.ItemHighlight{
+					Name:        "<em>Shirt</em>",
+					Description: "Linen",
+				},
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
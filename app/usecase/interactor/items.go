@@ -2,12 +2,14 @@ package interactor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"reflect"
 
 	"github.com/tuanna7593/gosample/app/domain/entity"
 	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
 	"github.com/tuanna7593/gosample/app/usecase"
 	"github.com/tuanna7593/gosample/app/usecase/converter"
 	"github.com/tuanna7593/gosample/app/usecase/payload"
@@ -15,54 +17,438 @@ import (
 
 // ItemUseCaseImpl implementation of Item usecase
 type ItemUseCaseImpl struct {
-	itemRepository     repository.ItemRepository
-	purchaseRepository repository.PurchaseRepository
-	txManager          repository.TransactionManager
+	itemRepository              repository.ItemRepository
+	purchaseRepository          repository.PurchaseRepository
+	inventoryMovementRepository repository.InventoryMovementRepository
+	locationStockRepository     repository.LocationStockRepository
+	locationRepository          repository.LocationRepository
+	categoryRepository          repository.CategoryRepository
+	productRepository           repository.ProductRepository
+	txManager                   repository.TransactionManager
+	allocationStrategy          valueobject.AllocationStrategy
+	stockAlertNotifier          repository.StockAlertNotifier
 }
 
 // NewItemUseCaseInteractor create new instance of Item interactor
 func NewItemUseCaseInteractor(
 	itemRepo repository.ItemRepository,
 	purchaseRepository repository.PurchaseRepository,
+	movementRepo repository.InventoryMovementRepository,
+	locationStockRepo repository.LocationStockRepository,
+	locationRepo repository.LocationRepository,
+	categoryRepo repository.CategoryRepository,
+	productRepo repository.ProductRepository,
 	txManager repository.TransactionManager,
+	allocationStrategy valueobject.AllocationStrategy,
+	stockAlertNotifier repository.StockAlertNotifier,
 ) usecase.ItemUseCase {
 	return &ItemUseCaseImpl{
-		itemRepository:     itemRepo,
-		purchaseRepository: purchaseRepository,
-		txManager:          txManager,
+		itemRepository:              itemRepo,
+		purchaseRepository:          purchaseRepository,
+		inventoryMovementRepository: movementRepo,
+		locationStockRepository:     locationStockRepo,
+		locationRepository:          locationRepo,
+		categoryRepository:          categoryRepo,
+		productRepository:           productRepo,
+		txManager:                   txManager,
+		allocationStrategy:          allocationStrategy,
+		stockAlertNotifier:          stockAlertNotifier,
 	}
 }
 
-// Create create a new item
+// Create create a new item, the sku of items is unique
 func (uc ItemUseCaseImpl) Create(ctx context.Context, request payload.CreateItemRequest) (payload.Item, error) {
+	if request.ProductID != nil {
+		product, err := uc.productRepository.GetByID(ctx, *request.ProductID)
+		if err != nil {
+			log.Printf("failed to get product:%d\n", *request.ProductID)
+			return payload.Item{}, err
+		}
+
+		if reflect.DeepEqual(product, entity.Product{}) {
+			return payload.Item{}, newNotFoundProductError(*request.ProductID)
+		}
+	}
+
 	item := converter.ConvertCreateItemRequestToEntity(request)
 
-	err := uc.itemRepository.Create(ctx, &item)
+	// start transaction
+	uc.txManager.Begin()
+
+	// assign tx to repositories
+	uc.itemRepository.AssignTx(uc.txManager)
+	uc.inventoryMovementRepository.AssignTx(uc.txManager)
+
+	var err error
+	defer func() {
+		if err != nil {
+			log.Printf("found error - rollback transaction:%v\n", err)
+			uc.txManager.Rollback()
+		}
+	}()
+
+	existing, err := uc.itemRepository.GetBySKU(ctx, item.SKU)
+	if err != nil {
+		log.Printf("failed to get item by sku:%s\n", item.SKU)
+		return payload.Item{}, err
+	}
+
+	if !reflect.DeepEqual(existing, entity.Item{}) {
+		err = newSKUExistsError(item.SKU)
+		return payload.Item{}, err
+	}
+
+	err = uc.itemRepository.Create(ctx, &item)
+	if err != nil {
+		// the sku has been used by a concurrent request since it was checked
+		if errors.Is(err, repository.ErrSKUExists) {
+			err = newSKUExistsError(item.SKU)
+		}
+		return payload.Item{}, err
+	}
+
+	err = createInventoryMovement(
+		ctx, uc.inventoryMovementRepository,
+		item.ID, valueobject.MovementReasonCreate, int64(item.CurrentStockValue),
+	)
 	if err != nil {
 		return payload.Item{}, err
 	}
 
+	// commit transaction
+	errCommit := uc.txManager.Commit()
+	if errCommit != nil {
+		log.Printf("failed to commit transaction:%+v\n", errCommit)
+		return payload.Item{}, errCommit
+	}
+
 	return converter.ConvertItemEntityToPayload(item), nil
 }
 
-// List get list item
-func (uc ItemUseCaseImpl) List(ctx context.Context, pagination payload.PaginationRequest) ([]payload.Item, error) {
+// List get the items matched the filter
+func (uc ItemUseCaseImpl) List(
+	ctx context.Context,
+	filter payload.ItemFilter,
+	pagination payload.PaginationRequest,
+) ([]payload.Item, error) {
+	filterValueObject, err := uc.convertItemFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
 	paginationValueObject := converter.ConvertPaginationPayloadToValueObject(pagination)
-	items, err := uc.itemRepository.List(ctx, paginationValueObject)
+	items, err := uc.itemRepository.List(ctx, filterValueObject, paginationValueObject)
 	if err != nil {
-		log.Printf("failed to get items - pagination:%+v", paginationValueObject)
+		log.Printf("failed to get items - filter:%+v - pagination:%+v", filterValueObject, paginationValueObject)
 		return nil, err
 	}
 
+	if len(items) == 0 {
+		return []payload.Item{}, nil
+	}
+
+	// get the location stocks of all the items at once
+	itemIDs := make([]valueobject.ItemID, len(items))
+	for i := range items {
+		itemIDs[i] = items[i].ID
+	}
+	stocks, err := uc.locationStockRepository.ListByItems(ctx, itemIDs)
+	if err != nil {
+		log.Printf("failed to get location stocks of items:%v\n", itemIDs)
+		return nil, err
+	}
+	stocksByItem := make(map[valueobject.ItemID][]entity.LocationStock, len(items))
+	for _, stock := range stocks {
+		stocksByItem[stock.ItemID] = append(stocksByItem[stock.ItemID], stock)
+	}
+
 	itemResps := make([]payload.Item, len(items))
 	for i := range items {
 		itemResps[i] = converter.ConvertItemEntityToPayload(items[i])
+		itemResps[i].Locations = converter.ConvertLocationStockEntitiesToPayload(stocksByItem[items[i].ID])
 	}
 
 	return itemResps, nil
 }
 
-// List get list item
+// Count count the items matched the filter
+func (uc ItemUseCaseImpl) Count(ctx context.Context, filter payload.ItemFilter) (int64, error) {
+	filterValueObject, err := uc.convertItemFilter(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	total, err := uc.itemRepository.Count(ctx, filterValueObject)
+	if err != nil {
+		log.Printf("failed to count items - filter:%+v", filterValueObject)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// convertItemFilter convert the filter to value object, the category of filter is expanded to its descendants
+func (uc ItemUseCaseImpl) convertItemFilter(ctx context.Context, filter payload.ItemFilter) (valueobject.ItemFilter, error) {
+	filterValueObject := converter.ConvertItemFilterPayloadToValueObject(filter)
+	if filter.CategoryID == 0 {
+		return filterValueObject, nil
+	}
+
+	categories, err := uc.categoryRepository.List(ctx)
+	if err != nil {
+		log.Println("failed to get categories")
+		return valueobject.ItemFilter{}, err
+	}
+
+	categoryIDs := entity.Categories(categories).DescendantIDs(filter.CategoryID)
+	if categoryIDs == nil {
+		return valueobject.ItemFilter{}, newNotFoundCategoryError(filter.CategoryID)
+	}
+	filterValueObject.CategoryIDs = categoryIDs
+
+	return filterValueObject, nil
+}
+
+// GetItem get an item by id
+func (uc ItemUseCaseImpl) GetItem(ctx context.Context, itemID valueobject.ItemID) (payload.Item, error) {
+	item, err := uc.itemRepository.GetByID(ctx, itemID)
+	if err != nil {
+		log.Printf("failed to get item:%d\n", itemID)
+		return payload.Item{}, err
+	}
+
+	if reflect.DeepEqual(item, entity.Item{}) || item.IsArchived() {
+		return payload.Item{}, newNotFoundItemError(itemID)
+	}
+
+	return convertItemWithLocations(ctx, uc.locationStockRepository, item)
+}
+
+// GetItemBySKU get an item by sku
+func (uc ItemUseCaseImpl) GetItemBySKU(ctx context.Context, sku string) (payload.Item, error) {
+	item, err := uc.itemRepository.GetBySKU(ctx, sku)
+	if err != nil {
+		log.Printf("failed to get item by sku:%s\n", sku)
+		return payload.Item{}, err
+	}
+
+	if reflect.DeepEqual(item, entity.Item{}) || item.IsArchived() {
+		msg := fmt.Sprintf("not found item with sku:%s", sku)
+		log.Println(msg)
+		return payload.Item{}, payload.Error{
+			Code:    payload.ErrCodeNotFoundItem,
+			Message: msg,
+			Param:   sku,
+			Type:    payload.ErrorTypeNotFound,
+		}
+	}
+
+	return convertItemWithLocations(ctx, uc.locationStockRepository, item)
+}
+
+// DeleteItem archive an item, the archived item is hidden from the list and cannot be bought.
+// The item which has queued backorders cannot be archived since they could be neither fulfilled nor refunded
+func (uc ItemUseCaseImpl) DeleteItem(ctx context.Context, itemID valueobject.ItemID) error {
+	// start transaction and assign tx to repositories
+	uc.txManager.Begin()
+	uc.itemRepository.AssignTx(uc.txManager)
+
+	var err error
+	defer func() {
+		if err != nil {
+			log.Printf("found error - rollback transaction:%v\n", err)
+			uc.txManager.Rollback()
+		}
+	}()
+
+	// find and lock item until the transaction ends
+	item, err := uc.itemRepository.GetByIDForUpdate(ctx, itemID)
+	if err != nil {
+		log.Printf("failed to get item:%d\n", itemID)
+		return err
+	}
+
+	if reflect.DeepEqual(item, entity.Item{}) || item.IsArchived() {
+		err = newNotFoundItemError(itemID)
+		return err
+	}
+
+	if item.BackordersQueued() {
+		msg := fmt.Sprintf(
+			"the backorders of item are queued - item:%d - backordered quantity:%d",
+			itemID, item.BackorderedStockValue,
+		)
+		log.Println(msg)
+		err = payload.Error{
+			Code:    payload.ErrCodeBackordersQueued,
+			Message: msg,
+			Param:   itemID,
+			Type:    payload.ErrorTypeConflict,
+		}
+		return err
+	}
+
+	err = uc.itemRepository.Archive(ctx, &item)
+	if err != nil {
+		log.Printf("failed to archive item:%d\n", itemID)
+		return err
+	}
+
+	// commit transaction
+	errCommit := uc.txManager.Commit()
+	if errCommit != nil {
+		log.Printf("failed to commit transaction:%+v\n", errCommit)
+		return errCommit
+	}
+
+	return nil
+}
+
+// UpdateItem update the total stock and selling price of an item
+func (uc ItemUseCaseImpl) UpdateItem(ctx context.Context, req payload.UpdateItemRequest) (payload.Item, error) {
+	// start transaction
+	uc.txManager.Begin()
+
+	// assign tx to repositories
+	uc.itemRepository.AssignTx(uc.txManager)
+	uc.purchaseRepository.AssignTx(uc.txManager)
+	uc.inventoryMovementRepository.AssignTx(uc.txManager)
+	uc.locationStockRepository.AssignTx(uc.txManager)
+
+	var err error
+	defer func() {
+		if err != nil {
+			log.Printf("found error - rollback transaction:%v\n", err)
+			uc.txManager.Rollback()
+		}
+	}()
+
+	// find and lock item until the transaction ends
+	item, err := uc.itemRepository.GetByIDForUpdate(ctx, req.ItemID)
+	if err != nil {
+		log.Printf("failed to get item:%d\n", req.ItemID)
+		return payload.Item{}, err
+	}
+
+	if reflect.DeepEqual(item, entity.Item{}) || item.IsArchived() {
+		err = newNotFoundItemError(req.ItemID)
+		return payload.Item{}, err
+	}
+
+	err = checkItemVersion(item, req.ExpectedVersion)
+	if err != nil {
+		return payload.Item{}, err
+	}
+
+	updateValues := map[string]interface{}{}
+	if req.TotalStockValue != nil && *req.TotalStockValue != item.TotalStockValue {
+		// the total stock cannot drop below the quantity which has been sold
+		soldValue := item.TotalStockValue - item.CurrentStockValue
+		if *req.TotalStockValue < soldValue {
+			msg := fmt.Sprintf(
+				"the total stock is less than sold quantity - sold quantity:%d - request total stock:%d",
+				soldValue, *req.TotalStockValue,
+			)
+			log.Println(msg)
+			err = payload.Error{
+				Code:    payload.ErrCodeTotalStockBelowSold,
+				Message: msg,
+				Param:   *req.TotalStockValue,
+				Type:    payload.ErrorTypeBadRequest,
+			}
+			return payload.Item{}, err
+		}
+
+		newCurrentStockValue := *req.TotalStockValue - soldValue
+		updateValues["total_stock_value"] = *req.TotalStockValue
+		updateValues["current_stock_value"] = newCurrentStockValue
+
+		if newCurrentStockValue < item.CurrentStockValue {
+			located, errTake := takeStock(
+				ctx, uc.locationStockRepository, uc.allocationStrategy,
+				item, item.CurrentStockValue-newCurrentStockValue, nil,
+			)
+			if errTake != nil {
+				err = errTake
+				return payload.Item{}, err
+			}
+			if located != item.LocatedStockValue {
+				updateValues["located_stock_value"] = located
+			}
+		}
+	}
+
+	if req.SellingPrice != nil && !req.SellingPrice.Equal(item.SellingPrice) {
+		updateValues["selling_price"] = *req.SellingPrice
+	}
+
+	if req.ReorderThreshold != nil && *req.ReorderThreshold != item.ReorderThreshold {
+		updateValues["reorder_threshold"] = *req.ReorderThreshold
+	}
+
+	if req.Backorderable != nil && *req.Backorderable != item.Backorderable {
+		// the queued backorders would be passed by the purchases which take the current stock
+		if !*req.Backorderable && item.BackordersQueued() {
+			msg := fmt.Sprintf(
+				"the backorders of item are queued - item:%d - backordered quantity:%d",
+				item.ID, item.BackorderedStockValue,
+			)
+			log.Println(msg)
+			err = payload.Error{
+				Code:    payload.ErrCodeBackordersQueued,
+				Message: msg,
+				Param:   *req.Backorderable,
+				Type:    payload.ErrorTypeConflict,
+			}
+			return payload.Item{}, err
+		}
+		updateValues["backorderable"] = *req.Backorderable
+	}
+
+	if len(updateValues) > 0 {
+		currentStockValue := item.CurrentStockValue
+		err = uc.itemRepository.Updates(ctx, &item, updateValues)
+		if err != nil {
+			log.Printf("failed to update item:%d\n", item.ID)
+			return payload.Item{}, err
+		}
+
+		if newCurrentStockValue, ok := updateValues["current_stock_value"].(uint64); ok {
+			err = createInventoryMovement(
+				ctx, uc.inventoryMovementRepository,
+				item.ID, valueobject.MovementReasonAdjustment, int64(newCurrentStockValue)-int64(currentStockValue),
+			)
+			if err != nil {
+				return payload.Item{}, err
+			}
+
+			// the raised stock is taken by the queued backorders first
+			if newCurrentStockValue > currentStockValue {
+				raised := item
+				raised.TotalStockValue = *req.TotalStockValue
+				raised.CurrentStockValue = newCurrentStockValue
+				err = fulfillQueuedBackorders(
+					ctx, uc.itemRepository, uc.purchaseRepository, uc.inventoryMovementRepository,
+					uc.locationStockRepository, uc.allocationStrategy, &item, raised,
+				)
+				if err != nil {
+					return payload.Item{}, err
+				}
+			}
+		}
+	}
+
+	// commit transaction
+	errCommit := uc.txManager.Commit()
+	if errCommit != nil {
+		log.Printf("failed to commit transaction:%+v\n", errCommit)
+		return payload.Item{}, errCommit
+	}
+
+	return converter.ConvertItemEntityToPayload(item), nil
+}
+
+// BuyItem buy an item, the variants of a product are bought by their own item id
 func (uc ItemUseCaseImpl) BuyItem(ctx context.Context, req payload.PurchaseRequest) (payload.Purchase, error) {
 	// start transaction
 	uc.txManager.Begin()
@@ -70,6 +456,8 @@ func (uc ItemUseCaseImpl) BuyItem(ctx context.Context, req payload.PurchaseReque
 	// assign tx to repositories
 	uc.itemRepository.AssignTx(uc.txManager)
 	uc.purchaseRepository.AssignTx(uc.txManager)
+	uc.inventoryMovementRepository.AssignTx(uc.txManager)
+	uc.locationStockRepository.AssignTx(uc.txManager)
 
 	var err error
 	defer func() {
@@ -79,8 +467,8 @@ func (uc ItemUseCaseImpl) BuyItem(ctx context.Context, req payload.PurchaseReque
 		}
 	}()
 
-	// find item
-	item, err := uc.itemRepository.GetByID(ctx, req.ItemID)
+	// find and lock item until the transaction ends
+	item, err := uc.itemRepository.GetByIDForUpdate(ctx, req.ItemID)
 	if err != nil {
 		log.Printf("failed to get item:%d\n", req.ItemID)
 		return payload.Purchase{}, err
@@ -99,6 +487,49 @@ func (uc ItemUseCaseImpl) BuyItem(ctx context.Context, req payload.PurchaseReque
 		return payload.Purchase{}, err
 	}
 
+	if item.IsArchived() {
+		msg := fmt.Sprintf("the item has been archived:%d", req.ItemID)
+		log.Println(msg)
+		err = payload.Error{
+			Code:    payload.ErrCodeArchivedItem,
+			Message: msg,
+			Param:   req.ItemID,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return payload.Purchase{}, err
+	}
+
+	err = checkItemVersion(item, req.ExpectedVersion)
+	if err != nil {
+		return payload.Purchase{}, err
+	}
+
+	// the stock at an unknown location would be read as empty, so the location is checked first
+	if req.LocationID != nil {
+		_, err = getLocation(ctx, uc.locationRepository, *req.LocationID)
+		if err != nil {
+			return payload.Purchase{}, err
+		}
+	}
+
+	// the queued backorders are fulfilled first, so the purchase is also backordered while the queue is not empty
+	if item.Backorderable && (item.CurrentStockValue < req.Quantity || item.BackordersQueued()) {
+		var purchaseEnt entity.Purchase
+		purchaseEnt, err = uc.backorder(ctx, item, req.Quantity)
+		if err != nil {
+			return payload.Purchase{}, err
+		}
+
+		// commit transaction
+		errCommit := uc.txManager.Commit()
+		if errCommit != nil {
+			log.Printf("failed to commit transaction:%+v\n", errCommit)
+			return payload.Purchase{}, errCommit
+		}
+
+		return converter.ConvertPurchaseEntityToPayload(purchaseEnt), nil
+	}
+
 	// check the current stock value
 	if item.CurrentStockValue < req.Quantity {
 		msg := fmt.Sprintf(
@@ -115,9 +546,20 @@ func (uc ItemUseCaseImpl) BuyItem(ctx context.Context, req payload.PurchaseReque
 		return payload.Purchase{}, err
 	}
 
+	// take the quantity from the requested location or by the allocation strategy
+	located, err := takeStock(ctx, uc.locationStockRepository, uc.allocationStrategy, item, req.Quantity, req.LocationID)
+	if err != nil {
+		return payload.Purchase{}, err
+	}
+
 	// update the stock value of item
+	currentStockValue := item.CurrentStockValue - req.Quantity
+	alerts := collectLowStockAlert(nil, item, currentStockValue)
 	updateValues := map[string]interface{}{
-		"current_stock_value": item.CurrentStockValue - req.Quantity,
+		"current_stock_value": currentStockValue,
+	}
+	if located != item.LocatedStockValue {
+		updateValues["located_stock_value"] = located
 	}
 	err = uc.itemRepository.Updates(ctx, &item, updateValues)
 	if err != nil {
@@ -125,10 +567,19 @@ func (uc ItemUseCaseImpl) BuyItem(ctx context.Context, req payload.PurchaseReque
 		return payload.Purchase{}, err
 	}
 
+	err = createInventoryMovement(
+		ctx, uc.inventoryMovementRepository,
+		item.ID, valueobject.MovementReasonPurchase, -int64(req.Quantity),
+	)
+	if err != nil {
+		return payload.Purchase{}, err
+	}
+
 	// create purchase record
 	purchaseEnt := entity.Purchase{
 		ItemID:   req.ItemID,
 		Quantity: req.Quantity,
+		Status:   valueobject.PurchaseStatusFulfilled,
 	}
 	err = uc.purchaseRepository.Create(ctx, &purchaseEnt)
 	if err != nil {
@@ -143,5 +594,106 @@ func (uc ItemUseCaseImpl) BuyItem(ctx context.Context, req payload.PurchaseReque
 		return payload.Purchase{}, errCommit
 	}
 
+	notifyLowStock(ctx, uc.stockAlertNotifier, alerts)
+
 	return converter.ConvertPurchaseEntityToPayload(purchaseEnt), nil
 }
+
+// backorder create a backordered purchase of item, the stock is not taken until the item is restocked
+func (uc ItemUseCaseImpl) backorder(ctx context.Context, item entity.Item, quantity uint64) (entity.Purchase, error) {
+	err := uc.itemRepository.Updates(ctx, &item, map[string]interface{}{
+		"backordered_stock_value": item.BackorderedStockValue + quantity,
+	})
+	if err != nil {
+		log.Printf("failed to update backordered stock of item:%d\n", item.ID)
+		return entity.Purchase{}, err
+	}
+
+	purchaseEnt := entity.Purchase{
+		ItemID:   item.ID,
+		Quantity: quantity,
+		Status:   valueobject.PurchaseStatusBackordered,
+	}
+	err = uc.purchaseRepository.Create(ctx, &purchaseEnt)
+	if err != nil {
+		log.Printf("failed to create purchase:%+v\n", purchaseEnt)
+		return entity.Purchase{}, err
+	}
+
+	return purchaseEnt, nil
+}
+
+// checkItemExists check the item exists and is not archived
+func checkItemExists(ctx context.Context, itemRepo repository.ItemRepository, itemID valueobject.ItemID) error {
+	item, err := itemRepo.GetByID(ctx, itemID)
+	if err != nil {
+		log.Printf("failed to get item:%d\n", itemID)
+		return err
+	}
+
+	if reflect.DeepEqual(item, entity.Item{}) || item.IsArchived() {
+		return newNotFoundItemError(itemID)
+	}
+
+	return nil
+}
+
+// newNotFoundItemError create the not found error of item
+func newNotFoundItemError(itemID valueobject.ItemID) payload.Error {
+	msg := fmt.Sprintf("not found item:%d", itemID)
+	log.Println(msg)
+	return payload.Error{
+		Code:    payload.ErrCodeNotFoundItem,
+		Message: msg,
+		Param:   itemID,
+		Type:    payload.ErrorTypeNotFound,
+	}
+}
+
+// newSKUExistsError create the conflict error of the sku which has been used
+func newSKUExistsError(sku string) payload.Error {
+	msg := fmt.Sprintf("the sku has been used:%s", sku)
+	log.Println(msg)
+	return payload.Error{
+		Code:    payload.ErrCodeSKUExists,
+		Message: msg,
+		Param:   sku,
+		Type:    payload.ErrorTypeConflict,
+	}
+}
+
+// checkItemVersion check the item has not been changed since the client read it
+func checkItemVersion(item entity.Item, expectedVersion *uint64) error {
+	if expectedVersion == nil || *expectedVersion == item.Version {
+		return nil
+	}
+
+	msg := fmt.Sprintf(
+		"the item has been changed - current version:%d - expected version:%d",
+		item.Version, *expectedVersion,
+	)
+	log.Println(msg)
+	return payload.Error{
+		Code:    payload.ErrCodeItemVersionMismatch,
+		Message: msg,
+		Param:   *expectedVersion,
+		Type:    payload.ErrorTypePreconditionFailed,
+	}
+}
+
+// convertItemWithLocations convert item entity to payload with its stock at each location
+func convertItemWithLocations(
+	ctx context.Context,
+	stockRepo repository.LocationStockRepository,
+	item entity.Item,
+) (payload.Item, error) {
+	stocks, err := stockRepo.ListByItem(ctx, item.ID)
+	if err != nil {
+		log.Printf("failed to get location stocks of item:%d\n", item.ID)
+		return payload.Item{}, err
+	}
+
+	itemPayload := converter.ConvertItemEntityToPayload(item)
+	itemPayload.Locations = converter.ConvertLocationStockEntitiesToPayload(stocks)
+	return itemPayload, nil
+}
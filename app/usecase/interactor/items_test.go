@@ -3,6 +3,7 @@ package interactor
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/shopspring/decimal"
 
 	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
 	"github.com/tuanna7593/gosample/app/domain/repository/mock"
 	"github.com/tuanna7593/gosample/app/domain/valueobject"
 	"github.com/tuanna7593/gosample/app/usecase/payload"
@@ -23,21 +25,43 @@ func TestItemUseCaseImpl_Create(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
 
 		uc := ItemUseCaseImpl{
-			itemRepository: mItemRepo,
+			itemRepository:              mItemRepo,
+			inventoryMovementRepository: mMovementRepo,
+			txManager:                   mTxManager,
 		}
 		ctx := context.Background()
 		request := payload.CreateItemRequest{
+			SKU:             "TS-001",
+			Name:            "T-shirt",
 			TotalStockValue: 5,
 			SellingPrice:    decimal.NewFromFloat(1.55),
 		}
 		itemEntityRequest := entity.Item{
+			SKU:               "TS-001",
+			Name:              "T-shirt",
 			TotalStockValue:   5,
 			CurrentStockValue: 5,
 			SellingPrice:      decimal.NewFromFloat(1.55),
+			Version:           1,
 		}
-		mItemRepo.EXPECT().Create(ctx, &itemEntityRequest).Return(nil)
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetBySKU(ctx, "TS-001").Return(entity.Item{}, nil)
+		mItemRepo.EXPECT().Create(ctx, &itemEntityRequest).DoAndReturn(func(_ context.Context, item *entity.Item) error {
+			item.ID = valueobject.ItemID(1)
+			return nil
+		})
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonCreate,
+			Delta:  5,
+		}).Return(nil)
+		mTxManager.EXPECT().Commit().Return(nil)
 		got, err := uc.Create(ctx, request)
 		if err != nil {
 			t.Errorf("uc.Create() return an error:%v - want:nil", err)
@@ -45,9 +69,13 @@ func TestItemUseCaseImpl_Create(t *testing.T) {
 		}
 
 		want := payload.Item{
-			TotalStockValue:   5,
-			CurrentStockValue: 5,
-			SellingPrice:      decimal.NewFromFloat(1.55),
+			SKU:                  "TS-001",
+			Name:                 "T-shirt",
+			TotalStockValue:      5,
+			CurrentStockValue:    5,
+			UnassignedStockValue: 5,
+			SellingPrice:         decimal.NewFromFloat(1.55),
+			Version:              1,
 		}
 
 		if diff := cmp.Diff(got, want, cmpopts.IgnoreFields(payload.Item{}, "ID", "PlacedAt")); diff != "" {
@@ -60,22 +88,36 @@ func TestItemUseCaseImpl_Create(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
 
 		uc := ItemUseCaseImpl{
-			itemRepository: mItemRepo,
+			itemRepository:              mItemRepo,
+			inventoryMovementRepository: mMovementRepo,
+			txManager:                   mTxManager,
 		}
 		ctx := context.Background()
 		request := payload.CreateItemRequest{
+			SKU:             "TS-001",
+			Name:            "T-shirt",
 			TotalStockValue: 5,
 			SellingPrice:    decimal.NewFromFloat(1.55),
 		}
 		itemEntityRequest := entity.Item{
+			SKU:               "TS-001",
+			Name:              "T-shirt",
 			TotalStockValue:   5,
 			CurrentStockValue: 5,
 			SellingPrice:      decimal.NewFromFloat(1.55),
+			Version:           1,
 		}
 		wannaErr := errors.New("failed to create a new item")
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetBySKU(ctx, "TS-001").Return(entity.Item{}, nil)
 		mItemRepo.EXPECT().Create(ctx, &itemEntityRequest).Return(wannaErr)
+		mTxManager.EXPECT().Rollback()
 		got, err := uc.Create(ctx, request)
 		if !errors.Is(err, wannaErr) {
 			t.Errorf("uc.Create() return an error:%v - want:%v", err, wannaErr)
@@ -86,6 +128,118 @@ func TestItemUseCaseImpl_Create(t *testing.T) {
 			t.Error(diff)
 		}
 	})
+
+	t.Run("#3 SKU has been used", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:              mItemRepo,
+			inventoryMovementRepository: mMovementRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		request := payload.CreateItemRequest{
+			SKU:             "TS-001",
+			Name:            "T-shirt",
+			TotalStockValue: 5,
+			SellingPrice:    decimal.NewFromFloat(1.55),
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetBySKU(ctx, "TS-001").Return(entity.Item{ID: valueobject.ItemID(2), SKU: "TS-001"}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.Create(ctx, request)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeSKUExists,
+			Message: "the sku has been used:TS-001",
+			Param:   "TS-001",
+			Type:    payload.ErrorTypeConflict,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Create() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#4 Not found product", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mProductRepo := mock.NewMockProductRepository(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			productRepository: mProductRepo,
+		}
+		ctx := context.Background()
+		productID := valueobject.ProductID(1)
+		request := payload.CreateItemRequest{
+			ProductID:       &productID,
+			SKU:             "TS-S",
+			Name:            "T-shirt S",
+			TotalStockValue: 5,
+			SellingPrice:    decimal.NewFromFloat(1.55),
+		}
+		mProductRepo.EXPECT().GetByID(ctx, productID).Return(entity.Product{}, nil)
+
+		_, err := uc.Create(ctx, request)
+		wannaErr := newNotFoundProductError(productID)
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Create() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#5 SKU has been used by a concurrent create", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:              mItemRepo,
+			inventoryMovementRepository: mMovementRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		request := payload.CreateItemRequest{
+			SKU:             "TS-001",
+			Name:            "T-shirt",
+			TotalStockValue: 5,
+			SellingPrice:    decimal.NewFromFloat(1.55),
+		}
+		itemEntityRequest := entity.Item{
+			SKU:               "TS-001",
+			Name:              "T-shirt",
+			TotalStockValue:   5,
+			CurrentStockValue: 5,
+			SellingPrice:      decimal.NewFromFloat(1.55),
+			Version:           1,
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetBySKU(ctx, "TS-001").Return(entity.Item{}, nil)
+		mItemRepo.EXPECT().Create(ctx, &itemEntityRequest).Return(repository.ErrSKUExists)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.Create(ctx, request)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeSKUExists,
+			Message: "the sku has been used:TS-001",
+			Param:   "TS-001",
+			Type:    payload.ErrorTypeConflict,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Create() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
 }
 
 func TestItemUseCaseImpl_List(t *testing.T) {
@@ -94,9 +248,11 @@ func TestItemUseCaseImpl_List(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
 
 		uc := ItemUseCaseImpl{
-			itemRepository: mItemRepo,
+			itemRepository:          mItemRepo,
+			locationStockRepository: mLocationStockRepo,
 		}
 		ctx := context.Background()
 		paginationPl := payload.PaginationRequest{
@@ -120,11 +276,20 @@ func TestItemUseCaseImpl_List(t *testing.T) {
 				CreatedAt:         time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local),
 				TotalStockValue:   10,
 				CurrentStockValue: 5,
+				LocatedStockValue: 2,
 				SellingPrice:      decimal.NewFromFloat(3),
 			},
 		}
-		mItemRepo.EXPECT().List(ctx, paginationVal).Return(itemEnts, nil)
-		got, err := uc.List(ctx, paginationPl)
+		mItemRepo.EXPECT().List(ctx, valueobject.ItemFilter{}, paginationVal).Return(itemEnts, nil)
+		mLocationStockRepo.EXPECT().ListByItems(ctx, []valueobject.ItemID{1, 2}).Return([]entity.LocationStock{
+			{
+				ID:                valueobject.LocationStockID(1),
+				ItemID:            valueobject.ItemID(2),
+				LocationID:        valueobject.LocationID(1),
+				CurrentStockValue: 2,
+			},
+		}, nil)
+		got, err := uc.List(ctx, payload.ItemFilter{}, paginationPl)
 		if err != nil {
 			t.Errorf("uc.List() return an error:%v - want:nil", err)
 			return
@@ -132,18 +297,28 @@ func TestItemUseCaseImpl_List(t *testing.T) {
 
 		want := []payload.Item{
 			{
-				ID:                valueobject.ItemID(1),
-				PlacedAt:          time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
-				TotalStockValue:   5,
-				CurrentStockValue: 5,
-				SellingPrice:      decimal.NewFromFloat(1.55),
+				ID:                   valueobject.ItemID(1),
+				PlacedAt:             time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+				TotalStockValue:      5,
+				CurrentStockValue:    5,
+				UnassignedStockValue: 5,
+				Locations:            []payload.ItemLocationStock{},
+				SellingPrice:         decimal.NewFromFloat(1.55),
 			},
 			{
-				ID:                valueobject.ItemID(2),
-				PlacedAt:          time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local),
-				TotalStockValue:   10,
-				CurrentStockValue: 5,
-				SellingPrice:      decimal.NewFromFloat(3),
+				ID:                   valueobject.ItemID(2),
+				PlacedAt:             time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local),
+				TotalStockValue:      10,
+				CurrentStockValue:    5,
+				LocatedStockValue:    2,
+				UnassignedStockValue: 3,
+				Locations: []payload.ItemLocationStock{
+					{
+						LocationID:        valueobject.LocationID(1),
+						CurrentStockValue: 2,
+					},
+				},
+				SellingPrice: decimal.NewFromFloat(3),
 			},
 		}
 
@@ -171,8 +346,8 @@ func TestItemUseCaseImpl_List(t *testing.T) {
 			Limit: 5,
 		}
 		wannaErr := errors.New("failed to get items")
-		mItemRepo.EXPECT().List(ctx, paginationVal).Return(nil, wannaErr)
-		got, err := uc.List(ctx, paginationPl)
+		mItemRepo.EXPECT().List(ctx, valueobject.ItemFilter{}, paginationVal).Return(nil, wannaErr)
+		got, err := uc.List(ctx, payload.ItemFilter{}, paginationPl)
 		if !errors.Is(err, wannaErr) {
 			t.Errorf("uc.List() return an error:%v - want:%v", err, wannaErr)
 			return
@@ -183,33 +358,1136 @@ func TestItemUseCaseImpl_List(t *testing.T) {
 			t.Error(diff)
 		}
 	})
-}
 
-func TestItemUseCaseImpl_BuyItem(t *testing.T) {
-	t.Run("#1: Failed to get item", func(t *testing.T) {
+	t.Run("#3 Filter by the category and its descendants", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mCategoryRepo := mock.NewMockCategoryRepository(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:     mItemRepo,
+			categoryRepository: mCategoryRepo,
+		}
+		ctx := context.Background()
+		clothesID := valueobject.CategoryID(1)
+		shirtsID := valueobject.CategoryID(2)
+		mCategoryRepo.EXPECT().List(ctx).Return([]entity.Category{
+			{ID: clothesID, Name: "Clothes"},
+			{ID: shirtsID, Name: "Shirts", ParentID: &clothesID},
+			{ID: valueobject.CategoryID(3), Name: "T-shirts", ParentID: &shirtsID},
+			{ID: valueobject.CategoryID(4), Name: "Shoes"},
+		}, nil)
+		mItemRepo.EXPECT().List(
+			ctx,
+			valueobject.ItemFilter{CategoryIDs: []valueobject.CategoryID{1, 2, 3}},
+			valueobject.PaginationRequest{Page: 1, Limit: 5},
+		).Return(nil, nil)
+
+		got, err := uc.List(ctx, payload.ItemFilter{CategoryID: clothesID}, payload.PaginationRequest{Page: 1, Limit: 5})
+		if err != nil {
+			t.Errorf("uc.List() return an error:%v - want:nil", err)
+			return
+		}
+
+		if len(got) != 0 {
+			t.Errorf("uc.List() return %d items - want none", len(got))
+		}
+	})
+
+	t.Run("#4 Not found category", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mCategoryRepo := mock.NewMockCategoryRepository(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			categoryRepository: mCategoryRepo,
+		}
+		ctx := context.Background()
+		mCategoryRepo.EXPECT().List(ctx).Return([]entity.Category{{ID: valueobject.CategoryID(1), Name: "Clothes"}}, nil)
+
+		_, err := uc.List(ctx, payload.ItemFilter{CategoryID: valueobject.CategoryID(2)}, payload.PaginationRequest{Page: 1, Limit: 5})
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundCategory,
+			Message: "not found category:2",
+			Param:   valueobject.CategoryID(2),
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.List() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+}
+
+func TestItemUseCaseImpl_Count(t *testing.T) {
+	t.Run("#1: Failed to count items", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository: mItemRepo,
+		}
+		ctx := context.Background()
+		wannaErr := errors.New("failed to count")
+		mItemRepo.EXPECT().Count(ctx, valueobject.ItemFilter{}).Return(int64(0), wannaErr)
+
+		_, err := uc.Count(ctx, payload.ItemFilter{})
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Count() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository: mItemRepo,
+		}
+		ctx := context.Background()
+		mItemRepo.EXPECT().Count(ctx, valueobject.ItemFilter{InStockOnly: true}).Return(int64(3), nil)
+
+		got, err := uc.Count(ctx, payload.ItemFilter{InStockOnly: true})
+		if err != nil {
+			t.Errorf("uc.Count() return an error:%v - want:nil", err)
+			return
+		}
+
+		if got != 3 {
+			t.Errorf("uc.Count() return:%d - want:3", got)
+		}
+	})
+}
+
+func TestItemUseCaseImpl_GetItem(t *testing.T) {
+	t.Run("#1: Failed to get item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository: mItemRepo,
+		}
+		ctx := context.Background()
+		wannaErr := errors.New("failed to get item")
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{}, wannaErr)
+
+		_, err := uc.GetItem(ctx, valueobject.ItemID(1))
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.GetItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Not found item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository: mItemRepo,
+		}
+		ctx := context.Background()
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{}, nil)
+
+		_, err := uc.GetItem(ctx, valueobject.ItemID(1))
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundItem,
+			Message: "not found item:1",
+			Param:   valueobject.ItemID(1),
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.GetItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#3: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:          mItemRepo,
+			locationStockRepository: mLocationStockRepo,
+		}
+		ctx := context.Background()
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalStockValue:   5,
+			CurrentStockValue: 3,
+			LocatedStockValue: 1,
+			SellingPrice:      decimal.NewFromFloat(1.55),
+		}
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(item, nil)
+		mLocationStockRepo.EXPECT().ListByItem(ctx, valueobject.ItemID(1)).Return([]entity.LocationStock{
+			{
+				ID:                valueobject.LocationStockID(1),
+				ItemID:            valueobject.ItemID(1),
+				LocationID:        valueobject.LocationID(2),
+				CurrentStockValue: 1,
+			},
+		}, nil)
+
+		got, err := uc.GetItem(ctx, valueobject.ItemID(1))
+		if err != nil {
+			t.Errorf("uc.GetItem() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Item{
+			ID:                   valueobject.ItemID(1),
+			PlacedAt:             time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalStockValue:      5,
+			CurrentStockValue:    3,
+			LocatedStockValue:    1,
+			UnassignedStockValue: 2,
+			Locations: []payload.ItemLocationStock{
+				{
+					LocationID:        valueobject.LocationID(2),
+					CurrentStockValue: 1,
+				},
+			},
+			SellingPrice: decimal.NewFromFloat(1.55),
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestItemUseCaseImpl_GetItemBySKU(t *testing.T) {
+	t.Run("#1: Not found item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository: mItemRepo,
+		}
+		ctx := context.Background()
+		mItemRepo.EXPECT().GetBySKU(ctx, "TS-001").Return(entity.Item{}, nil)
+
+		_, err := uc.GetItemBySKU(ctx, "TS-001")
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundItem,
+			Message: "not found item with sku:TS-001",
+			Param:   "TS-001",
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.GetItemBySKU() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:          mItemRepo,
+			locationStockRepository: mLocationStockRepo,
+		}
+		ctx := context.Background()
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			SKU:               "TS-001",
+			Name:              "T-shirt",
+			Description:       "Cotton",
+			TotalStockValue:   5,
+			CurrentStockValue: 4,
+			SellingPrice:      decimal.NewFromFloat(1.55),
+			Version:           1,
+		}
+		mItemRepo.EXPECT().GetBySKU(ctx, "TS-001").Return(item, nil)
+		mLocationStockRepo.EXPECT().ListByItem(ctx, item.ID).Return(nil, nil)
+
+		got, err := uc.GetItemBySKU(ctx, "TS-001")
+		if err != nil {
+			t.Errorf("uc.GetItemBySKU() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Item{
+			ID:                   valueobject.ItemID(1),
+			SKU:                  "TS-001",
+			Name:                 "T-shirt",
+			Description:          "Cotton",
+			TotalStockValue:      5,
+			CurrentStockValue:    4,
+			UnassignedStockValue: 4,
+			Locations:            []payload.ItemLocationStock{},
+			SellingPrice:         decimal.NewFromFloat(1.55),
+			Version:              1,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestItemUseCaseImpl_UpdateItem(t *testing.T) {
+	t.Run("#1: Not found item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		totalStockValue := uint64(10)
+		req := payload.UpdateItemRequest{
+			ItemID:          valueobject.ItemID(1),
+			TotalStockValue: &totalStockValue,
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(entity.Item{}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.UpdateItem(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundItem,
+			Message: "not found item:1",
+			Param:   req.ItemID,
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.UpdateItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Total stock below sold quantity", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		totalStockValue := uint64(2)
+		req := payload.UpdateItemRequest{
+			ItemID:          valueobject.ItemID(1),
+			TotalStockValue: &totalStockValue,
+		}
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalStockValue:   5,
+			CurrentStockValue: 2,
+			SellingPrice:      decimal.NewFromFloat(1.55),
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.UpdateItem(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeTotalStockBelowSold,
+			Message: "the total stock is less than sold quantity - sold quantity:3 - request total stock:2",
+			Param:   totalStockValue,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.UpdateItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#3: Failed to update item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		sellingPrice := decimal.NewFromFloat(2.5)
+		req := payload.UpdateItemRequest{
+			ItemID:       valueobject.ItemID(1),
+			SellingPrice: &sellingPrice,
+		}
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalStockValue:   5,
+			CurrentStockValue: 2,
+			SellingPrice:      decimal.NewFromFloat(1.55),
+		}
+		updateValues := map[string]interface{}{
+			"selling_price": sellingPrice,
+		}
+		wannaErr := errors.New("failed to update item")
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, updateValues).Return(wannaErr)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.UpdateItem(ctx, req)
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.UpdateItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#4: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		totalStockValue := uint64(10)
+		sellingPrice := decimal.NewFromFloat(2.5)
+		req := payload.UpdateItemRequest{
+			ItemID:          valueobject.ItemID(1),
+			TotalStockValue: &totalStockValue,
+			SellingPrice:    &sellingPrice,
+		}
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalStockValue:   5,
+			CurrentStockValue: 2,
+			SellingPrice:      decimal.NewFromFloat(1.55),
+		}
+		updateValues := map[string]interface{}{
+			"total_stock_value":   uint64(10),
+			"current_stock_value": uint64(7),
+			"selling_price":       sellingPrice,
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, updateValues).DoAndReturn(
+			func(_ context.Context, item *entity.Item, _ map[string]interface{}) error {
+				item.TotalStockValue = 10
+				item.CurrentStockValue = 7
+				item.SellingPrice = sellingPrice
+				return nil
+			},
+		)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonAdjustment,
+			Delta:  5,
+		}).Return(nil)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.UpdateItem(ctx, req)
+		if err != nil {
+			t.Errorf("uc.UpdateItem() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Item{
+			ID:                   valueobject.ItemID(1),
+			PlacedAt:             time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalStockValue:      10,
+			CurrentStockValue:    7,
+			UnassignedStockValue: 7,
+			SellingPrice:         sellingPrice,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#5: Item version mismatch", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		sellingPrice := decimal.NewFromFloat(2.5)
+		expectedVersion := uint64(2)
+		req := payload.UpdateItemRequest{
+			ItemID:          valueobject.ItemID(1),
+			SellingPrice:    &sellingPrice,
+			ExpectedVersion: &expectedVersion,
+		}
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalStockValue:   5,
+			CurrentStockValue: 2,
+			SellingPrice:      decimal.NewFromFloat(1.55),
+			Version:           3,
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.UpdateItem(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeItemVersionMismatch,
+			Message: "the item has been changed - current version:3 - expected version:2",
+			Param:   expectedVersion,
+			Type:    payload.ErrorTypePreconditionFailed,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.UpdateItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#6: Update reorder threshold only", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		reorderThreshold := uint64(3)
+		req := payload.UpdateItemRequest{
+			ItemID:           valueobject.ItemID(1),
+			ReorderThreshold: &reorderThreshold,
+		}
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   5,
+			CurrentStockValue: 2,
+			SellingPrice:      decimal.NewFromFloat(1.55),
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, map[string]interface{}{
+			"reorder_threshold": uint64(3),
+		}).DoAndReturn(
+			func(_ context.Context, item *entity.Item, _ map[string]interface{}) error {
+				item.ReorderThreshold = 3
+				return nil
+			},
+		)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.UpdateItem(ctx, req)
+		if err != nil {
+			t.Errorf("uc.UpdateItem() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Item{
+			ID:                   valueobject.ItemID(1),
+			TotalStockValue:      5,
+			CurrentStockValue:    2,
+			UnassignedStockValue: 2,
+			ReorderThreshold:     3,
+			SellingPrice:         decimal.NewFromFloat(1.55),
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#7: Stop backordering while backorders are queued", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		backorderable := false
+		req := payload.UpdateItemRequest{
+			ItemID:        valueobject.ItemID(1),
+			Backorderable: &backorderable,
+		}
+		item := entity.Item{
+			ID:                    valueobject.ItemID(1),
+			CreatedAt:             time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalStockValue:       5,
+			BackorderedStockValue: 3,
+			Backorderable:         true,
+			SellingPrice:          decimal.NewFromFloat(1.55),
+			Version:               1,
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.UpdateItem(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeBackordersQueued,
+			Message: "the backorders of item are queued - item:1 - backordered quantity:3",
+			Param:   false,
+			Type:    payload.ErrorTypeConflict,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.UpdateItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+}
+
+func TestItemUseCaseImpl_DeleteItem(t *testing.T) {
+	t.Run("#1: Not found item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository: mItemRepo,
+			txManager:      mTxManager,
+		}
+		ctx := context.Background()
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(1)).Return(entity.Item{}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		err := uc.DeleteItem(ctx, valueobject.ItemID(1))
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundItem,
+			Message: "not found item:1",
+			Param:   valueobject.ItemID(1),
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.DeleteItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Item already archived", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository: mItemRepo,
+			txManager:      mTxManager,
+		}
+		ctx := context.Background()
+		deletedAt := time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local)
+		item := entity.Item{
+			ID:        valueobject.ItemID(1),
+			DeletedAt: &deletedAt,
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(1)).Return(item, nil)
+		mTxManager.EXPECT().Rollback()
+
+		err := uc.DeleteItem(ctx, valueobject.ItemID(1))
+		var e payload.Error
+		if !errors.As(err, &e) || e.Code != payload.ErrCodeNotFoundItem {
+			t.Errorf("uc.DeleteItem() return an error:%v - want:%v", err, payload.ErrCodeNotFoundItem)
+		}
+	})
+
+	t.Run("#3: Failed to archive item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository: mItemRepo,
+			txManager:      mTxManager,
+		}
+		ctx := context.Background()
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   5,
+			CurrentStockValue: 5,
+		}
+		wannaErr := errors.New("failed to archive item")
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(1)).Return(item, nil)
+		mItemRepo.EXPECT().Archive(ctx, &item).Return(wannaErr)
+		mTxManager.EXPECT().Rollback()
+
+		err := uc.DeleteItem(ctx, valueobject.ItemID(1))
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.DeleteItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#4: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository: mItemRepo,
+			txManager:      mTxManager,
+		}
+		ctx := context.Background()
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   5,
+			CurrentStockValue: 5,
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(1)).Return(item, nil)
+		mItemRepo.EXPECT().Archive(ctx, &item).Return(nil)
+		mTxManager.EXPECT().Commit()
+
+		err := uc.DeleteItem(ctx, valueobject.ItemID(1))
+		if err != nil {
+			t.Errorf("uc.DeleteItem() return an error:%v - want:nil", err)
+		}
+	})
+
+	t.Run("#5: Backorders of item are queued", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository: mItemRepo,
+			txManager:      mTxManager,
+		}
+		ctx := context.Background()
+		item := entity.Item{
+			ID:                    valueobject.ItemID(1),
+			TotalStockValue:       5,
+			BackorderedStockValue: 2,
+			Backorderable:         true,
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(1)).Return(item, nil)
+		mTxManager.EXPECT().Rollback()
+
+		err := uc.DeleteItem(ctx, valueobject.ItemID(1))
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeBackordersQueued,
+			Message: "the backorders of item are queued - item:1 - backordered quantity:2",
+			Param:   valueobject.ItemID(1),
+			Type:    payload.ErrorTypeConflict,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.DeleteItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+}
+
+func TestItemUseCaseImpl_BuyItem(t *testing.T) {
+	t.Run("#1: Failed to get item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.PurchaseRequest{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 2,
+		}
+		wannaErr := errors.New("failed to get item")
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(entity.Item{}, wannaErr)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.BuyItem(ctx, req)
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.BuyItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Not found item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.PurchaseRequest{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 2,
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(entity.Item{}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.BuyItem(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundItem,
+			Message: "not found item:1",
+			Param:   req.ItemID,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.BuyItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#3: Item has been archived", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.PurchaseRequest{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 2,
+		}
+		deletedAt := time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local)
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalStockValue:   5,
+			CurrentStockValue: 5,
+			SellingPrice:      decimal.NewFromFloat(1.55),
+			DeletedAt:         &deletedAt,
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.BuyItem(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeArchivedItem,
+			Message: "the item has been archived:1",
+			Param:   req.ItemID,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.BuyItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#4: Item out of stock", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.PurchaseRequest{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 2,
+		}
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalStockValue:   5,
+			CurrentStockValue: 1,
+			SellingPrice:      decimal.NewFromFloat(1.55),
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.BuyItem(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeOutOfStock,
+			Message: "the item out of stock - current quantity:1 - request quantity:2",
+			Param:   req.Quantity,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.BuyItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#5: Failed to update current stock of item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.PurchaseRequest{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 2,
+		}
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalStockValue:   5,
+			CurrentStockValue: 5,
+			SellingPrice:      decimal.NewFromFloat(1.55),
+		}
+		updateValues := map[string]interface{}{
+			"current_stock_value": uint64(3),
+		}
+		wannaErr := errors.New("failed to update item")
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, updateValues).Return(wannaErr)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.BuyItem(ctx, req)
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.BuyItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#6: Failed to create purchase", func(t *testing.T) {
 		t.Parallel()
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 
 		mItemRepo := mock.NewMockItemRepository(mockCtrl)
 		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
 		mTxManager := mock.NewMockTransactionManager(mockCtrl)
 
 		uc := ItemUseCaseImpl{
-			itemRepository:     mItemRepo,
-			purchaseRepository: mPurchaseRepo,
-			txManager:          mTxManager,
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
 		}
 		ctx := context.Background()
 		req := payload.PurchaseRequest{
 			ItemID:   valueobject.ItemID(1),
 			Quantity: 2,
 		}
-		wannaErr := errors.New("failed to get item")
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalStockValue:   5,
+			CurrentStockValue: 5,
+			SellingPrice:      decimal.NewFromFloat(1.55),
+		}
+		updateValues := map[string]interface{}{
+			"current_stock_value": uint64(3),
+		}
+		purchaseEnt := entity.Purchase{
+			ItemID:   req.ItemID,
+			Quantity: req.Quantity,
+			Status:   valueobject.PurchaseStatusFulfilled,
+		}
+		wannaErr := errors.New("failed to update item")
+
 		mTxManager.EXPECT().Begin()
 		mItemRepo.EXPECT().AssignTx(mTxManager)
 		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
-		mItemRepo.EXPECT().GetByID(ctx, req.ItemID).Return(entity.Item{}, wannaErr)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, updateValues).Return(nil)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonPurchase,
+			Delta:  -2,
+		}).Return(nil)
+		mPurchaseRepo.EXPECT().Create(ctx, &purchaseEnt).Return(wannaErr)
 		mTxManager.EXPECT().Rollback()
 
 		_, err := uc.BuyItem(ctx, req)
@@ -218,57 +1496,84 @@ func TestItemUseCaseImpl_BuyItem(t *testing.T) {
 		}
 	})
 
-	t.Run("#2: Not found item", func(t *testing.T) {
+	t.Run("#7: Failed to commit transaction", func(t *testing.T) {
 		t.Parallel()
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 
 		mItemRepo := mock.NewMockItemRepository(mockCtrl)
 		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
 		mTxManager := mock.NewMockTransactionManager(mockCtrl)
 
 		uc := ItemUseCaseImpl{
-			itemRepository:     mItemRepo,
-			purchaseRepository: mPurchaseRepo,
-			txManager:          mTxManager,
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
 		}
 		ctx := context.Background()
 		req := payload.PurchaseRequest{
 			ItemID:   valueobject.ItemID(1),
 			Quantity: 2,
 		}
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			TotalStockValue:   5,
+			CurrentStockValue: 5,
+			SellingPrice:      decimal.NewFromFloat(1.55),
+		}
+		updateValues := map[string]interface{}{
+			"current_stock_value": uint64(3),
+		}
+		purchaseEnt := entity.Purchase{
+			ItemID:   req.ItemID,
+			Quantity: req.Quantity,
+			Status:   valueobject.PurchaseStatusFulfilled,
+		}
+		wannaErr := errors.New("failed to commit transaction")
 
 		mTxManager.EXPECT().Begin()
 		mItemRepo.EXPECT().AssignTx(mTxManager)
 		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
-		mItemRepo.EXPECT().GetByID(ctx, req.ItemID).Return(entity.Item{}, nil)
-		mTxManager.EXPECT().Rollback()
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, updateValues).Return(nil)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonPurchase,
+			Delta:  -2,
+		}).Return(nil)
+		mPurchaseRepo.EXPECT().Create(ctx, &purchaseEnt).Return(nil)
+		mTxManager.EXPECT().Commit().Return(wannaErr)
 
 		_, err := uc.BuyItem(ctx, req)
-		wannaErr := payload.Error{
-			Code:    payload.ErrCodeNotFoundItem,
-			Message: "not found item:1",
-			Param:   req.ItemID,
-			Type:    payload.ErrorTypeBadRequest,
-		}
 		if !errors.Is(err, wannaErr) {
 			t.Errorf("uc.BuyItem() return an error:%v - want:%v", err, wannaErr)
 		}
 	})
 
-	t.Run("#3: Item out of stock", func(t *testing.T) {
+	t.Run("#8: Success", func(t *testing.T) {
 		t.Parallel()
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 
 		mItemRepo := mock.NewMockItemRepository(mockCtrl)
 		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
 		mTxManager := mock.NewMockTransactionManager(mockCtrl)
 
 		uc := ItemUseCaseImpl{
-			itemRepository:     mItemRepo,
-			purchaseRepository: mPurchaseRepo,
-			txManager:          mTxManager,
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
 		}
 		ctx := context.Background()
 		req := payload.PurchaseRequest{
@@ -279,46 +1584,75 @@ func TestItemUseCaseImpl_BuyItem(t *testing.T) {
 			ID:                valueobject.ItemID(1),
 			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
 			TotalStockValue:   5,
-			CurrentStockValue: 1,
+			CurrentStockValue: 5,
 			SellingPrice:      decimal.NewFromFloat(1.55),
 		}
+		updateValues := map[string]interface{}{
+			"current_stock_value": uint64(3),
+		}
+		purchaseEnt := entity.Purchase{
+			ItemID:   req.ItemID,
+			Quantity: req.Quantity,
+			Status:   valueobject.PurchaseStatusFulfilled,
+		}
 
 		mTxManager.EXPECT().Begin()
 		mItemRepo.EXPECT().AssignTx(mTxManager)
 		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
-		mItemRepo.EXPECT().GetByID(ctx, req.ItemID).Return(item, nil)
-		mTxManager.EXPECT().Rollback()
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, updateValues).Return(nil)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonPurchase,
+			Delta:  -2,
+		}).Return(nil)
+		mPurchaseRepo.EXPECT().Create(ctx, &purchaseEnt).Return(nil)
+		mTxManager.EXPECT().Commit().Return(nil)
 
-		_, err := uc.BuyItem(ctx, req)
-		wannaErr := payload.Error{
-			Code:    payload.ErrCodeOutOfStock,
-			Message: "the item out of stock - current quantity:1 - request quantity:2",
-			Param:   req.Quantity,
-			Type:    payload.ErrorTypeBadRequest,
+		got, err := uc.BuyItem(ctx, req)
+		if err != nil {
+			t.Errorf("uc.BuyItem() return an error:%v - want:nil", err)
+			return
 		}
-		if !errors.Is(err, wannaErr) {
-			t.Errorf("uc.BuyItem() return an error:%v - want:%v", err, wannaErr)
+		wannaPurchase := payload.Purchase{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 2,
+			Status:   valueobject.PurchaseStatusFulfilled,
+		}
+		if diff := cmp.Diff(
+			got, wannaPurchase,
+			cmpopts.IgnoreFields(payload.Purchase{}, "ID", "BoughtAt"),
+		); diff != "" {
+			t.Error(diff)
 		}
 	})
 
-	t.Run("#4: Failed to update current stock of item", func(t *testing.T) {
+	t.Run("#9: Item version mismatch", func(t *testing.T) {
 		t.Parallel()
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 
 		mItemRepo := mock.NewMockItemRepository(mockCtrl)
 		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
 		mTxManager := mock.NewMockTransactionManager(mockCtrl)
 
 		uc := ItemUseCaseImpl{
-			itemRepository:     mItemRepo,
-			purchaseRepository: mPurchaseRepo,
-			txManager:          mTxManager,
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
 		}
 		ctx := context.Background()
+		expectedVersion := uint64(1)
 		req := payload.PurchaseRequest{
-			ItemID:   valueobject.ItemID(1),
-			Quantity: 2,
+			ItemID:          valueobject.ItemID(1),
+			Quantity:        2,
+			ExpectedVersion: &expectedVersion,
 		}
 		item := entity.Item{
 			ID:                valueobject.ItemID(1),
@@ -326,87 +1660,110 @@ func TestItemUseCaseImpl_BuyItem(t *testing.T) {
 			TotalStockValue:   5,
 			CurrentStockValue: 5,
 			SellingPrice:      decimal.NewFromFloat(1.55),
+			Version:           2,
 		}
-		updateValues := map[string]interface{}{
-			"current_stock_value": uint64(3),
-		}
-		wannaErr := errors.New("failed to update item")
 
 		mTxManager.EXPECT().Begin()
 		mItemRepo.EXPECT().AssignTx(mTxManager)
 		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
-		mItemRepo.EXPECT().GetByID(ctx, req.ItemID).Return(item, nil)
-		mItemRepo.EXPECT().Updates(ctx, &item, updateValues).Return(wannaErr)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
 		mTxManager.EXPECT().Rollback()
 
 		_, err := uc.BuyItem(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeItemVersionMismatch,
+			Message: "the item has been changed - current version:2 - expected version:1",
+			Param:   expectedVersion,
+			Type:    payload.ErrorTypePreconditionFailed,
+		}
 		if !errors.Is(err, wannaErr) {
 			t.Errorf("uc.BuyItem() return an error:%v - want:%v", err, wannaErr)
 		}
 	})
 
-	t.Run("#5: Failed to create purchase", func(t *testing.T) {
+	t.Run("#10: Item out of stock at location", func(t *testing.T) {
 		t.Parallel()
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 
 		mItemRepo := mock.NewMockItemRepository(mockCtrl)
 		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mLocationRepo := mock.NewMockLocationRepository(mockCtrl)
 		mTxManager := mock.NewMockTransactionManager(mockCtrl)
 
 		uc := ItemUseCaseImpl{
-			itemRepository:     mItemRepo,
-			purchaseRepository: mPurchaseRepo,
-			txManager:          mTxManager,
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			locationRepository:          mLocationRepo,
+			txManager:                   mTxManager,
 		}
 		ctx := context.Background()
+		locationID := valueobject.LocationID(2)
 		req := payload.PurchaseRequest{
-			ItemID:   valueobject.ItemID(1),
-			Quantity: 2,
+			ItemID:     valueobject.ItemID(1),
+			Quantity:   2,
+			LocationID: &locationID,
 		}
 		item := entity.Item{
 			ID:                valueobject.ItemID(1),
-			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
 			TotalStockValue:   5,
 			CurrentStockValue: 5,
+			LocatedStockValue: 1,
 			SellingPrice:      decimal.NewFromFloat(1.55),
 		}
-		updateValues := map[string]interface{}{
-			"current_stock_value": uint64(3),
-		}
-		purchaseEnt := entity.Purchase{
-			ItemID:   req.ItemID,
-			Quantity: req.Quantity,
-		}
-		wannaErr := errors.New("failed to update item")
 
 		mTxManager.EXPECT().Begin()
 		mItemRepo.EXPECT().AssignTx(mTxManager)
 		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
-		mItemRepo.EXPECT().GetByID(ctx, req.ItemID).Return(item, nil)
-		mItemRepo.EXPECT().Updates(ctx, &item, updateValues).Return(nil)
-		mPurchaseRepo.EXPECT().Create(ctx, &purchaseEnt).Return(wannaErr)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mLocationRepo.EXPECT().GetByID(ctx, locationID).Return(entity.Location{ID: locationID, Code: "HN-01"}, nil)
+		mLocationStockRepo.EXPECT().GetForUpdate(ctx, req.ItemID, locationID).Return(entity.LocationStock{
+			ID:                valueobject.LocationStockID(1),
+			ItemID:            valueobject.ItemID(1),
+			LocationID:        locationID,
+			CurrentStockValue: 1,
+		}, nil)
 		mTxManager.EXPECT().Rollback()
 
 		_, err := uc.BuyItem(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeOutOfStock,
+			Message: "the item out of stock at location:2 - current quantity:1 - request quantity:2",
+			Param:   req.Quantity,
+			Type:    payload.ErrorTypeBadRequest,
+		}
 		if !errors.Is(err, wannaErr) {
 			t.Errorf("uc.BuyItem() return an error:%v - want:%v", err, wannaErr)
 		}
 	})
 
-	t.Run("#6: Failed to commit transaction", func(t *testing.T) {
+	t.Run("#11: Send low stock alert after commit", func(t *testing.T) {
 		t.Parallel()
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 
 		mItemRepo := mock.NewMockItemRepository(mockCtrl)
 		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
 		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+		mNotifier := mock.NewMockStockAlertNotifier(mockCtrl)
 
 		uc := ItemUseCaseImpl{
-			itemRepository:     mItemRepo,
-			purchaseRepository: mPurchaseRepo,
-			txManager:          mTxManager,
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+			stockAlertNotifier:          mNotifier,
 		}
 		ctx := context.Background()
 		req := payload.PurchaseRequest{
@@ -415,47 +1772,61 @@ func TestItemUseCaseImpl_BuyItem(t *testing.T) {
 		}
 		item := entity.Item{
 			ID:                valueobject.ItemID(1),
-			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
 			TotalStockValue:   5,
 			CurrentStockValue: 5,
+			ReorderThreshold:  4,
 			SellingPrice:      decimal.NewFromFloat(1.55),
 		}
-		updateValues := map[string]interface{}{
-			"current_stock_value": uint64(3),
-		}
 		purchaseEnt := entity.Purchase{
 			ItemID:   req.ItemID,
 			Quantity: req.Quantity,
+			Status:   valueobject.PurchaseStatusFulfilled,
 		}
-		wannaErr := errors.New("failed to commit transaction")
 
 		mTxManager.EXPECT().Begin()
 		mItemRepo.EXPECT().AssignTx(mTxManager)
 		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
-		mItemRepo.EXPECT().GetByID(ctx, req.ItemID).Return(item, nil)
-		mItemRepo.EXPECT().Updates(ctx, &item, updateValues).Return(nil)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, map[string]interface{}{
+			"current_stock_value": uint64(3),
+		}).Return(nil)
+		mMovementRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 		mPurchaseRepo.EXPECT().Create(ctx, &purchaseEnt).Return(nil)
-		mTxManager.EXPECT().Commit().Return(wannaErr)
+		// the purchase is not failed by the notifier
+		gomock.InOrder(
+			mTxManager.EXPECT().Commit().Return(nil),
+			mNotifier.EXPECT().NotifyLowStock(ctx, entity.LowStockAlert{
+				ItemID:            valueobject.ItemID(1),
+				CurrentStockValue: 3,
+				ReorderThreshold:  4,
+			}).Return(errors.New("failed to send alert")),
+		)
 
 		_, err := uc.BuyItem(ctx, req)
-		if !errors.Is(err, wannaErr) {
-			t.Errorf("uc.BuyItem() return an error:%v - want:%v", err, wannaErr)
+		if err != nil {
+			t.Errorf("uc.BuyItem() return an error:%v - want:nil", err)
 		}
 	})
 
-	t.Run("#7: Success", func(t *testing.T) {
+	t.Run("#12: Backorder when out of stock", func(t *testing.T) {
 		t.Parallel()
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 
 		mItemRepo := mock.NewMockItemRepository(mockCtrl)
 		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
 		mTxManager := mock.NewMockTransactionManager(mockCtrl)
 
 		uc := ItemUseCaseImpl{
-			itemRepository:     mItemRepo,
-			purchaseRepository: mPurchaseRepo,
-			txManager:          mTxManager,
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
 		}
 		ctx := context.Background()
 		req := payload.PurchaseRequest{
@@ -464,24 +1835,27 @@ func TestItemUseCaseImpl_BuyItem(t *testing.T) {
 		}
 		item := entity.Item{
 			ID:                valueobject.ItemID(1),
-			CreatedAt:         time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
 			TotalStockValue:   5,
-			CurrentStockValue: 5,
+			CurrentStockValue: 1,
+			Backorderable:     true,
 			SellingPrice:      decimal.NewFromFloat(1.55),
 		}
-		updateValues := map[string]interface{}{
-			"current_stock_value": uint64(3),
-		}
 		purchaseEnt := entity.Purchase{
 			ItemID:   req.ItemID,
 			Quantity: req.Quantity,
+			Status:   valueobject.PurchaseStatusBackordered,
 		}
 
+		// the stock is not taken and no movement is recorded until the item is restocked
 		mTxManager.EXPECT().Begin()
 		mItemRepo.EXPECT().AssignTx(mTxManager)
 		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
-		mItemRepo.EXPECT().GetByID(ctx, req.ItemID).Return(item, nil)
-		mItemRepo.EXPECT().Updates(ctx, &item, updateValues).Return(nil)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, map[string]interface{}{
+			"backordered_stock_value": uint64(2),
+		}).Return(nil)
 		mPurchaseRepo.EXPECT().Create(ctx, &purchaseEnt).Return(nil)
 		mTxManager.EXPECT().Commit().Return(nil)
 
@@ -493,6 +1867,7 @@ func TestItemUseCaseImpl_BuyItem(t *testing.T) {
 		wannaPurchase := payload.Purchase{
 			ItemID:   valueobject.ItemID(1),
 			Quantity: 2,
+			Status:   valueobject.PurchaseStatusBackordered,
 		}
 		if diff := cmp.Diff(
 			got, wannaPurchase,
@@ -501,4 +1876,309 @@ func TestItemUseCaseImpl_BuyItem(t *testing.T) {
 			t.Error(diff)
 		}
 	})
+
+	t.Run("#13: Backorder while earlier backorders are queued", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.PurchaseRequest{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 1,
+		}
+		// the current stock is enough but the queued backorders are fulfilled first
+		item := entity.Item{
+			ID:                    valueobject.ItemID(1),
+			TotalStockValue:       5,
+			CurrentStockValue:     1,
+			BackorderedStockValue: 3,
+			Backorderable:         true,
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, map[string]interface{}{
+			"backordered_stock_value": uint64(4),
+		}).Return(nil)
+		mPurchaseRepo.EXPECT().Create(ctx, &entity.Purchase{
+			ItemID:   req.ItemID,
+			Quantity: req.Quantity,
+			Status:   valueobject.PurchaseStatusBackordered,
+		}).Return(nil)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.BuyItem(ctx, req)
+		if err != nil {
+			t.Errorf("uc.BuyItem() return an error:%v - want:nil", err)
+			return
+		}
+		if got.Status != valueobject.PurchaseStatusBackordered {
+			t.Errorf("uc.BuyItem() return a purchase with status:%s - want:%s", got.Status, valueobject.PurchaseStatusBackordered)
+		}
+	})
+
+	t.Run("#14: Not found location", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mLocationRepo := mock.NewMockLocationRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ItemUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			locationRepository:          mLocationRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		locationID := valueobject.LocationID(3)
+		req := payload.PurchaseRequest{
+			ItemID:     valueobject.ItemID(1),
+			Quantity:   2,
+			LocationID: &locationID,
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(entity.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   5,
+			CurrentStockValue: 5,
+			SellingPrice:      decimal.NewFromFloat(1.55),
+		}, nil)
+		mLocationRepo.EXPECT().GetByID(ctx, locationID).Return(entity.Location{}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.BuyItem(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundLocation,
+			Message: "not found location:3",
+			Param:   locationID,
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.BuyItem() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+}
+
+// TestItemUseCaseImpl_BuyItem_LocksItemInTransaction checks that BuyItem reads the item with
+// GetByIDForUpdate inside its transaction, the fake row lock is only released when the transaction ends
+// so the stock would be oversold if the item was read without the lock.
+// The FOR UPDATE query itself is checked by TestItemRepositoryImpl_GetByIDForUpdate.
+func TestItemUseCaseImpl_BuyItem_LocksItemInTransaction(t *testing.T) {
+	t.Run("#1: Never oversell when many buyers buy the same item", func(t *testing.T) {
+		t.Parallel()
+		const (
+			stock  = 10
+			buyers = 100
+		)
+		store := &fakeStockStore{
+			item: entity.Item{
+				ID:                valueobject.ItemID(1),
+				TotalStockValue:   stock,
+				CurrentStockValue: stock,
+				SellingPrice:      decimal.NewFromFloat(1.55),
+			},
+		}
+
+		var (
+			wg          sync.WaitGroup
+			mu          sync.Mutex
+			succeeded   int
+			outOfStocks int
+		)
+		for i := 0; i < buyers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				uc := NewItemUseCaseInteractor(
+					&fakeItemRepository{store: store},
+					&fakePurchaseRepository{store: store},
+					&fakeInventoryMovementRepository{store: store},
+					&fakeLocationStockRepository{},
+					nil,
+					nil,
+					nil,
+					&fakeTransactionManager{store: store},
+					valueobject.AllocationStrategyMostStock,
+					nil,
+				)
+				_, err := uc.BuyItem(context.Background(), payload.PurchaseRequest{
+					ItemID:   valueobject.ItemID(1),
+					Quantity: 1,
+				})
+
+				mu.Lock()
+				defer mu.Unlock()
+				var e payload.Error
+				switch {
+				case err == nil:
+					succeeded++
+				case errors.As(err, &e) && e.Code == payload.ErrCodeOutOfStock:
+					outOfStocks++
+				default:
+					t.Errorf("uc.BuyItem() return an error:%v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if succeeded != stock {
+			t.Errorf("%d purchases succeeded - want:%d", succeeded, stock)
+		}
+		if outOfStocks != buyers-stock {
+			t.Errorf("%d purchases are out of stock - want:%d", outOfStocks, buyers-stock)
+		}
+		if store.item.CurrentStockValue != 0 {
+			t.Errorf("current stock value is %d - want:0", store.item.CurrentStockValue)
+		}
+		if len(store.purchases) != stock {
+			t.Errorf("%d purchases are created - want:%d", len(store.purchases), stock)
+		}
+		var delta int64
+		for _, movement := range store.movements {
+			delta += movement.Delta
+		}
+		if delta != -stock {
+			t.Errorf("the sum of movement deltas is %d - want:%d", delta, -stock)
+		}
+	})
+}
+
+// fakeStockStore in-memory storage of one item, rowLock plays the role of the row lock of database
+type fakeStockStore struct {
+	rowLock   sync.Mutex
+	mu        sync.Mutex
+	item      entity.Item
+	purchases []entity.Purchase
+	movements []entity.InventoryMovement
+}
+
+type fakeTransactionManager struct {
+	store  *fakeStockStore
+	locked bool
+}
+
+func (tx *fakeTransactionManager) Begin() {}
+
+func (tx *fakeTransactionManager) Commit() error {
+	tx.release()
+	return nil
+}
+
+func (tx *fakeTransactionManager) Rollback() {
+	tx.release()
+}
+
+func (tx *fakeTransactionManager) GetTx() interface{} {
+	return tx
+}
+
+func (tx *fakeTransactionManager) release() {
+	if tx.locked {
+		tx.locked = false
+		tx.store.rowLock.Unlock()
+	}
+}
+
+type fakeItemRepository struct {
+	repository.ItemRepository
+	store *fakeStockStore
+	tx    *fakeTransactionManager
+}
+
+func (r *fakeItemRepository) AssignTx(txm repository.TransactionManager) {
+	r.tx = txm.GetTx().(*fakeTransactionManager)
+}
+
+func (r *fakeItemRepository) GetByID(ctx context.Context, itemID valueobject.ItemID) (entity.Item, error) {
+	r.store.mu.Lock()
+	item := r.store.item
+	r.store.mu.Unlock()
+
+	// widen the window between read and write like a round trip to database
+	time.Sleep(time.Millisecond)
+	return item, nil
+}
+
+func (r *fakeItemRepository) GetByIDForUpdate(ctx context.Context, itemID valueobject.ItemID) (entity.Item, error) {
+	r.store.rowLock.Lock()
+	r.tx.locked = true
+	return r.GetByID(ctx, itemID)
+}
+
+func (r *fakeItemRepository) Updates(ctx context.Context, item *entity.Item, values map[string]interface{}) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if v, ok := values["current_stock_value"]; ok {
+		r.store.item.CurrentStockValue = v.(uint64)
+	}
+	*item = r.store.item
+	return nil
+}
+
+type fakePurchaseRepository struct {
+	repository.PurchaseRepository
+	store *fakeStockStore
+}
+
+func (r *fakePurchaseRepository) AssignTx(txm repository.TransactionManager) {}
+
+func (r *fakePurchaseRepository) Create(ctx context.Context, purchase *entity.Purchase) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	purchase.ID = valueobject.PurchaseID(len(r.store.purchases) + 1)
+	r.store.purchases = append(r.store.purchases, *purchase)
+	return nil
 }
+
+type fakeInventoryMovementRepository struct {
+	repository.InventoryMovementRepository
+	store *fakeStockStore
+}
+
+func (r *fakeInventoryMovementRepository) AssignTx(txm repository.TransactionManager) {}
+
+func (r *fakeInventoryMovementRepository) Create(ctx context.Context, movement *entity.InventoryMovement) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	movement.ID = valueobject.InventoryMovementID(len(r.store.movements) + 1)
+	r.store.movements = append(r.store.movements, *movement)
+	return nil
+}
+
+// fakeLocationStockRepository the item of fakeStockStore is not kept at any location
+type fakeLocationStockRepository struct {
+	repository.LocationStockRepository
+}
+
+func (r *fakeLocationStockRepository) AssignTx(txm repository.TransactionManager) {}
@@ -0,0 +1,256 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase"
+	"github.com/tuanna7593/gosample/app/usecase/converter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// LocationUseCaseImpl implementation of Location usecase
+type LocationUseCaseImpl struct {
+	locationRepository repository.LocationRepository
+}
+
+// NewLocationUseCaseInteractor create new instance of Location interactor
+func NewLocationUseCaseInteractor(locationRepo repository.LocationRepository) usecase.LocationUseCase {
+	return &LocationUseCaseImpl{
+		locationRepository: locationRepo,
+	}
+}
+
+// Create create a new location, the code of locations is unique
+func (uc LocationUseCaseImpl) Create(ctx context.Context, req payload.CreateLocationRequest) (payload.Location, error) {
+	existing, err := uc.locationRepository.GetByCode(ctx, req.Code)
+	if err != nil {
+		log.Printf("failed to get location by code:%s\n", req.Code)
+		return payload.Location{}, err
+	}
+
+	if !reflect.DeepEqual(existing, entity.Location{}) {
+		msg := fmt.Sprintf("the location code has been used:%s", req.Code)
+		log.Println(msg)
+		return payload.Location{}, payload.Error{
+			Code:    payload.ErrCodeLocationCodeExists,
+			Message: msg,
+			Param:   req.Code,
+			Type:    payload.ErrorTypeConflict,
+		}
+	}
+
+	location := converter.ConvertCreateLocationRequestToEntity(req)
+	err = uc.locationRepository.Create(ctx, &location)
+	if err != nil {
+		log.Printf("failed to create location:%+v\n", location)
+		return payload.Location{}, err
+	}
+
+	return converter.ConvertLocationEntityToPayload(location), nil
+}
+
+// List get all the locations
+func (uc LocationUseCaseImpl) List(ctx context.Context) ([]payload.Location, error) {
+	locations, err := uc.locationRepository.List(ctx)
+	if err != nil {
+		log.Println("failed to get locations")
+		return nil, err
+	}
+
+	locationResps := make([]payload.Location, len(locations))
+	for i := range locations {
+		locationResps[i] = converter.ConvertLocationEntityToPayload(locations[i])
+	}
+
+	return locationResps, nil
+}
+
+// getLocation get a location by id, not found error is returned when the location does not exist
+func getLocation(
+	ctx context.Context,
+	locationRepo repository.LocationRepository,
+	locationID valueobject.LocationID,
+) (entity.Location, error) {
+	location, err := locationRepo.GetByID(ctx, locationID)
+	if err != nil {
+		log.Printf("failed to get location:%d\n", locationID)
+		return entity.Location{}, err
+	}
+
+	if reflect.DeepEqual(location, entity.Location{}) {
+		msg := fmt.Sprintf("not found location:%d", locationID)
+		log.Println(msg)
+		return entity.Location{}, payload.Error{
+			Code:    payload.ErrCodeNotFoundLocation,
+			Message: msg,
+			Param:   locationID,
+			Type:    payload.ErrorTypeNotFound,
+		}
+	}
+
+	return location, nil
+}
+
+// takeStock take the quantity from the stock of item which must have enough current stock.
+// The quantity is taken from the location if it is set, otherwise the unassigned stock is taken first
+// and the rest is taken from the locations by the allocation strategy.
+// The located stock value of item after taking is returned, the item itself is not updated
+func takeStock(
+	ctx context.Context,
+	stockRepo repository.LocationStockRepository,
+	strategy valueobject.AllocationStrategy,
+	item entity.Item,
+	quantity uint64,
+	locationID *valueobject.LocationID,
+) (uint64, error) {
+	if locationID != nil {
+		return takeStockAtLocation(ctx, stockRepo, item, quantity, *locationID)
+	}
+
+	unassigned := item.UnassignedStockValue()
+	if quantity <= unassigned {
+		return item.LocatedStockValue, nil
+	}
+
+	stocks, err := stockRepo.ListByItemForUpdate(ctx, item.ID)
+	if err != nil {
+		log.Printf("failed to get location stocks of item:%d\n", item.ID)
+		return 0, err
+	}
+
+	located := quantity - unassigned
+	remaining := located
+	for _, stock := range sortLocationStocks(strategy, stocks) {
+		if remaining == 0 {
+			break
+		}
+		if stock.CurrentStockValue == 0 {
+			continue
+		}
+
+		taken := stock.CurrentStockValue
+		if taken > remaining {
+			taken = remaining
+		}
+		err = stockRepo.Updates(ctx, &stock, map[string]interface{}{
+			"current_stock_value": stock.CurrentStockValue - taken,
+		})
+		if err != nil {
+			log.Printf("failed to update stock of item:%d at location:%d\n", item.ID, stock.LocationID)
+			return 0, err
+		}
+		remaining -= taken
+	}
+
+	if remaining > 0 {
+		return 0, fmt.Errorf(
+			"the location stocks of item:%d are less than its located stock value:%d",
+			item.ID, item.LocatedStockValue,
+		)
+	}
+
+	return item.LocatedStockValue - located, nil
+}
+
+// takeStockAtLocation take the quantity from the stock of item at a location
+func takeStockAtLocation(
+	ctx context.Context,
+	stockRepo repository.LocationStockRepository,
+	item entity.Item,
+	quantity uint64,
+	locationID valueobject.LocationID,
+) (uint64, error) {
+	stock, err := stockRepo.GetForUpdate(ctx, item.ID, locationID)
+	if err != nil {
+		log.Printf("failed to get stock of item:%d at location:%d\n", item.ID, locationID)
+		return 0, err
+	}
+
+	if stock.CurrentStockValue < quantity {
+		msg := fmt.Sprintf(
+			"the item out of stock at location:%d - current quantity:%d - request quantity:%d",
+			locationID, stock.CurrentStockValue, quantity,
+		)
+		log.Println(msg)
+		return 0, payload.Error{
+			Code:    payload.ErrCodeOutOfStock,
+			Message: msg,
+			Param:   quantity,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+	}
+
+	err = stockRepo.Updates(ctx, &stock, map[string]interface{}{
+		"current_stock_value": stock.CurrentStockValue - quantity,
+	})
+	if err != nil {
+		log.Printf("failed to update stock of item:%d at location:%d\n", item.ID, locationID)
+		return 0, err
+	}
+
+	return item.LocatedStockValue - quantity, nil
+}
+
+// putStock keep the quantity at a location, the located stock value of item after putting is returned
+func putStock(
+	ctx context.Context,
+	stockRepo repository.LocationStockRepository,
+	item entity.Item,
+	quantity uint64,
+	locationID valueobject.LocationID,
+) (uint64, error) {
+	stock, err := stockRepo.GetForUpdate(ctx, item.ID, locationID)
+	if err != nil {
+		log.Printf("failed to get stock of item:%d at location:%d\n", item.ID, locationID)
+		return 0, err
+	}
+
+	if reflect.DeepEqual(stock, entity.LocationStock{}) {
+		stock = entity.LocationStock{
+			ItemID:            item.ID,
+			LocationID:        locationID,
+			CurrentStockValue: quantity,
+		}
+		err = stockRepo.Create(ctx, &stock)
+	} else {
+		err = stockRepo.Updates(ctx, &stock, map[string]interface{}{
+			"current_stock_value": stock.CurrentStockValue + quantity,
+		})
+	}
+	if err != nil {
+		log.Printf("failed to put stock of item:%d at location:%d\n", item.ID, locationID)
+		return 0, err
+	}
+
+	return item.LocatedStockValue + quantity, nil
+}
+
+// sortLocationStocks order the location stocks by the allocation strategy,
+// the stock is taken from the first location first
+func sortLocationStocks(strategy valueobject.AllocationStrategy, stocks []entity.LocationStock) []entity.LocationStock {
+	sorted := make([]entity.LocationStock, len(stocks))
+	copy(sorted, stocks)
+
+	switch strategy {
+	case valueobject.AllocationStrategyLocationOrder:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].LocationID < sorted[j].LocationID
+		})
+	default:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if sorted[i].CurrentStockValue != sorted[j].CurrentStockValue {
+				return sorted[i].CurrentStockValue > sorted[j].CurrentStockValue
+			}
+			return sorted[i].LocationID < sorted[j].LocationID
+		})
+	}
+
+	return sorted
+}
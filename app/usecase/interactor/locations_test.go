@@ -0,0 +1,112 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository/mock"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestLocationUseCaseImpl_Create(t *testing.T) {
+	t.Run("#1: Location code has been used", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mLocationRepo := mock.NewMockLocationRepository(mockCtrl)
+
+		uc := LocationUseCaseImpl{
+			locationRepository: mLocationRepo,
+		}
+		ctx := context.Background()
+		mLocationRepo.EXPECT().GetByCode(ctx, "HN-01").Return(entity.Location{
+			ID:   valueobject.LocationID(1),
+			Code: "HN-01",
+			Name: "Ha Noi warehouse",
+		}, nil)
+
+		_, err := uc.Create(ctx, payload.CreateLocationRequest{Code: "HN-01", Name: "Ha Noi"})
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeLocationCodeExists,
+			Message: "the location code has been used:HN-01",
+			Param:   "HN-01",
+			Type:    payload.ErrorTypeConflict,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Create() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mLocationRepo := mock.NewMockLocationRepository(mockCtrl)
+
+		uc := LocationUseCaseImpl{
+			locationRepository: mLocationRepo,
+		}
+		ctx := context.Background()
+		createdAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		mLocationRepo.EXPECT().GetByCode(ctx, "HN-01").Return(entity.Location{}, nil)
+		mLocationRepo.EXPECT().Create(ctx, &entity.Location{Code: "HN-01", Name: "Ha Noi warehouse"}).DoAndReturn(
+			func(_ context.Context, location *entity.Location) error {
+				location.ID = valueobject.LocationID(1)
+				location.CreatedAt = createdAt
+				return nil
+			},
+		)
+
+		got, err := uc.Create(ctx, payload.CreateLocationRequest{Code: "HN-01", Name: "Ha Noi warehouse"})
+		if err != nil {
+			t.Errorf("uc.Create() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Location{
+			ID:        valueobject.LocationID(1),
+			Code:      "HN-01",
+			Name:      "Ha Noi warehouse",
+			CreatedAt: createdAt,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestSortLocationStocks(t *testing.T) {
+	stocks := []entity.LocationStock{
+		{LocationID: valueobject.LocationID(1), CurrentStockValue: 2},
+		{LocationID: valueobject.LocationID(2), CurrentStockValue: 5},
+		{LocationID: valueobject.LocationID(3), CurrentStockValue: 5},
+	}
+
+	t.Run("#1: Most stock", func(t *testing.T) {
+		t.Parallel()
+		got := sortLocationStocks(valueobject.AllocationStrategyMostStock, stocks)
+		want := []entity.LocationStock{
+			{LocationID: valueobject.LocationID(2), CurrentStockValue: 5},
+			{LocationID: valueobject.LocationID(3), CurrentStockValue: 5},
+			{LocationID: valueobject.LocationID(1), CurrentStockValue: 2},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Location order", func(t *testing.T) {
+		t.Parallel()
+		got := sortLocationStocks(valueobject.AllocationStrategyLocationOrder, stocks)
+		if diff := cmp.Diff(got, stocks); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
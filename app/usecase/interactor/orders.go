@@ -0,0 +1,304 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase"
+	"github.com/tuanna7593/gosample/app/usecase/converter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// OrderUseCaseImpl implementation of Order usecase
+type OrderUseCaseImpl struct {
+	itemRepository              repository.ItemRepository
+	purchaseRepository          repository.PurchaseRepository
+	orderRepository             repository.OrderRepository
+	inventoryMovementRepository repository.InventoryMovementRepository
+	locationStockRepository     repository.LocationStockRepository
+	txManager                   repository.TransactionManager
+	allocationStrategy          valueobject.AllocationStrategy
+	stockAlertNotifier          repository.StockAlertNotifier
+}
+
+// NewOrderUseCaseInteractor create new instance of Order interactor
+func NewOrderUseCaseInteractor(
+	itemRepo repository.ItemRepository,
+	purchaseRepo repository.PurchaseRepository,
+	orderRepo repository.OrderRepository,
+	movementRepo repository.InventoryMovementRepository,
+	locationStockRepo repository.LocationStockRepository,
+	txManager repository.TransactionManager,
+	allocationStrategy valueobject.AllocationStrategy,
+	stockAlertNotifier repository.StockAlertNotifier,
+) usecase.OrderUseCase {
+	return &OrderUseCaseImpl{
+		itemRepository:              itemRepo,
+		purchaseRepository:          purchaseRepo,
+		orderRepository:             orderRepo,
+		inventoryMovementRepository: movementRepo,
+		locationStockRepository:     locationStockRepo,
+		txManager:                   txManager,
+		allocationStrategy:          allocationStrategy,
+		stockAlertNotifier:          stockAlertNotifier,
+	}
+}
+
+// PlaceOrder buy all the lines of order in one transaction,
+// the stock is not changed when any line cannot be bought
+func (uc OrderUseCaseImpl) PlaceOrder(ctx context.Context, req payload.OrderRequest) (payload.Order, error) {
+	// sum the request quantity of each item, the same item can be on many lines
+	requestQuantities := map[valueobject.ItemID]uint64{}
+	itemIDs := []valueobject.ItemID{}
+	overflows := payload.Errors{}
+	for i, line := range req.Lines {
+		prev, ok := requestQuantities[line.ItemID]
+		if !ok {
+			itemIDs = append(itemIDs, line.ItemID)
+		}
+
+		// the wrapped sum would pass the stock check without taking the stock
+		sum := prev + line.Quantity
+		if sum < prev {
+			overflows = append(overflows, newOrderLineError(
+				payload.ErrCodeInvalidBuyQuantity,
+				fmt.Sprintf("the sum of quantity of item overflows - line:%d - item:%d", i, line.ItemID),
+				line.ItemID,
+			))
+			continue
+		}
+		requestQuantities[line.ItemID] = sum
+	}
+	if len(overflows) > 0 {
+		return payload.Order{}, overflows
+	}
+
+	// always lock the items in the same order to avoid deadlock between orders
+	sort.Slice(itemIDs, func(i, j int) bool {
+		return itemIDs[i] < itemIDs[j]
+	})
+
+	// start transaction and assign tx to repositories
+	uc.begin()
+
+	var err error
+	defer func() {
+		if err != nil {
+			log.Printf("found error - rollback transaction:%v\n", err)
+			uc.txManager.Rollback()
+		}
+	}()
+
+	// find and lock items until the transaction ends
+	items, err := uc.lockItems(ctx, itemIDs)
+	if err != nil {
+		return payload.Order{}, err
+	}
+
+	// check every line so the client gets all the failed lines at once
+	errs := payload.Errors{}
+	for i, line := range req.Lines {
+		item := items[line.ItemID]
+		switch {
+		case reflect.DeepEqual(item, entity.Item{}):
+			errs = append(errs, newOrderLineError(
+				payload.ErrCodeNotFoundItem,
+				fmt.Sprintf("not found item - line:%d - item:%d", i, line.ItemID),
+				line.ItemID,
+			))
+		case item.IsArchived():
+			errs = append(errs, newOrderLineError(
+				payload.ErrCodeArchivedItem,
+				fmt.Sprintf("the item has been archived - line:%d - item:%d", i, line.ItemID),
+				line.ItemID,
+			))
+		case item.BackordersQueued():
+			errs = append(errs, newOrderLineError(
+				payload.ErrCodeBackordersQueued,
+				fmt.Sprintf(
+					"the stock of item is held for backorders - line:%d - item:%d - backordered quantity:%d",
+					i, line.ItemID, item.BackorderedStockValue,
+				),
+				line.ItemID,
+			))
+		case item.CurrentStockValue < requestQuantities[line.ItemID]:
+			errs = append(errs, newOrderLineError(
+				payload.ErrCodeOutOfStock,
+				fmt.Sprintf(
+					"the item out of stock - line:%d - item:%d - current quantity:%d - request quantity:%d",
+					i, line.ItemID, item.CurrentStockValue, requestQuantities[line.ItemID],
+				),
+				line.ItemID,
+			))
+		}
+	}
+	if len(errs) > 0 {
+		err = errs
+		return payload.Order{}, err
+	}
+
+	order, alerts, err := uc.fulfil(ctx, items, itemIDs, requestQuantities, req.Lines)
+	if err != nil {
+		return payload.Order{}, err
+	}
+
+	// commit transaction
+	errCommit := uc.txManager.Commit()
+	if errCommit != nil {
+		log.Printf("failed to commit transaction:%+v\n", errCommit)
+		return payload.Order{}, errCommit
+	}
+
+	notifyLowStock(ctx, uc.stockAlertNotifier, alerts)
+
+	return converter.ConvertOrderEntityToPayload(order), nil
+}
+
+// begin start the transaction and assign it to the repositories of checkout
+func (uc OrderUseCaseImpl) begin() {
+	uc.txManager.Begin()
+	uc.itemRepository.AssignTx(uc.txManager)
+	uc.purchaseRepository.AssignTx(uc.txManager)
+	uc.orderRepository.AssignTx(uc.txManager)
+	uc.inventoryMovementRepository.AssignTx(uc.txManager)
+	uc.locationStockRepository.AssignTx(uc.txManager)
+}
+
+// lockItems find and lock the items in the order of itemIDs until the transaction ends
+func (uc OrderUseCaseImpl) lockItems(
+	ctx context.Context,
+	itemIDs []valueobject.ItemID,
+) (map[valueobject.ItemID]entity.Item, error) {
+	items := make(map[valueobject.ItemID]entity.Item, len(itemIDs))
+	for _, itemID := range itemIDs {
+		item, err := uc.itemRepository.GetByIDForUpdate(ctx, itemID)
+		if err != nil {
+			log.Printf("failed to get item:%d\n", itemID)
+			return nil, err
+		}
+		items[itemID] = item
+	}
+
+	return items, nil
+}
+
+// fulfil take the request quantities from the locked items and record a purchase for each line in an order,
+// the caller checks the items can be bought and commits the transaction
+func (uc OrderUseCaseImpl) fulfil(
+	ctx context.Context,
+	items map[valueobject.ItemID]entity.Item,
+	itemIDs []valueobject.ItemID,
+	requestQuantities map[valueobject.ItemID]uint64,
+	lines []payload.OrderLineRequest,
+) (entity.Order, []entity.LowStockAlert, error) {
+	// update the stock value of items
+	var alerts []entity.LowStockAlert
+	for _, itemID := range itemIDs {
+		item := items[itemID]
+		located, err := takeStock(
+			ctx, uc.locationStockRepository, uc.allocationStrategy,
+			item, requestQuantities[itemID], nil,
+		)
+		if err != nil {
+			return entity.Order{}, nil, err
+		}
+
+		currentStockValue := item.CurrentStockValue - requestQuantities[itemID]
+		alerts = collectLowStockAlert(alerts, item, currentStockValue)
+		updateValues := map[string]interface{}{
+			"current_stock_value": currentStockValue,
+		}
+		if located != item.LocatedStockValue {
+			updateValues["located_stock_value"] = located
+		}
+		err = uc.itemRepository.Updates(ctx, &item, updateValues)
+		if err != nil {
+			log.Printf("failed to update current stock of item:%d\n", itemID)
+			return entity.Order{}, nil, err
+		}
+		items[itemID] = item
+
+		err = createInventoryMovement(
+			ctx, uc.inventoryMovementRepository,
+			itemID, valueobject.MovementReasonPurchase, -int64(requestQuantities[itemID]),
+		)
+		if err != nil {
+			return entity.Order{}, nil, err
+		}
+	}
+
+	// create a purchase record for each line
+	order := entity.Order{
+		TotalPrice: decimal.Zero,
+		Lines:      make([]entity.OrderLine, len(lines)),
+	}
+	for i, line := range lines {
+		purchaseEnt := entity.Purchase{
+			ItemID:   line.ItemID,
+			Quantity: line.Quantity,
+			Status:   valueobject.PurchaseStatusFulfilled,
+		}
+		err := uc.purchaseRepository.Create(ctx, &purchaseEnt)
+		if err != nil {
+			log.Printf("failed to create purchase:%+v\n", purchaseEnt)
+			return entity.Order{}, nil, err
+		}
+
+		order.Lines[i] = entity.OrderLine{
+			ItemID:     line.ItemID,
+			PurchaseID: purchaseEnt.ID,
+			Quantity:   line.Quantity,
+			UnitPrice:  items[line.ItemID].SellingPrice,
+		}
+		order.TotalPrice = order.TotalPrice.Add(order.Lines[i].LineTotal())
+	}
+
+	err := uc.orderRepository.Create(ctx, &order)
+	if err != nil {
+		log.Printf("failed to create order:%+v\n", order)
+		return entity.Order{}, nil, err
+	}
+
+	return order, alerts, nil
+}
+
+// GetOrder get an order by id
+func (uc OrderUseCaseImpl) GetOrder(ctx context.Context, orderID valueobject.OrderID) (payload.Order, error) {
+	order, err := uc.orderRepository.GetByID(ctx, orderID)
+	if err != nil {
+		log.Printf("failed to get order:%d\n", orderID)
+		return payload.Order{}, err
+	}
+
+	if reflect.DeepEqual(order, entity.Order{}) {
+		msg := fmt.Sprintf("not found order:%d", orderID)
+		log.Println(msg)
+		return payload.Order{}, payload.Error{
+			Code:    payload.ErrCodeNotFoundOrder,
+			Message: msg,
+			Param:   orderID,
+			Type:    payload.ErrorTypeNotFound,
+		}
+	}
+
+	return converter.ConvertOrderEntityToPayload(order), nil
+}
+
+// newOrderLineError create the error of a line which cannot be bought
+func newOrderLineError(code payload.ErrorCode, msg string, itemID valueobject.ItemID) payload.Error {
+	log.Println(msg)
+	return payload.Error{
+		Code:    code,
+		Message: msg,
+		Param:   itemID,
+		Type:    payload.ErrorTypeBadRequest,
+	}
+}
@@ -0,0 +1,469 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/shopspring/decimal"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository/mock"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestOrderUseCaseImpl_PlaceOrder(t *testing.T) {
+	t.Run("#1: Failed to get item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mOrderRepo := mock.NewMockOrderRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := OrderUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			orderRepository:             mOrderRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.OrderRequest{
+			Lines: []payload.OrderLineRequest{
+				{ItemID: valueobject.ItemID(1), Quantity: 2},
+			},
+		}
+		wannaErr := errors.New("failed to get item")
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mOrderRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(1)).Return(entity.Item{}, wannaErr)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.PlaceOrder(ctx, req)
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.PlaceOrder() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Some lines cannot be bought", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mOrderRepo := mock.NewMockOrderRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := OrderUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			orderRepository:             mOrderRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.OrderRequest{
+			Lines: []payload.OrderLineRequest{
+				{ItemID: valueobject.ItemID(2), Quantity: 2},
+				{ItemID: valueobject.ItemID(1), Quantity: 1},
+				{ItemID: valueobject.ItemID(3), Quantity: 1},
+				{ItemID: valueobject.ItemID(2), Quantity: 2},
+			},
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mOrderRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		gomock.InOrder(
+			mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(1)).Return(entity.Item{
+				ID:                valueobject.ItemID(1),
+				CurrentStockValue: 1,
+			}, nil),
+			mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(2)).Return(entity.Item{
+				ID:                valueobject.ItemID(2),
+				CurrentStockValue: 3,
+			}, nil),
+			mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(3)).Return(entity.Item{}, nil),
+		)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.PlaceOrder(ctx, req)
+		wannaErr := payload.Errors{
+			{
+				Code:    payload.ErrCodeOutOfStock,
+				Message: "the item out of stock - line:0 - item:2 - current quantity:3 - request quantity:4",
+				Param:   valueobject.ItemID(2),
+				Type:    payload.ErrorTypeBadRequest,
+			},
+			{
+				Code:    payload.ErrCodeNotFoundItem,
+				Message: "not found item - line:2 - item:3",
+				Param:   valueobject.ItemID(3),
+				Type:    payload.ErrorTypeBadRequest,
+			},
+			{
+				Code:    payload.ErrCodeOutOfStock,
+				Message: "the item out of stock - line:3 - item:2 - current quantity:3 - request quantity:4",
+				Param:   valueobject.ItemID(2),
+				Type:    payload.ErrorTypeBadRequest,
+			},
+		}
+		if diff := cmp.Diff(err, error(wannaErr)); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#3: Failed to create order", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mOrderRepo := mock.NewMockOrderRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := OrderUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			orderRepository:             mOrderRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.OrderRequest{
+			Lines: []payload.OrderLineRequest{
+				{ItemID: valueobject.ItemID(1), Quantity: 2},
+			},
+		}
+		wannaErr := errors.New("failed to create order")
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mOrderRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(1)).Return(entity.Item{
+			ID:                valueobject.ItemID(1),
+			CurrentStockValue: 5,
+			SellingPrice:      decimal.NewFromFloat(1.5),
+		}, nil)
+		mItemRepo.EXPECT().Updates(ctx, gomock.Any(), gomock.Any()).Return(nil)
+		mMovementRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		mPurchaseRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		mOrderRepo.EXPECT().Create(ctx, gomock.Any()).Return(wannaErr)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.PlaceOrder(ctx, req)
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.PlaceOrder() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#4: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mOrderRepo := mock.NewMockOrderRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := OrderUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			orderRepository:             mOrderRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.OrderRequest{
+			Lines: []payload.OrderLineRequest{
+				{ItemID: valueobject.ItemID(2), Quantity: 3},
+				{ItemID: valueobject.ItemID(1), Quantity: 1},
+			},
+		}
+		item1 := entity.Item{
+			ID:                valueobject.ItemID(1),
+			CurrentStockValue: 1,
+			SellingPrice:      decimal.NewFromFloat(10.25),
+		}
+		item2 := entity.Item{
+			ID:                valueobject.ItemID(2),
+			CurrentStockValue: 5,
+			SellingPrice:      decimal.NewFromFloat(1.5),
+		}
+		createdAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mOrderRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		gomock.InOrder(
+			mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(1)).Return(item1, nil),
+			mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(2)).Return(item2, nil),
+		)
+		mItemRepo.EXPECT().Updates(ctx, &item1, map[string]interface{}{
+			"current_stock_value": uint64(0),
+		}).Return(nil)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonPurchase,
+			Delta:  -1,
+		}).Return(nil)
+		mItemRepo.EXPECT().Updates(ctx, &item2, map[string]interface{}{
+			"current_stock_value": uint64(2),
+		}).Return(nil)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(2),
+			Reason: valueobject.MovementReasonPurchase,
+			Delta:  -3,
+		}).Return(nil)
+		gomock.InOrder(
+			mPurchaseRepo.EXPECT().Create(ctx, &entity.Purchase{ItemID: valueobject.ItemID(2), Quantity: 3, Status: valueobject.PurchaseStatusFulfilled}).
+				DoAndReturn(func(_ context.Context, p *entity.Purchase) error {
+					p.ID = valueobject.PurchaseID(11)
+					return nil
+				}),
+			mPurchaseRepo.EXPECT().Create(ctx, &entity.Purchase{ItemID: valueobject.ItemID(1), Quantity: 1, Status: valueobject.PurchaseStatusFulfilled}).
+				DoAndReturn(func(_ context.Context, p *entity.Purchase) error {
+					p.ID = valueobject.PurchaseID(12)
+					return nil
+				}),
+		)
+		mOrderRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, o *entity.Order) error {
+			o.ID = valueobject.OrderID(1)
+			o.CreatedAt = createdAt
+			return nil
+		})
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.PlaceOrder(ctx, req)
+		if err != nil {
+			t.Errorf("uc.PlaceOrder() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Order{
+			ID:         valueobject.OrderID(1),
+			PlacedAt:   createdAt,
+			TotalPrice: decimal.NewFromFloat(14.75),
+			Lines: []payload.OrderLine{
+				{
+					ItemID:     valueobject.ItemID(2),
+					PurchaseID: valueobject.PurchaseID(11),
+					Quantity:   3,
+					UnitPrice:  decimal.NewFromFloat(1.5),
+					LineTotal:  decimal.NewFromFloat(4.5),
+				},
+				{
+					ItemID:     valueobject.ItemID(1),
+					PurchaseID: valueobject.PurchaseID(12),
+					Quantity:   1,
+					UnitPrice:  decimal.NewFromFloat(10.25),
+					LineTotal:  decimal.NewFromFloat(10.25),
+				},
+			},
+		}
+		if diff := cmp.Diff(got, want, cmp.Comparer(func(x, y decimal.Decimal) bool {
+			return x.Equal(y)
+		})); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#5: Backorders of item are queued", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mOrderRepo := mock.NewMockOrderRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := OrderUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			orderRepository:             mOrderRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.OrderRequest{
+			Lines: []payload.OrderLineRequest{
+				{ItemID: valueobject.ItemID(1), Quantity: 1},
+				{ItemID: valueobject.ItemID(2), Quantity: 1},
+			},
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mOrderRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		gomock.InOrder(
+			mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(1)).Return(entity.Item{
+				ID:                valueobject.ItemID(1),
+				CurrentStockValue: 5,
+			}, nil),
+			mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(2)).Return(entity.Item{
+				ID:                    valueobject.ItemID(2),
+				CurrentStockValue:     3,
+				BackorderedStockValue: 4,
+				Backorderable:         true,
+			}, nil),
+		)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.PlaceOrder(ctx, req)
+		wannaErr := payload.Errors{
+			{
+				Code:    payload.ErrCodeBackordersQueued,
+				Message: "the stock of item is held for backorders - line:1 - item:2 - backordered quantity:4",
+				Param:   valueobject.ItemID(2),
+				Type:    payload.ErrorTypeBadRequest,
+			},
+		}
+		if diff := cmp.Diff(err, error(wannaErr)); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#6: Sum of quantity of item overflows", func(t *testing.T) {
+		t.Parallel()
+		uc := OrderUseCaseImpl{}
+		ctx := context.Background()
+		req := payload.OrderRequest{
+			Lines: []payload.OrderLineRequest{
+				{ItemID: valueobject.ItemID(1), Quantity: 1 << 63},
+				{ItemID: valueobject.ItemID(1), Quantity: 1 << 63},
+			},
+		}
+
+		_, err := uc.PlaceOrder(ctx, req)
+		wannaErr := payload.Errors{
+			{
+				Code:    payload.ErrCodeInvalidBuyQuantity,
+				Message: "the sum of quantity of item overflows - line:1 - item:1",
+				Param:   valueobject.ItemID(1),
+				Type:    payload.ErrorTypeBadRequest,
+			},
+		}
+		if diff := cmp.Diff(err, error(wannaErr)); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestOrderUseCaseImpl_GetOrder(t *testing.T) {
+	t.Run("#1: Not found order", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mOrderRepo := mock.NewMockOrderRepository(mockCtrl)
+
+		uc := OrderUseCaseImpl{
+			orderRepository: mOrderRepo,
+		}
+		ctx := context.Background()
+		mOrderRepo.EXPECT().GetByID(ctx, valueobject.OrderID(1)).Return(entity.Order{}, nil)
+
+		_, err := uc.GetOrder(ctx, valueobject.OrderID(1))
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundOrder,
+			Message: "not found order:1",
+			Param:   valueobject.OrderID(1),
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.GetOrder() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mOrderRepo := mock.NewMockOrderRepository(mockCtrl)
+
+		uc := OrderUseCaseImpl{
+			orderRepository: mOrderRepo,
+		}
+		ctx := context.Background()
+		createdAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		mOrderRepo.EXPECT().GetByID(ctx, valueobject.OrderID(1)).Return(entity.Order{
+			ID:         valueobject.OrderID(1),
+			CreatedAt:  createdAt,
+			TotalPrice: decimal.NewFromInt(3),
+			Lines: []entity.OrderLine{
+				{
+					ID:         valueobject.OrderLineID(1),
+					OrderID:    valueobject.OrderID(1),
+					ItemID:     valueobject.ItemID(2),
+					PurchaseID: valueobject.PurchaseID(3),
+					Quantity:   2,
+					UnitPrice:  decimal.NewFromFloat(1.5),
+				},
+			},
+		}, nil)
+
+		got, err := uc.GetOrder(ctx, valueobject.OrderID(1))
+		if err != nil {
+			t.Errorf("uc.GetOrder() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Order{
+			ID:         valueobject.OrderID(1),
+			PlacedAt:   createdAt,
+			TotalPrice: decimal.NewFromInt(3),
+			Lines: []payload.OrderLine{
+				{
+					ItemID:     valueobject.ItemID(2),
+					PurchaseID: valueobject.PurchaseID(3),
+					Quantity:   2,
+					UnitPrice:  decimal.NewFromFloat(1.5),
+					LineTotal:  decimal.NewFromInt(3),
+				},
+			},
+		}
+		if diff := cmp.Diff(got, want, cmp.Comparer(func(x, y decimal.Decimal) bool {
+			return x.Equal(y)
+		})); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase"
+	"github.com/tuanna7593/gosample/app/usecase/converter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// ProductUseCaseImpl implementation of Product usecase
+type ProductUseCaseImpl struct {
+	productRepository repository.ProductRepository
+	itemRepository    repository.ItemRepository
+}
+
+// NewProductUseCaseInteractor create new instance of Product interactor
+func NewProductUseCaseInteractor(
+	productRepo repository.ProductRepository,
+	itemRepo repository.ItemRepository,
+) usecase.ProductUseCase {
+	return &ProductUseCaseImpl{
+		productRepository: productRepo,
+		itemRepository:    itemRepo,
+	}
+}
+
+// Create create a new product without variants, the variants are created as items of the product
+func (uc ProductUseCaseImpl) Create(ctx context.Context, req payload.CreateProductRequest) (payload.Product, error) {
+	product := converter.ConvertCreateProductRequestToEntity(req)
+	err := uc.productRepository.Create(ctx, &product)
+	if err != nil {
+		log.Printf("failed to create product:%+v\n", product)
+		return payload.Product{}, err
+	}
+
+	return converter.ConvertProductEntityToPayload(product, nil), nil
+}
+
+// GetProduct get a product by id, its stock is the sum of the stock of its variants
+func (uc ProductUseCaseImpl) GetProduct(ctx context.Context, productID valueobject.ProductID) (payload.Product, error) {
+	product, err := uc.productRepository.GetByID(ctx, productID)
+	if err != nil {
+		log.Printf("failed to get product:%d\n", productID)
+		return payload.Product{}, err
+	}
+
+	if reflect.DeepEqual(product, entity.Product{}) {
+		return payload.Product{}, newNotFoundProductError(productID)
+	}
+
+	variants, err := uc.itemRepository.ListByProduct(ctx, productID)
+	if err != nil {
+		log.Printf("failed to get variants of product:%d\n", productID)
+		return payload.Product{}, err
+	}
+
+	return converter.ConvertProductEntityToPayload(product, variants), nil
+}
+
+func newNotFoundProductError(productID valueobject.ProductID) payload.Error {
+	msg := fmt.Sprintf("not found product:%d", productID)
+	log.Println(msg)
+	return payload.Error{
+		Code:    payload.ErrCodeNotFoundProduct,
+		Message: msg,
+		Param:   productID,
+		Type:    payload.ErrorTypeNotFound,
+	}
+}
@@ -0,0 +1,151 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/shopspring/decimal"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository/mock"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestProductUseCaseImpl_GetProduct(t *testing.T) {
+	t.Run("#1: Not found product", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mProductRepo := mock.NewMockProductRepository(mockCtrl)
+
+		uc := ProductUseCaseImpl{
+			productRepository: mProductRepo,
+		}
+		ctx := context.Background()
+		mProductRepo.EXPECT().GetByID(ctx, valueobject.ProductID(1)).Return(entity.Product{}, nil)
+
+		_, err := uc.GetProduct(ctx, valueobject.ProductID(1))
+		wannaErr := newNotFoundProductError(valueobject.ProductID(1))
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.GetProduct() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Failed to get variants", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mProductRepo := mock.NewMockProductRepository(mockCtrl)
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+
+		uc := ProductUseCaseImpl{
+			productRepository: mProductRepo,
+			itemRepository:    mItemRepo,
+		}
+		ctx := context.Background()
+		wannaErr := errors.New("failed to get variants")
+		mProductRepo.EXPECT().GetByID(ctx, valueobject.ProductID(1)).Return(entity.Product{ID: valueobject.ProductID(1)}, nil)
+		mItemRepo.EXPECT().ListByProduct(ctx, valueobject.ProductID(1)).Return(nil, wannaErr)
+
+		_, err := uc.GetProduct(ctx, valueobject.ProductID(1))
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.GetProduct() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#3: Success with the aggregate stock of variants", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mProductRepo := mock.NewMockProductRepository(mockCtrl)
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+
+		uc := ProductUseCaseImpl{
+			productRepository: mProductRepo,
+			itemRepository:    mItemRepo,
+		}
+		ctx := context.Background()
+		createdAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		productID := valueobject.ProductID(1)
+		mProductRepo.EXPECT().GetByID(ctx, productID).Return(entity.Product{
+			ID:        productID,
+			CreatedAt: createdAt,
+			Name:      "T-shirt",
+		}, nil)
+		mItemRepo.EXPECT().ListByProduct(ctx, productID).Return([]entity.Item{
+			{
+				ID:                 valueobject.ItemID(1),
+				CreatedAt:          createdAt,
+				ProductID:          &productID,
+				SKU:                "TS-S",
+				Name:               "T-shirt S",
+				TotalStockValue:    5,
+				CurrentStockValue:  3,
+				ReservedStockValue: 1,
+				SellingPrice:       decimal.NewFromFloat(1.55),
+				Version:            1,
+			},
+			{
+				ID:                valueobject.ItemID(2),
+				CreatedAt:         createdAt,
+				ProductID:         &productID,
+				SKU:               "TS-M",
+				Name:              "T-shirt M",
+				TotalStockValue:   4,
+				CurrentStockValue: 4,
+				SellingPrice:      decimal.NewFromFloat(1.75),
+				Version:           1,
+			},
+		}, nil)
+
+		got, err := uc.GetProduct(ctx, productID)
+		if err != nil {
+			t.Errorf("uc.GetProduct() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Product{
+			ID:                 productID,
+			Name:               "T-shirt",
+			TotalStockValue:    9,
+			CurrentStockValue:  7,
+			ReservedStockValue: 1,
+			Variants: []payload.Item{
+				{
+					ID:                   valueobject.ItemID(1),
+					ProductID:            &productID,
+					SKU:                  "TS-S",
+					Name:                 "T-shirt S",
+					TotalStockValue:      5,
+					CurrentStockValue:    3,
+					ReservedStockValue:   1,
+					UnassignedStockValue: 3,
+					SellingPrice:         decimal.NewFromFloat(1.55),
+					PlacedAt:             createdAt,
+					Version:              1,
+				},
+				{
+					ID:                   valueobject.ItemID(2),
+					ProductID:            &productID,
+					SKU:                  "TS-M",
+					Name:                 "T-shirt M",
+					TotalStockValue:      4,
+					CurrentStockValue:    4,
+					UnassignedStockValue: 4,
+					SellingPrice:         decimal.NewFromFloat(1.75),
+					PlacedAt:             createdAt,
+					Version:              1,
+				},
+			},
+			CreatedAt: createdAt,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
@@ -0,0 +1,254 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase"
+	"github.com/tuanna7593/gosample/app/usecase/converter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// PurchaseUseCaseImpl implementation of Purchase usecase
+type PurchaseUseCaseImpl struct {
+	itemRepository              repository.ItemRepository
+	purchaseRepository          repository.PurchaseRepository
+	inventoryMovementRepository repository.InventoryMovementRepository
+	locationStockRepository     repository.LocationStockRepository
+	txManager                   repository.TransactionManager
+	allocationStrategy          valueobject.AllocationStrategy
+}
+
+// NewPurchaseUseCaseInteractor create new instance of Purchase interactor
+func NewPurchaseUseCaseInteractor(
+	itemRepo repository.ItemRepository,
+	purchaseRepository repository.PurchaseRepository,
+	movementRepo repository.InventoryMovementRepository,
+	locationStockRepo repository.LocationStockRepository,
+	txManager repository.TransactionManager,
+	allocationStrategy valueobject.AllocationStrategy,
+) usecase.PurchaseUseCase {
+	return &PurchaseUseCaseImpl{
+		itemRepository:              itemRepo,
+		purchaseRepository:          purchaseRepository,
+		inventoryMovementRepository: movementRepo,
+		locationStockRepository:     locationStockRepo,
+		txManager:                   txManager,
+		allocationStrategy:          allocationStrategy,
+	}
+}
+
+// List get list purchase, the purchases of an item are listed when the filter has item id
+func (uc PurchaseUseCaseImpl) List(
+	ctx context.Context,
+	filter payload.PurchaseFilter,
+	pagination payload.PaginationRequest,
+) ([]payload.Purchase, error) {
+	if filter.ItemID != 0 {
+		// the purchases of archived item are still listed
+		item, err := uc.itemRepository.GetByID(ctx, filter.ItemID)
+		if err != nil {
+			log.Printf("failed to get item:%d\n", filter.ItemID)
+			return nil, err
+		}
+
+		if reflect.DeepEqual(item, entity.Item{}) {
+			return nil, newNotFoundItemError(filter.ItemID)
+		}
+	}
+
+	filterValueObject := converter.ConvertPurchaseFilterPayloadToValueObject(filter)
+	paginationValueObject := converter.ConvertPaginationPayloadToValueObject(pagination)
+	purchases, err := uc.purchaseRepository.List(ctx, filterValueObject, paginationValueObject)
+	if err != nil {
+		log.Printf("failed to get purchases - filter:%+v - pagination:%+v", filterValueObject, paginationValueObject)
+		return nil, err
+	}
+
+	purchaseResps := make([]payload.Purchase, len(purchases))
+	for i := range purchases {
+		purchaseResps[i] = converter.ConvertPurchaseEntityToPayload(purchases[i])
+	}
+
+	return purchaseResps, nil
+}
+
+// Count count the purchases matched the filter
+func (uc PurchaseUseCaseImpl) Count(ctx context.Context, filter payload.PurchaseFilter) (int64, error) {
+	filterValueObject := converter.ConvertPurchaseFilterPayloadToValueObject(filter)
+	total, err := uc.purchaseRepository.Count(ctx, filterValueObject)
+	if err != nil {
+		log.Printf("failed to count purchases - filter:%+v", filterValueObject)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// GetPurchase get a purchase by id
+func (uc PurchaseUseCaseImpl) GetPurchase(ctx context.Context, purchaseID valueobject.PurchaseID) (payload.Purchase, error) {
+	purchase, err := uc.purchaseRepository.GetByID(ctx, purchaseID)
+	if err != nil {
+		log.Printf("failed to get purchase:%d\n", purchaseID)
+		return payload.Purchase{}, err
+	}
+
+	if reflect.DeepEqual(purchase, entity.Purchase{}) {
+		return payload.Purchase{}, newNotFoundPurchaseError(purchaseID)
+	}
+
+	return converter.ConvertPurchaseEntityToPayload(purchase), nil
+}
+
+// RefundPurchase refund the whole or a part of purchase and return the quantity to the stock of item
+func (uc PurchaseUseCaseImpl) RefundPurchase(ctx context.Context, req payload.RefundRequest) (payload.Purchase, error) {
+	// start transaction
+	uc.txManager.Begin()
+
+	// assign tx to repositories
+	uc.itemRepository.AssignTx(uc.txManager)
+	uc.purchaseRepository.AssignTx(uc.txManager)
+	uc.inventoryMovementRepository.AssignTx(uc.txManager)
+	uc.locationStockRepository.AssignTx(uc.txManager)
+
+	var err error
+	defer func() {
+		if err != nil {
+			log.Printf("found error - rollback transaction:%v\n", err)
+			uc.txManager.Rollback()
+		}
+	}()
+
+	// find and lock purchase until the transaction ends
+	purchase, err := uc.purchaseRepository.GetByIDForUpdate(ctx, req.PurchaseID)
+	if err != nil {
+		log.Printf("failed to get purchase:%d\n", req.PurchaseID)
+		return payload.Purchase{}, err
+	}
+
+	if reflect.DeepEqual(purchase, entity.Purchase{}) {
+		err = newNotFoundPurchaseError(req.PurchaseID)
+		return payload.Purchase{}, err
+	}
+
+	// the stock of a backordered purchase has not been taken yet
+	if purchase.IsBackordered() {
+		msg := fmt.Sprintf("the purchase is backordered:%d", req.PurchaseID)
+		log.Println(msg)
+		err = payload.Error{
+			Code:    payload.ErrCodePurchaseBackordered,
+			Message: msg,
+			Param:   req.PurchaseID,
+			Type:    payload.ErrorTypeConflict,
+		}
+		return payload.Purchase{}, err
+	}
+
+	// check the refundable quantity
+	refundableQuantity := purchase.RefundableQuantity()
+	if refundableQuantity == 0 {
+		msg := fmt.Sprintf("the purchase has been refunded:%d", req.PurchaseID)
+		log.Println(msg)
+		err = payload.Error{
+			Code:    payload.ErrCodeAlreadyRefunded,
+			Message: msg,
+			Param:   req.PurchaseID,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return payload.Purchase{}, err
+	}
+
+	quantity := req.Quantity
+	if quantity == 0 {
+		quantity = refundableQuantity
+	}
+
+	if quantity > refundableQuantity {
+		msg := fmt.Sprintf(
+			"the refund quantity exceeds the refundable quantity - refundable quantity:%d - request quantity:%d",
+			refundableQuantity, quantity,
+		)
+		log.Println(msg)
+		err = payload.Error{
+			Code:    payload.ErrCodeRefundQuantityExceeded,
+			Message: msg,
+			Param:   quantity,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return payload.Purchase{}, err
+	}
+
+	// find and lock item, the stock of archived item is also restored
+	item, err := uc.itemRepository.GetByIDForUpdate(ctx, purchase.ItemID)
+	if err != nil {
+		log.Printf("failed to get item:%d\n", purchase.ItemID)
+		return payload.Purchase{}, err
+	}
+
+	// return the quantity to the stock of item, the queued backorders are fulfilled from the refunded stock
+	refunded := item
+	refunded.CurrentStockValue += quantity
+	itemUpdateValues := map[string]interface{}{
+		"current_stock_value": refunded.CurrentStockValue,
+	}
+	err = uc.itemRepository.Updates(ctx, &item, itemUpdateValues)
+	if err != nil {
+		log.Printf("failed to update current stock of item:%d\n", item.ID)
+		return payload.Purchase{}, err
+	}
+
+	err = createInventoryMovement(
+		ctx, uc.inventoryMovementRepository,
+		item.ID, valueobject.MovementReasonRefund, int64(quantity),
+	)
+	if err != nil {
+		return payload.Purchase{}, err
+	}
+
+	// the refunded stock is taken by the queued backorders first
+	err = fulfillQueuedBackorders(
+		ctx, uc.itemRepository, uc.purchaseRepository, uc.inventoryMovementRepository,
+		uc.locationStockRepository, uc.allocationStrategy, &item, refunded,
+	)
+	if err != nil {
+		return payload.Purchase{}, err
+	}
+
+	// mark the purchase as refunded
+	purchaseUpdateValues := map[string]interface{}{
+		"refunded_quantity": purchase.RefundedQuantity + quantity,
+		"refunded_at":       time.Now(),
+	}
+	err = uc.purchaseRepository.Updates(ctx, &purchase, purchaseUpdateValues)
+	if err != nil {
+		log.Printf("failed to update refunded quantity of purchase:%d\n", purchase.ID)
+		return payload.Purchase{}, err
+	}
+
+	// commit transaction
+	errCommit := uc.txManager.Commit()
+	if errCommit != nil {
+		log.Printf("failed to commit transaction:%+v\n", errCommit)
+		return payload.Purchase{}, errCommit
+	}
+
+	return converter.ConvertPurchaseEntityToPayload(purchase), nil
+}
+
+// newNotFoundPurchaseError create the not found error of purchase
+func newNotFoundPurchaseError(purchaseID valueobject.PurchaseID) payload.Error {
+	msg := fmt.Sprintf("not found purchase:%d", purchaseID)
+	log.Println(msg)
+	return payload.Error{
+		Code:    payload.ErrCodeNotFoundPurchase,
+		Message: msg,
+		Param:   purchaseID,
+		Type:    payload.ErrorTypeNotFound,
+	}
+}
@@ -0,0 +1,676 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository/mock"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestPurchaseUseCaseImpl_List(t *testing.T) {
+	t.Run("#1 Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+
+		uc := PurchaseUseCaseImpl{
+			purchaseRepository: mPurchaseRepo,
+		}
+		ctx := context.Background()
+		from := time.Date(2021, 10, 16, 0, 0, 0, 0, time.Local)
+		paginationPl := payload.PaginationRequest{
+			Page:  1,
+			Limit: 5,
+		}
+		paginationVal := valueobject.PaginationRequest{
+			Page:  1,
+			Limit: 5,
+		}
+		purchaseEnts := []entity.Purchase{
+			{
+				ID:        valueobject.PurchaseID(1),
+				CreatedAt: time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+				ItemID:    valueobject.ItemID(2),
+				Quantity:  3,
+			},
+		}
+		mPurchaseRepo.EXPECT().List(ctx, valueobject.PurchaseFilter{CreatedFrom: from}, paginationVal).Return(purchaseEnts, nil)
+
+		got, err := uc.List(ctx, payload.PurchaseFilter{CreatedFrom: from}, paginationPl)
+		if err != nil {
+			t.Errorf("uc.List() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := []payload.Purchase{
+			{
+				ID:       valueobject.PurchaseID(1),
+				ItemID:   valueobject.ItemID(2),
+				Quantity: 3,
+				BoughtAt: time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2 Not found item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+
+		uc := PurchaseUseCaseImpl{
+			itemRepository:     mItemRepo,
+			purchaseRepository: mPurchaseRepo,
+		}
+		ctx := context.Background()
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{}, nil)
+
+		_, err := uc.List(ctx, payload.PurchaseFilter{ItemID: valueobject.ItemID(1)}, payload.PaginationRequest{})
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundItem,
+			Message: "not found item:1",
+			Param:   valueobject.ItemID(1),
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.List() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#3 Failed when get purchases", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+
+		uc := PurchaseUseCaseImpl{
+			itemRepository:     mItemRepo,
+			purchaseRepository: mPurchaseRepo,
+		}
+		ctx := context.Background()
+		filterVal := valueobject.PurchaseFilter{ItemID: valueobject.ItemID(1)}
+		wannaErr := errors.New("failed to get purchases")
+		mItemRepo.EXPECT().GetByID(ctx, valueobject.ItemID(1)).Return(entity.Item{ID: valueobject.ItemID(1)}, nil)
+		mPurchaseRepo.EXPECT().List(ctx, filterVal, valueobject.PaginationRequest{}).Return(nil, wannaErr)
+
+		got, err := uc.List(ctx, payload.PurchaseFilter{ItemID: valueobject.ItemID(1)}, payload.PaginationRequest{})
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.List() return an error:%v - want:%v", err, wannaErr)
+			return
+		}
+
+		var want []payload.Purchase
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestPurchaseUseCaseImpl_Count(t *testing.T) {
+	t.Run("#1: Failed to count purchases", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+
+		uc := PurchaseUseCaseImpl{
+			purchaseRepository: mPurchaseRepo,
+		}
+		ctx := context.Background()
+		wannaErr := errors.New("failed to count")
+		mPurchaseRepo.EXPECT().Count(ctx, valueobject.PurchaseFilter{}).Return(int64(0), wannaErr)
+
+		_, err := uc.Count(ctx, payload.PurchaseFilter{})
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Count() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+
+		uc := PurchaseUseCaseImpl{
+			purchaseRepository: mPurchaseRepo,
+		}
+		ctx := context.Background()
+		mPurchaseRepo.EXPECT().Count(ctx, valueobject.PurchaseFilter{ItemID: valueobject.ItemID(1)}).Return(int64(3), nil)
+
+		got, err := uc.Count(ctx, payload.PurchaseFilter{ItemID: valueobject.ItemID(1)})
+		if err != nil {
+			t.Errorf("uc.Count() return an error:%v - want:nil", err)
+			return
+		}
+
+		if got != 3 {
+			t.Errorf("uc.Count() return:%d - want:3", got)
+		}
+	})
+}
+
+func TestPurchaseUseCaseImpl_GetPurchase(t *testing.T) {
+	t.Run("#1: Failed to get purchase", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+
+		uc := PurchaseUseCaseImpl{
+			purchaseRepository: mPurchaseRepo,
+		}
+		ctx := context.Background()
+		wannaErr := errors.New("failed to get purchase")
+		mPurchaseRepo.EXPECT().GetByID(ctx, valueobject.PurchaseID(1)).Return(entity.Purchase{}, wannaErr)
+
+		_, err := uc.GetPurchase(ctx, valueobject.PurchaseID(1))
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.GetPurchase() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Not found purchase", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+
+		uc := PurchaseUseCaseImpl{
+			purchaseRepository: mPurchaseRepo,
+		}
+		ctx := context.Background()
+		mPurchaseRepo.EXPECT().GetByID(ctx, valueobject.PurchaseID(1)).Return(entity.Purchase{}, nil)
+
+		_, err := uc.GetPurchase(ctx, valueobject.PurchaseID(1))
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundPurchase,
+			Message: "not found purchase:1",
+			Param:   valueobject.PurchaseID(1),
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.GetPurchase() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#3: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+
+		uc := PurchaseUseCaseImpl{
+			purchaseRepository: mPurchaseRepo,
+		}
+		ctx := context.Background()
+		purchase := entity.Purchase{
+			ID:        valueobject.PurchaseID(1),
+			CreatedAt: time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+			ItemID:    valueobject.ItemID(2),
+			Quantity:  3,
+		}
+		mPurchaseRepo.EXPECT().GetByID(ctx, valueobject.PurchaseID(1)).Return(purchase, nil)
+
+		got, err := uc.GetPurchase(ctx, valueobject.PurchaseID(1))
+		if err != nil {
+			t.Errorf("uc.GetPurchase() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Purchase{
+			ID:       valueobject.PurchaseID(1),
+			ItemID:   valueobject.ItemID(2),
+			Quantity: 3,
+			BoughtAt: time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local),
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestPurchaseUseCaseImpl_RefundPurchase(t *testing.T) {
+	t.Run("#1: Not found purchase", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := PurchaseUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.RefundRequest{
+			PurchaseID: valueobject.PurchaseID(1),
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().GetByIDForUpdate(ctx, req.PurchaseID).Return(entity.Purchase{}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.RefundPurchase(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundPurchase,
+			Message: "not found purchase:1",
+			Param:   req.PurchaseID,
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.RefundPurchase() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Purchase has been refunded", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := PurchaseUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.RefundRequest{
+			PurchaseID: valueobject.PurchaseID(1),
+		}
+		purchase := entity.Purchase{
+			ID:               valueobject.PurchaseID(1),
+			ItemID:           valueobject.ItemID(1),
+			Quantity:         3,
+			RefundedQuantity: 3,
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().GetByIDForUpdate(ctx, req.PurchaseID).Return(purchase, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.RefundPurchase(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeAlreadyRefunded,
+			Message: "the purchase has been refunded:1",
+			Param:   req.PurchaseID,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.RefundPurchase() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#3: Refund quantity exceeds refundable quantity", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := PurchaseUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.RefundRequest{
+			PurchaseID: valueobject.PurchaseID(1),
+			Quantity:   2,
+		}
+		purchase := entity.Purchase{
+			ID:               valueobject.PurchaseID(1),
+			ItemID:           valueobject.ItemID(1),
+			Quantity:         3,
+			RefundedQuantity: 2,
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().GetByIDForUpdate(ctx, req.PurchaseID).Return(purchase, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.RefundPurchase(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeRefundQuantityExceeded,
+			Message: "the refund quantity exceeds the refundable quantity - refundable quantity:1 - request quantity:2",
+			Param:   uint64(2),
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.RefundPurchase() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#4: Failed to update current stock of item", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := PurchaseUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.RefundRequest{
+			PurchaseID: valueobject.PurchaseID(1),
+		}
+		purchase := entity.Purchase{
+			ID:       valueobject.PurchaseID(1),
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 3,
+		}
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   5,
+			CurrentStockValue: 2,
+		}
+		updateValues := map[string]interface{}{
+			"current_stock_value": uint64(5),
+		}
+		wannaErr := errors.New("failed to update item")
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().GetByIDForUpdate(ctx, req.PurchaseID).Return(purchase, nil)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, purchase.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, updateValues).Return(wannaErr)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.RefundPurchase(ctx, req)
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.RefundPurchase() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#5: Success with partial refund", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := PurchaseUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.RefundRequest{
+			PurchaseID: valueobject.PurchaseID(1),
+			Quantity:   1,
+		}
+		purchase := entity.Purchase{
+			ID:       valueobject.PurchaseID(1),
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 3,
+		}
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   5,
+			CurrentStockValue: 2,
+		}
+		itemUpdateValues := map[string]interface{}{
+			"current_stock_value": uint64(3),
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().GetByIDForUpdate(ctx, req.PurchaseID).Return(purchase, nil)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, purchase.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, itemUpdateValues).Return(nil)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonRefund,
+			Delta:  1,
+		}).Return(nil)
+		mPurchaseRepo.EXPECT().Updates(ctx, &purchase, gomock.Any()).DoAndReturn(
+			func(_ context.Context, purchase *entity.Purchase, values map[string]interface{}) error {
+				if values["refunded_quantity"] != uint64(1) {
+					t.Errorf("refunded_quantity is %v - want:1", values["refunded_quantity"])
+				}
+				purchase.RefundedQuantity = 1
+				return nil
+			},
+		)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.RefundPurchase(ctx, req)
+		if err != nil {
+			t.Errorf("uc.RefundPurchase() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Purchase{
+			ID:               valueobject.PurchaseID(1),
+			ItemID:           valueobject.ItemID(1),
+			Quantity:         3,
+			RefundedQuantity: 1,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#6: Purchase is backordered", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := PurchaseUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.RefundRequest{
+			PurchaseID: valueobject.PurchaseID(1),
+		}
+		purchase := entity.Purchase{
+			ID:       valueobject.PurchaseID(1),
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 3,
+			Status:   valueobject.PurchaseStatusBackordered,
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().GetByIDForUpdate(ctx, req.PurchaseID).Return(purchase, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.RefundPurchase(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodePurchaseBackordered,
+			Message: "the purchase is backordered:1",
+			Param:   req.PurchaseID,
+			Type:    payload.ErrorTypeConflict,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.RefundPurchase() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#7: Fulfill the queued backorders with the refunded stock", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := PurchaseUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.RefundRequest{
+			PurchaseID: valueobject.PurchaseID(1),
+			Quantity:   2,
+		}
+		purchase := entity.Purchase{
+			ID:       valueobject.PurchaseID(1),
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 3,
+		}
+		item := entity.Item{
+			ID:                    valueobject.ItemID(1),
+			TotalStockValue:       5,
+			BackorderedStockValue: 2,
+			Backorderable:         true,
+			Version:               1,
+		}
+		refundedItem := item
+		refundedItem.CurrentStockValue = 2
+		refundedItem.Version = 2
+		backorders := []entity.Purchase{
+			{ID: valueobject.PurchaseID(2), ItemID: item.ID, Quantity: 2, Status: valueobject.PurchaseStatusBackordered},
+		}
+
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().GetByIDForUpdate(ctx, req.PurchaseID).Return(purchase, nil)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, purchase.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, map[string]interface{}{
+			"current_stock_value": uint64(2),
+		}).DoAndReturn(
+			func(_ context.Context, item *entity.Item, _ map[string]interface{}) error {
+				item.CurrentStockValue = 2
+				item.Version++
+				return nil
+			},
+		)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonRefund,
+			Delta:  2,
+		}).Return(nil)
+		mPurchaseRepo.EXPECT().ListBackorderedByItemForUpdate(ctx, item.ID).Return(backorders, nil)
+		mPurchaseRepo.EXPECT().Updates(ctx, &backorders[0], gomock.Any()).DoAndReturn(
+			func(_ context.Context, purchase *entity.Purchase, values map[string]interface{}) error {
+				if values["status"] != valueobject.PurchaseStatusFulfilled {
+					t.Errorf("purchase:%d is updated to status:%v - want:%s", purchase.ID, values["status"], valueobject.PurchaseStatusFulfilled)
+				}
+				return nil
+			},
+		)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonPurchase,
+			Delta:  -2,
+		}).Return(nil)
+		mItemRepo.EXPECT().Updates(ctx, &refundedItem, map[string]interface{}{
+			"current_stock_value":     uint64(0),
+			"located_stock_value":     uint64(0),
+			"backordered_stock_value": uint64(0),
+		}).Return(nil)
+		mPurchaseRepo.EXPECT().Updates(ctx, &purchase, gomock.Any()).DoAndReturn(
+			func(_ context.Context, purchase *entity.Purchase, values map[string]interface{}) error {
+				purchase.RefundedQuantity = 2
+				return nil
+			},
+		)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.RefundPurchase(ctx, req)
+		if err != nil {
+			t.Errorf("uc.RefundPurchase() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Purchase{
+			ID:               valueobject.PurchaseID(1),
+			ItemID:           valueobject.ItemID(1),
+			Quantity:         3,
+			RefundedQuantity: 2,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
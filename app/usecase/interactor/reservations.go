@@ -0,0 +1,497 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase"
+	"github.com/tuanna7593/gosample/app/usecase/converter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// releaseExpiredBatchSize the maximum number of expired reservations are released in a run
+const releaseExpiredBatchSize = 100
+
+// ReservationUseCaseImpl implementation of Reservation usecase
+type ReservationUseCaseImpl struct {
+	itemRepository              repository.ItemRepository
+	purchaseRepository          repository.PurchaseRepository
+	reservationRepository       repository.ReservationRepository
+	inventoryMovementRepository repository.InventoryMovementRepository
+	locationStockRepository     repository.LocationStockRepository
+	txManager                   repository.TransactionManager
+	allocationStrategy          valueobject.AllocationStrategy
+}
+
+// NewReservationUseCaseInteractor create new instance of Reservation interactor
+func NewReservationUseCaseInteractor(
+	itemRepo repository.ItemRepository,
+	purchaseRepo repository.PurchaseRepository,
+	reservationRepo repository.ReservationRepository,
+	movementRepo repository.InventoryMovementRepository,
+	locationStockRepo repository.LocationStockRepository,
+	txManager repository.TransactionManager,
+	allocationStrategy valueobject.AllocationStrategy,
+) usecase.ReservationUseCase {
+	return &ReservationUseCaseImpl{
+		itemRepository:              itemRepo,
+		purchaseRepository:          purchaseRepo,
+		reservationRepository:       reservationRepo,
+		inventoryMovementRepository: movementRepo,
+		locationStockRepository:     locationStockRepo,
+		txManager:                   txManager,
+		allocationStrategy:          allocationStrategy,
+	}
+}
+
+// Reserve move the quantity from the current stock of item to its reserved stock
+func (uc ReservationUseCaseImpl) Reserve(ctx context.Context, req payload.ReservationRequest) (payload.Reservation, error) {
+	// start transaction
+	uc.txManager.Begin()
+
+	// assign tx to repositories
+	uc.itemRepository.AssignTx(uc.txManager)
+	uc.reservationRepository.AssignTx(uc.txManager)
+	uc.inventoryMovementRepository.AssignTx(uc.txManager)
+	uc.locationStockRepository.AssignTx(uc.txManager)
+
+	var err error
+	defer func() {
+		if err != nil {
+			log.Printf("found error - rollback transaction:%v\n", err)
+			uc.txManager.Rollback()
+		}
+	}()
+
+	// find and lock item until the transaction ends
+	item, err := uc.itemRepository.GetByIDForUpdate(ctx, req.ItemID)
+	if err != nil {
+		log.Printf("failed to get item:%d\n", req.ItemID)
+		return payload.Reservation{}, err
+	}
+
+	if reflect.DeepEqual(item, entity.Item{}) {
+		err = newNotFoundItemError(req.ItemID)
+		return payload.Reservation{}, err
+	}
+
+	if item.IsArchived() {
+		msg := fmt.Sprintf("the item has been archived:%d", req.ItemID)
+		log.Println(msg)
+		err = payload.Error{
+			Code:    payload.ErrCodeArchivedItem,
+			Message: msg,
+			Param:   req.ItemID,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return payload.Reservation{}, err
+	}
+
+	// a reservation cannot be backordered, so it is rejected while the backorders of item are queued
+	if item.BackordersQueued() {
+		msg := fmt.Sprintf(
+			"the stock of item is held for backorders - item:%d - backordered quantity:%d",
+			req.ItemID, item.BackorderedStockValue,
+		)
+		log.Println(msg)
+		err = payload.Error{
+			Code:    payload.ErrCodeBackordersQueued,
+			Message: msg,
+			Param:   req.ItemID,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return payload.Reservation{}, err
+	}
+
+	// check the current stock value
+	if item.CurrentStockValue < req.Quantity {
+		msg := fmt.Sprintf(
+			"the item out of stock - current quantity:%d - request quantity:%d",
+			item.CurrentStockValue, req.Quantity,
+		)
+		log.Println(msg)
+		err = payload.Error{
+			Code:    payload.ErrCodeOutOfStock,
+			Message: msg,
+			Param:   req.Quantity,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return payload.Reservation{}, err
+	}
+
+	// the held quantity is taken from the locations, it is unassigned when it is released
+	located, err := takeStock(ctx, uc.locationStockRepository, uc.allocationStrategy, item, req.Quantity, nil)
+	if err != nil {
+		return payload.Reservation{}, err
+	}
+
+	// move the quantity to the reserved stock
+	updateValues := map[string]interface{}{
+		"current_stock_value":  item.CurrentStockValue - req.Quantity,
+		"reserved_stock_value": item.ReservedStockValue + req.Quantity,
+	}
+	if located != item.LocatedStockValue {
+		updateValues["located_stock_value"] = located
+	}
+	err = uc.itemRepository.Updates(ctx, &item, updateValues)
+	if err != nil {
+		log.Printf("failed to update stock of item:%d\n", item.ID)
+		return payload.Reservation{}, err
+	}
+
+	err = createInventoryMovement(
+		ctx, uc.inventoryMovementRepository,
+		item.ID, valueobject.MovementReasonReserve, -int64(req.Quantity),
+	)
+	if err != nil {
+		return payload.Reservation{}, err
+	}
+
+	reservation := entity.Reservation{
+		ItemID:    req.ItemID,
+		Quantity:  req.Quantity,
+		Status:    valueobject.ReservationStatusHeld,
+		ExpiresAt: time.Now().Add(req.TTL),
+	}
+	err = uc.reservationRepository.Create(ctx, &reservation)
+	if err != nil {
+		log.Printf("failed to create reservation:%+v\n", reservation)
+		return payload.Reservation{}, err
+	}
+
+	// commit transaction
+	errCommit := uc.txManager.Commit()
+	if errCommit != nil {
+		log.Printf("failed to commit transaction:%+v\n", errCommit)
+		return payload.Reservation{}, errCommit
+	}
+
+	return converter.ConvertReservationEntityToPayload(reservation), nil
+}
+
+// GetReservation get a reservation by id
+func (uc ReservationUseCaseImpl) GetReservation(ctx context.Context, reservationID valueobject.ReservationID) (payload.Reservation, error) {
+	reservation, err := uc.reservationRepository.GetByID(ctx, reservationID)
+	if err != nil {
+		log.Printf("failed to get reservation:%d\n", reservationID)
+		return payload.Reservation{}, err
+	}
+
+	if reflect.DeepEqual(reservation, entity.Reservation{}) {
+		return payload.Reservation{}, newNotFoundReservationError(reservationID)
+	}
+
+	return converter.ConvertReservationEntityToPayload(reservation), nil
+}
+
+// Confirm create a purchase of the held quantity, the quantity is removed from the reserved stock
+func (uc ReservationUseCaseImpl) Confirm(ctx context.Context, reservationID valueobject.ReservationID) (payload.Purchase, error) {
+	// start transaction
+	uc.txManager.Begin()
+
+	// assign tx to repositories
+	uc.itemRepository.AssignTx(uc.txManager)
+	uc.purchaseRepository.AssignTx(uc.txManager)
+	uc.reservationRepository.AssignTx(uc.txManager)
+
+	var err error
+	defer func() {
+		if err != nil {
+			log.Printf("found error - rollback transaction:%v\n", err)
+			uc.txManager.Rollback()
+		}
+	}()
+
+	// always lock the reservation before the item, the same as the other reservation flows
+	reservation, err := uc.getHeldReservationForUpdate(ctx, reservationID)
+	if err != nil {
+		return payload.Purchase{}, err
+	}
+
+	if reservation.IsExpired(time.Now()) {
+		msg := fmt.Sprintf("the reservation has been expired:%d", reservationID)
+		log.Println(msg)
+		err = payload.Error{
+			Code:    payload.ErrCodeReservationExpired,
+			Message: msg,
+			Param:   reservationID,
+			Type:    payload.ErrorTypeConflict,
+		}
+		return payload.Purchase{}, err
+	}
+
+	item, err := uc.itemRepository.GetByIDForUpdate(ctx, reservation.ItemID)
+	if err != nil {
+		log.Printf("failed to get item:%d\n", reservation.ItemID)
+		return payload.Purchase{}, err
+	}
+
+	// the held quantity stays reserved until the reservation is cancelled or expired
+	if item.IsArchived() {
+		msg := fmt.Sprintf("the item has been archived:%d", reservation.ItemID)
+		log.Println(msg)
+		err = payload.Error{
+			Code:    payload.ErrCodeArchivedItem,
+			Message: msg,
+			Param:   reservation.ItemID,
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		return payload.Purchase{}, err
+	}
+
+	// the held quantity has already left the current stock
+	updateValues := map[string]interface{}{
+		"reserved_stock_value": item.ReservedStockValue - reservation.Quantity,
+	}
+	err = uc.itemRepository.Updates(ctx, &item, updateValues)
+	if err != nil {
+		log.Printf("failed to update reserved stock of item:%d\n", item.ID)
+		return payload.Purchase{}, err
+	}
+
+	purchaseEnt := entity.Purchase{
+		ItemID:   reservation.ItemID,
+		Quantity: reservation.Quantity,
+		Status:   valueobject.PurchaseStatusFulfilled,
+	}
+	err = uc.purchaseRepository.Create(ctx, &purchaseEnt)
+	if err != nil {
+		log.Printf("failed to create purchase:%+v\n", purchaseEnt)
+		return payload.Purchase{}, err
+	}
+
+	err = uc.reservationRepository.Updates(ctx, &reservation, map[string]interface{}{
+		"status":      valueobject.ReservationStatusConfirmed,
+		"purchase_id": purchaseEnt.ID,
+	})
+	if err != nil {
+		log.Printf("failed to confirm reservation:%d\n", reservationID)
+		return payload.Purchase{}, err
+	}
+
+	// commit transaction
+	errCommit := uc.txManager.Commit()
+	if errCommit != nil {
+		log.Printf("failed to commit transaction:%+v\n", errCommit)
+		return payload.Purchase{}, errCommit
+	}
+
+	return converter.ConvertPurchaseEntityToPayload(purchaseEnt), nil
+}
+
+// Cancel release a held reservation before it is expired
+func (uc ReservationUseCaseImpl) Cancel(ctx context.Context, reservationID valueobject.ReservationID) (payload.Reservation, error) {
+	// start transaction
+	uc.txManager.Begin()
+
+	// assign tx to repositories
+	uc.itemRepository.AssignTx(uc.txManager)
+	uc.purchaseRepository.AssignTx(uc.txManager)
+	uc.reservationRepository.AssignTx(uc.txManager)
+	uc.inventoryMovementRepository.AssignTx(uc.txManager)
+	uc.locationStockRepository.AssignTx(uc.txManager)
+
+	var err error
+	defer func() {
+		if err != nil {
+			log.Printf("found error - rollback transaction:%v\n", err)
+			uc.txManager.Rollback()
+		}
+	}()
+
+	reservation, err := uc.getHeldReservationForUpdate(ctx, reservationID)
+	if err != nil {
+		return payload.Reservation{}, err
+	}
+
+	err = uc.release(ctx, &reservation)
+	if err != nil {
+		return payload.Reservation{}, err
+	}
+
+	// commit transaction
+	errCommit := uc.txManager.Commit()
+	if errCommit != nil {
+		log.Printf("failed to commit transaction:%+v\n", errCommit)
+		return payload.Reservation{}, errCommit
+	}
+
+	return converter.ConvertReservationEntityToPayload(reservation), nil
+}
+
+// ReleaseExpired release the expired reservations, each one is released in its own transaction.
+// A failed reservation does not stop the others of the batch, the failures are returned in one error
+func (uc ReservationUseCaseImpl) ReleaseExpired(ctx context.Context, now time.Time) (int, error) {
+	reservations, err := uc.reservationRepository.ListExpired(ctx, now, releaseExpiredBatchSize)
+	if err != nil {
+		log.Println("failed to get expired reservations")
+		return 0, err
+	}
+
+	released := 0
+	var (
+		failedIDs []valueobject.ReservationID
+		firstErr  error
+	)
+	for i := range reservations {
+		ok, err := uc.releaseExpired(ctx, reservations[i].ID, now)
+		if err != nil {
+			log.Printf("failed to release expired reservation:%d - %v\n", reservations[i].ID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			failedIDs = append(failedIDs, reservations[i].ID)
+			continue
+		}
+		if ok {
+			released++
+		}
+	}
+
+	if len(failedIDs) > 0 {
+		return released, fmt.Errorf("failed to release expired reservations:%v - first error:%w", failedIDs, firstErr)
+	}
+
+	return released, nil
+}
+
+// releaseExpired release the reservation if it is still held and expired after being locked
+func (uc ReservationUseCaseImpl) releaseExpired(ctx context.Context, reservationID valueobject.ReservationID, now time.Time) (bool, error) {
+	// start transaction
+	uc.txManager.Begin()
+
+	// assign tx to repositories
+	uc.itemRepository.AssignTx(uc.txManager)
+	uc.purchaseRepository.AssignTx(uc.txManager)
+	uc.reservationRepository.AssignTx(uc.txManager)
+	uc.inventoryMovementRepository.AssignTx(uc.txManager)
+	uc.locationStockRepository.AssignTx(uc.txManager)
+
+	var err error
+	defer func() {
+		if err != nil {
+			log.Printf("found error - rollback transaction:%v\n", err)
+			uc.txManager.Rollback()
+		}
+	}()
+
+	reservation, err := uc.reservationRepository.GetByIDForUpdate(ctx, reservationID)
+	if err != nil {
+		log.Printf("failed to get reservation:%d\n", reservationID)
+		return false, err
+	}
+
+	// the reservation has been confirmed or cancelled since it was listed
+	if !reservation.IsHeld() || !reservation.IsExpired(now) {
+		uc.txManager.Rollback()
+		return false, nil
+	}
+
+	err = uc.release(ctx, &reservation)
+	if err != nil {
+		return false, err
+	}
+
+	// commit transaction
+	errCommit := uc.txManager.Commit()
+	if errCommit != nil {
+		log.Printf("failed to commit transaction:%+v\n", errCommit)
+		return false, errCommit
+	}
+
+	return true, nil
+}
+
+// getHeldReservationForUpdate lock the reservation and check it is still held
+func (uc ReservationUseCaseImpl) getHeldReservationForUpdate(ctx context.Context, reservationID valueobject.ReservationID) (entity.Reservation, error) {
+	reservation, err := uc.reservationRepository.GetByIDForUpdate(ctx, reservationID)
+	if err != nil {
+		log.Printf("failed to get reservation:%d\n", reservationID)
+		return entity.Reservation{}, err
+	}
+
+	if reflect.DeepEqual(reservation, entity.Reservation{}) {
+		return entity.Reservation{}, newNotFoundReservationError(reservationID)
+	}
+
+	if !reservation.IsHeld() {
+		msg := fmt.Sprintf("the reservation is not held - reservation:%d - status:%s", reservationID, reservation.Status)
+		log.Println(msg)
+		return entity.Reservation{}, payload.Error{
+			Code:    payload.ErrCodeReservationNotHeld,
+			Message: msg,
+			Param:   reservationID,
+			Type:    payload.ErrorTypeConflict,
+		}
+	}
+
+	return reservation, nil
+}
+
+// release return the held quantity to the current stock of item, the reservation must be locked
+func (uc ReservationUseCaseImpl) release(ctx context.Context, reservation *entity.Reservation) error {
+	item, err := uc.itemRepository.GetByIDForUpdate(ctx, reservation.ItemID)
+	if err != nil {
+		log.Printf("failed to get item:%d\n", reservation.ItemID)
+		return err
+	}
+
+	// released the stock of item after the release, the queued backorders are fulfilled from it
+	released := item
+	released.CurrentStockValue += reservation.Quantity
+	released.ReservedStockValue -= reservation.Quantity
+	updateValues := map[string]interface{}{
+		"current_stock_value":  released.CurrentStockValue,
+		"reserved_stock_value": released.ReservedStockValue,
+	}
+	err = uc.itemRepository.Updates(ctx, &item, updateValues)
+	if err != nil {
+		log.Printf("failed to update stock of item:%d\n", item.ID)
+		return err
+	}
+
+	err = createInventoryMovement(
+		ctx, uc.inventoryMovementRepository,
+		item.ID, valueobject.MovementReasonRelease, int64(reservation.Quantity),
+	)
+	if err != nil {
+		return err
+	}
+
+	// the released stock is taken by the queued backorders first
+	err = fulfillQueuedBackorders(
+		ctx, uc.itemRepository, uc.purchaseRepository, uc.inventoryMovementRepository,
+		uc.locationStockRepository, uc.allocationStrategy, &item, released,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = uc.reservationRepository.Updates(ctx, reservation, map[string]interface{}{
+		"status": valueobject.ReservationStatusReleased,
+	})
+	if err != nil {
+		log.Printf("failed to release reservation:%d\n", reservation.ID)
+		return err
+	}
+
+	return nil
+}
+
+// newNotFoundReservationError create the not found error of reservation
+func newNotFoundReservationError(reservationID valueobject.ReservationID) payload.Error {
+	msg := fmt.Sprintf("not found reservation:%d", reservationID)
+	log.Println(msg)
+	return payload.Error{
+		Code:    payload.ErrCodeNotFoundReservation,
+		Message: msg,
+		Param:   reservationID,
+		Type:    payload.ErrorTypeNotFound,
+	}
+}
@@ -0,0 +1,782 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository/mock"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestReservationUseCaseImpl_Reserve(t *testing.T) {
+	t.Run("#1: Out of stock", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mReservationRepo := mock.NewMockReservationRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ReservationUseCaseImpl{
+			itemRepository:              mItemRepo,
+			reservationRepository:       mReservationRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.ReservationRequest{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 3,
+			TTL:      time.Minute,
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(entity.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   5,
+			CurrentStockValue: 2,
+		}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.Reserve(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeOutOfStock,
+			Message: "the item out of stock - current quantity:2 - request quantity:3",
+			Param:   uint64(3),
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Reserve() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mReservationRepo := mock.NewMockReservationRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ReservationUseCaseImpl{
+			itemRepository:              mItemRepo,
+			reservationRepository:       mReservationRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.ReservationRequest{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 2,
+			TTL:      time.Minute,
+		}
+		item := entity.Item{
+			ID:                 valueobject.ItemID(1),
+			TotalStockValue:    5,
+			CurrentStockValue:  4,
+			ReservedStockValue: 1,
+		}
+		updateValues := map[string]interface{}{
+			"current_stock_value":  uint64(2),
+			"reserved_stock_value": uint64(3),
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, updateValues).Return(nil)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonReserve,
+			Delta:  -2,
+		}).Return(nil)
+		mReservationRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		before := time.Now()
+		got, err := uc.Reserve(ctx, req)
+		if err != nil {
+			t.Errorf("uc.Reserve() return an error:%v - want:nil", err)
+			return
+		}
+
+		if got.ExpiresAt.Before(before.Add(req.TTL)) {
+			t.Errorf("ExpiresAt:%v should not be before:%v", got.ExpiresAt, before.Add(req.TTL))
+		}
+
+		want := payload.Reservation{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 2,
+			Status:   valueobject.ReservationStatusHeld,
+		}
+		if diff := cmp.Diff(
+			got, want,
+			cmpopts.IgnoreFields(payload.Reservation{}, "ExpiresAt"),
+		); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#3: Backorders of item are queued", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mReservationRepo := mock.NewMockReservationRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ReservationUseCaseImpl{
+			itemRepository:              mItemRepo,
+			reservationRepository:       mReservationRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.ReservationRequest{
+			ItemID:   valueobject.ItemID(1),
+			Quantity: 2,
+			TTL:      time.Minute,
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(entity.Item{
+			ID:                    valueobject.ItemID(1),
+			TotalStockValue:       5,
+			CurrentStockValue:     4,
+			BackorderedStockValue: 6,
+			Backorderable:         true,
+		}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.Reserve(ctx, req)
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeBackordersQueued,
+			Message: "the stock of item is held for backorders - item:1 - backordered quantity:6",
+			Param:   valueobject.ItemID(1),
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Reserve() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+}
+
+func TestReservationUseCaseImpl_Confirm(t *testing.T) {
+	t.Run("#1: Not found reservation", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mReservationRepo := mock.NewMockReservationRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ReservationUseCaseImpl{
+			itemRepository:        mItemRepo,
+			purchaseRepository:    mPurchaseRepo,
+			reservationRepository: mReservationRepo,
+			txManager:             mTxManager,
+		}
+		ctx := context.Background()
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ReservationID(1)).Return(entity.Reservation{}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.Confirm(ctx, valueobject.ReservationID(1))
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundReservation,
+			Message: "not found reservation:1",
+			Param:   valueobject.ReservationID(1),
+			Type:    payload.ErrorTypeNotFound,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Confirm() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Reservation has been released", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mReservationRepo := mock.NewMockReservationRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ReservationUseCaseImpl{
+			itemRepository:        mItemRepo,
+			purchaseRepository:    mPurchaseRepo,
+			reservationRepository: mReservationRepo,
+			txManager:             mTxManager,
+		}
+		ctx := context.Background()
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ReservationID(1)).Return(entity.Reservation{
+			ID:     valueobject.ReservationID(1),
+			Status: valueobject.ReservationStatusReleased,
+		}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.Confirm(ctx, valueobject.ReservationID(1))
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeReservationNotHeld,
+			Message: "the reservation is not held - reservation:1 - status:released",
+			Param:   valueobject.ReservationID(1),
+			Type:    payload.ErrorTypeConflict,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Confirm() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#3: Reservation has been expired", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mReservationRepo := mock.NewMockReservationRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ReservationUseCaseImpl{
+			itemRepository:        mItemRepo,
+			purchaseRepository:    mPurchaseRepo,
+			reservationRepository: mReservationRepo,
+			txManager:             mTxManager,
+		}
+		ctx := context.Background()
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ReservationID(1)).Return(entity.Reservation{
+			ID:        valueobject.ReservationID(1),
+			Status:    valueobject.ReservationStatusHeld,
+			ExpiresAt: time.Now().Add(-time.Minute),
+		}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.Confirm(ctx, valueobject.ReservationID(1))
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeReservationExpired,
+			Message: "the reservation has been expired:1",
+			Param:   valueobject.ReservationID(1),
+			Type:    payload.ErrorTypeConflict,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Confirm() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#4: Item has been archived", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mReservationRepo := mock.NewMockReservationRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ReservationUseCaseImpl{
+			itemRepository:        mItemRepo,
+			purchaseRepository:    mPurchaseRepo,
+			reservationRepository: mReservationRepo,
+			txManager:             mTxManager,
+		}
+		ctx := context.Background()
+		deletedAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ReservationID(1)).Return(entity.Reservation{
+			ID:        valueobject.ReservationID(1),
+			ItemID:    valueobject.ItemID(2),
+			Quantity:  2,
+			Status:    valueobject.ReservationStatusHeld,
+			ExpiresAt: time.Now().Add(time.Minute),
+		}, nil)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.ItemID(2)).Return(entity.Item{
+			ID:                 valueobject.ItemID(2),
+			TotalStockValue:    5,
+			CurrentStockValue:  3,
+			ReservedStockValue: 2,
+			DeletedAt:          &deletedAt,
+		}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		_, err := uc.Confirm(ctx, valueobject.ReservationID(1))
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeArchivedItem,
+			Message: "the item has been archived:2",
+			Param:   valueobject.ItemID(2),
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Confirm() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#5: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mReservationRepo := mock.NewMockReservationRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ReservationUseCaseImpl{
+			itemRepository:        mItemRepo,
+			purchaseRepository:    mPurchaseRepo,
+			reservationRepository: mReservationRepo,
+			txManager:             mTxManager,
+		}
+		ctx := context.Background()
+		reservation := entity.Reservation{
+			ID:        valueobject.ReservationID(1),
+			ItemID:    valueobject.ItemID(2),
+			Quantity:  2,
+			Status:    valueobject.ReservationStatusHeld,
+			ExpiresAt: time.Now().Add(time.Minute),
+		}
+		item := entity.Item{
+			ID:                 valueobject.ItemID(2),
+			TotalStockValue:    5,
+			CurrentStockValue:  1,
+			ReservedStockValue: 3,
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().GetByIDForUpdate(ctx, reservation.ID).Return(reservation, nil)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, item.ID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, map[string]interface{}{
+			"reserved_stock_value": uint64(1),
+		}).Return(nil)
+		mPurchaseRepo.EXPECT().Create(ctx, &entity.Purchase{ItemID: item.ID, Quantity: 2, Status: valueobject.PurchaseStatusFulfilled}).
+			DoAndReturn(func(_ context.Context, p *entity.Purchase) error {
+				p.ID = valueobject.PurchaseID(3)
+				return nil
+			})
+		mReservationRepo.EXPECT().Updates(ctx, &reservation, map[string]interface{}{
+			"status":      valueobject.ReservationStatusConfirmed,
+			"purchase_id": valueobject.PurchaseID(3),
+		}).Return(nil)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.Confirm(ctx, reservation.ID)
+		if err != nil {
+			t.Errorf("uc.Confirm() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Purchase{
+			ID:       valueobject.PurchaseID(3),
+			ItemID:   valueobject.ItemID(2),
+			Quantity: 2,
+			Status:   valueobject.PurchaseStatusFulfilled,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestReservationUseCaseImpl_Cancel(t *testing.T) {
+	t.Run("#1: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mReservationRepo := mock.NewMockReservationRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ReservationUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			reservationRepository:       mReservationRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		expiresAt := time.Date(2021, 10, 16, 10, 15, 0, 0, time.Local)
+		reservation := entity.Reservation{
+			ID:        valueobject.ReservationID(1),
+			ItemID:    valueobject.ItemID(2),
+			Quantity:  2,
+			Status:    valueobject.ReservationStatusHeld,
+			ExpiresAt: expiresAt,
+		}
+		item := entity.Item{
+			ID:                 valueobject.ItemID(2),
+			TotalStockValue:    5,
+			CurrentStockValue:  1,
+			ReservedStockValue: 3,
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().GetByIDForUpdate(ctx, reservation.ID).Return(reservation, nil)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, item.ID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, map[string]interface{}{
+			"current_stock_value":  uint64(3),
+			"reserved_stock_value": uint64(1),
+		}).Return(nil)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(2),
+			Reason: valueobject.MovementReasonRelease,
+			Delta:  2,
+		}).Return(nil)
+		mReservationRepo.EXPECT().Updates(ctx, &reservation, map[string]interface{}{
+			"status": valueobject.ReservationStatusReleased,
+		}).DoAndReturn(func(_ context.Context, r *entity.Reservation, _ map[string]interface{}) error {
+			r.Status = valueobject.ReservationStatusReleased
+			return nil
+		})
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.Cancel(ctx, reservation.ID)
+		if err != nil {
+			t.Errorf("uc.Cancel() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Reservation{
+			ID:        valueobject.ReservationID(1),
+			ItemID:    valueobject.ItemID(2),
+			Quantity:  2,
+			Status:    valueobject.ReservationStatusReleased,
+			ExpiresAt: expiresAt,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("#2: Fulfill the queued backorders with the released stock", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mReservationRepo := mock.NewMockReservationRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ReservationUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			reservationRepository:       mReservationRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		reservation := entity.Reservation{
+			ID:        valueobject.ReservationID(1),
+			ItemID:    valueobject.ItemID(2),
+			Quantity:  2,
+			Status:    valueobject.ReservationStatusHeld,
+			ExpiresAt: time.Date(2021, 10, 16, 10, 15, 0, 0, time.Local),
+		}
+		item := entity.Item{
+			ID:                    valueobject.ItemID(2),
+			TotalStockValue:       5,
+			ReservedStockValue:    2,
+			BackorderedStockValue: 3,
+			Backorderable:         true,
+			Version:               1,
+		}
+		releasedItem := item
+		releasedItem.CurrentStockValue = 2
+		releasedItem.ReservedStockValue = 0
+		releasedItem.Version = 2
+		// the second backorder cannot be fulfilled with the rest of the released stock
+		backorders := []entity.Purchase{
+			{ID: valueobject.PurchaseID(4), ItemID: item.ID, Quantity: 2, Status: valueobject.PurchaseStatusBackordered},
+			{ID: valueobject.PurchaseID(5), ItemID: item.ID, Quantity: 1, Status: valueobject.PurchaseStatusBackordered},
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mReservationRepo.EXPECT().GetByIDForUpdate(ctx, reservation.ID).Return(reservation, nil)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, item.ID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, map[string]interface{}{
+			"current_stock_value":  uint64(2),
+			"reserved_stock_value": uint64(0),
+		}).DoAndReturn(
+			func(_ context.Context, item *entity.Item, _ map[string]interface{}) error {
+				item.CurrentStockValue = 2
+				item.ReservedStockValue = 0
+				item.Version++
+				return nil
+			},
+		)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(2),
+			Reason: valueobject.MovementReasonRelease,
+			Delta:  2,
+		}).Return(nil)
+		mPurchaseRepo.EXPECT().ListBackorderedByItemForUpdate(ctx, item.ID).Return(backorders, nil)
+		mPurchaseRepo.EXPECT().Updates(ctx, &backorders[0], gomock.Any()).DoAndReturn(
+			func(_ context.Context, purchase *entity.Purchase, values map[string]interface{}) error {
+				if values["status"] != valueobject.PurchaseStatusFulfilled {
+					t.Errorf("purchase:%d is updated to status:%v - want:%s", purchase.ID, values["status"], valueobject.PurchaseStatusFulfilled)
+				}
+				return nil
+			},
+		)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(2),
+			Reason: valueobject.MovementReasonPurchase,
+			Delta:  -2,
+		}).Return(nil)
+		mItemRepo.EXPECT().Updates(ctx, &releasedItem, map[string]interface{}{
+			"current_stock_value":     uint64(0),
+			"located_stock_value":     uint64(0),
+			"backordered_stock_value": uint64(1),
+		}).Return(nil)
+		mReservationRepo.EXPECT().Updates(ctx, &reservation, map[string]interface{}{
+			"status": valueobject.ReservationStatusReleased,
+		}).Return(nil)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		_, err := uc.Cancel(ctx, reservation.ID)
+		if err != nil {
+			t.Errorf("uc.Cancel() return an error:%v - want:nil", err)
+		}
+	})
+}
+
+func TestReservationUseCaseImpl_ReleaseExpired(t *testing.T) {
+	t.Run("#1: Failed to get expired reservations", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mReservationRepo := mock.NewMockReservationRepository(mockCtrl)
+
+		uc := ReservationUseCaseImpl{
+			reservationRepository: mReservationRepo,
+		}
+		ctx := context.Background()
+		now := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		wannaErr := errors.New("failed to get expired reservations")
+		mReservationRepo.EXPECT().ListExpired(ctx, now, releaseExpiredBatchSize).Return(nil, wannaErr)
+
+		_, err := uc.ReleaseExpired(ctx, now)
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.ReleaseExpired() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Skip the reservation which has been confirmed", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mReservationRepo := mock.NewMockReservationRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ReservationUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			reservationRepository:       mReservationRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		now := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		expired := entity.Reservation{
+			ID:        valueobject.ReservationID(1),
+			ItemID:    valueobject.ItemID(2),
+			Quantity:  2,
+			Status:    valueobject.ReservationStatusHeld,
+			ExpiresAt: now.Add(-time.Minute),
+		}
+		confirmed := entity.Reservation{
+			ID:        valueobject.ReservationID(3),
+			ItemID:    valueobject.ItemID(2),
+			Quantity:  1,
+			Status:    valueobject.ReservationStatusConfirmed,
+			ExpiresAt: now.Add(-time.Minute),
+		}
+		item := entity.Item{
+			ID:                 valueobject.ItemID(2),
+			TotalStockValue:    5,
+			CurrentStockValue:  1,
+			ReservedStockValue: 2,
+		}
+		mReservationRepo.EXPECT().ListExpired(ctx, now, releaseExpiredBatchSize).Return([]entity.Reservation{expired, confirmed}, nil)
+		mTxManager.EXPECT().Begin().Times(2)
+		mItemRepo.EXPECT().AssignTx(mTxManager).Times(2)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager).Times(2)
+		mReservationRepo.EXPECT().AssignTx(mTxManager).Times(2)
+		mMovementRepo.EXPECT().AssignTx(mTxManager).Times(2)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager).Times(2)
+		mReservationRepo.EXPECT().GetByIDForUpdate(ctx, expired.ID).Return(expired, nil)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, item.ID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, map[string]interface{}{
+			"current_stock_value":  uint64(3),
+			"reserved_stock_value": uint64(0),
+		}).Return(nil)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(2),
+			Reason: valueobject.MovementReasonRelease,
+			Delta:  2,
+		}).Return(nil)
+		mReservationRepo.EXPECT().Updates(ctx, &expired, map[string]interface{}{
+			"status": valueobject.ReservationStatusReleased,
+		}).Return(nil)
+		mTxManager.EXPECT().Commit().Return(nil)
+		mReservationRepo.EXPECT().GetByIDForUpdate(ctx, confirmed.ID).Return(confirmed, nil)
+		mTxManager.EXPECT().Rollback()
+
+		got, err := uc.ReleaseExpired(ctx, now)
+		if err != nil {
+			t.Errorf("uc.ReleaseExpired() return an error:%v - want:nil", err)
+			return
+		}
+
+		if got != 1 {
+			t.Errorf("uc.ReleaseExpired() return:%d - want:1", got)
+		}
+	})
+
+	t.Run("#3: Continue with the next reservation after a failure", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mReservationRepo := mock.NewMockReservationRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := ReservationUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			reservationRepository:       mReservationRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		now := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		broken := entity.Reservation{
+			ID:        valueobject.ReservationID(1),
+			ItemID:    valueobject.ItemID(4),
+			Quantity:  1,
+			Status:    valueobject.ReservationStatusHeld,
+			ExpiresAt: now.Add(-time.Minute),
+		}
+		expired := entity.Reservation{
+			ID:        valueobject.ReservationID(2),
+			ItemID:    valueobject.ItemID(2),
+			Quantity:  2,
+			Status:    valueobject.ReservationStatusHeld,
+			ExpiresAt: now.Add(-time.Minute),
+		}
+		item := entity.Item{
+			ID:                 valueobject.ItemID(2),
+			TotalStockValue:    5,
+			CurrentStockValue:  1,
+			ReservedStockValue: 2,
+		}
+		wannaErr := errors.New("failed to get reservation")
+		mReservationRepo.EXPECT().ListExpired(ctx, now, releaseExpiredBatchSize).Return([]entity.Reservation{broken, expired}, nil)
+		mTxManager.EXPECT().Begin().Times(2)
+		mItemRepo.EXPECT().AssignTx(mTxManager).Times(2)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager).Times(2)
+		mReservationRepo.EXPECT().AssignTx(mTxManager).Times(2)
+		mMovementRepo.EXPECT().AssignTx(mTxManager).Times(2)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager).Times(2)
+		mReservationRepo.EXPECT().GetByIDForUpdate(ctx, broken.ID).Return(entity.Reservation{}, wannaErr)
+		mTxManager.EXPECT().Rollback()
+		mReservationRepo.EXPECT().GetByIDForUpdate(ctx, expired.ID).Return(expired, nil)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, item.ID).Return(item, nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, map[string]interface{}{
+			"current_stock_value":  uint64(3),
+			"reserved_stock_value": uint64(0),
+		}).Return(nil)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(2),
+			Reason: valueobject.MovementReasonRelease,
+			Delta:  2,
+		}).Return(nil)
+		mReservationRepo.EXPECT().Updates(ctx, &expired, map[string]interface{}{
+			"status": valueobject.ReservationStatusReleased,
+		}).Return(nil)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.ReleaseExpired(ctx, now)
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.ReleaseExpired() return an error:%v - want:%v", err, wannaErr)
+		}
+
+		if got != 1 {
+			t.Errorf("uc.ReleaseExpired() return:%d - want:1", got)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package interactor
+
+import (
+	"context"
+	"log"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+)
+
+// collectLowStockAlert append the low stock alert of item to alerts
+// when its current stock drops below the reorder threshold by changing to currentStockValue
+func collectLowStockAlert(alerts []entity.LowStockAlert, item entity.Item, currentStockValue uint64) []entity.LowStockAlert {
+	if !item.CrossesReorderThreshold(currentStockValue) {
+		return alerts
+	}
+
+	return append(alerts, entity.LowStockAlert{
+		ItemID:            item.ID,
+		CurrentStockValue: currentStockValue,
+		ReorderThreshold:  item.ReorderThreshold,
+	})
+}
+
+// notifyLowStock send the alerts after the stock change has been committed,
+// a failed alert is only logged so it never fails the purchase
+func notifyLowStock(ctx context.Context, notifier repository.StockAlertNotifier, alerts []entity.LowStockAlert) {
+	for _, alert := range alerts {
+		if err := notifier.NotifyLowStock(ctx, alert); err != nil {
+			log.Printf("failed to notify low stock of item:%d - %v\n", alert.ItemID, err)
+		}
+	}
+}
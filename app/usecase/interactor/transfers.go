@@ -0,0 +1,264 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase"
+	"github.com/tuanna7593/gosample/app/usecase/converter"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+// TransferUseCaseImpl implementation of Transfer usecase
+type TransferUseCaseImpl struct {
+	itemRepository              repository.ItemRepository
+	purchaseRepository          repository.PurchaseRepository
+	inventoryMovementRepository repository.InventoryMovementRepository
+	locationRepository          repository.LocationRepository
+	locationStockRepository     repository.LocationStockRepository
+	transferRepository          repository.TransferRepository
+	txManager                   repository.TransactionManager
+	allocationStrategy          valueobject.AllocationStrategy
+}
+
+// NewTransferUseCaseInteractor create new instance of Transfer interactor
+func NewTransferUseCaseInteractor(
+	itemRepo repository.ItemRepository,
+	purchaseRepo repository.PurchaseRepository,
+	movementRepo repository.InventoryMovementRepository,
+	locationRepo repository.LocationRepository,
+	locationStockRepo repository.LocationStockRepository,
+	transferRepo repository.TransferRepository,
+	txManager repository.TransactionManager,
+	allocationStrategy valueobject.AllocationStrategy,
+) usecase.TransferUseCase {
+	return &TransferUseCaseImpl{
+		itemRepository:              itemRepo,
+		purchaseRepository:          purchaseRepo,
+		inventoryMovementRepository: movementRepo,
+		locationRepository:          locationRepo,
+		locationStockRepository:     locationStockRepo,
+		transferRepository:          transferRepo,
+		txManager:                   txManager,
+		allocationStrategy:          allocationStrategy,
+	}
+}
+
+// Transfer move the quantity from the stock of item at the source location to its in transit stock
+func (uc TransferUseCaseImpl) Transfer(ctx context.Context, req payload.TransferRequest) (payload.Transfer, error) {
+	// start transaction
+	uc.txManager.Begin()
+
+	// assign tx to repositories
+	uc.itemRepository.AssignTx(uc.txManager)
+	uc.inventoryMovementRepository.AssignTx(uc.txManager)
+	uc.locationStockRepository.AssignTx(uc.txManager)
+	uc.transferRepository.AssignTx(uc.txManager)
+
+	var err error
+	defer func() {
+		if err != nil {
+			log.Printf("found error - rollback transaction:%v\n", err)
+			uc.txManager.Rollback()
+		}
+	}()
+
+	// find and lock item until the transaction ends
+	item, err := uc.itemRepository.GetByIDForUpdate(ctx, req.ItemID)
+	if err != nil {
+		log.Printf("failed to get item:%d\n", req.ItemID)
+		return payload.Transfer{}, err
+	}
+
+	if reflect.DeepEqual(item, entity.Item{}) || item.IsArchived() {
+		err = newNotFoundItemError(req.ItemID)
+		return payload.Transfer{}, err
+	}
+
+	for _, locationID := range []valueobject.LocationID{req.FromLocationID, req.ToLocationID} {
+		_, err = getLocation(ctx, uc.locationRepository, locationID)
+		if err != nil {
+			return payload.Transfer{}, err
+		}
+	}
+
+	located, err := takeStockAtLocation(ctx, uc.locationStockRepository, item, req.Quantity, req.FromLocationID)
+	if err != nil {
+		return payload.Transfer{}, err
+	}
+
+	updateValues := map[string]interface{}{
+		"current_stock_value":    item.CurrentStockValue - req.Quantity,
+		"located_stock_value":    located,
+		"in_transit_stock_value": item.InTransitStockValue + req.Quantity,
+	}
+	err = uc.itemRepository.Updates(ctx, &item, updateValues)
+	if err != nil {
+		log.Printf("failed to update stock of item:%d\n", item.ID)
+		return payload.Transfer{}, err
+	}
+
+	transfer := converter.ConvertTransferRequestToEntity(req)
+	err = uc.transferRepository.Create(ctx, &transfer)
+	if err != nil {
+		log.Printf("failed to create transfer:%+v\n", transfer)
+		return payload.Transfer{}, err
+	}
+
+	err = createInventoryMovement(
+		ctx, uc.inventoryMovementRepository,
+		item.ID, valueobject.MovementReasonTransferOut, -int64(req.Quantity),
+	)
+	if err != nil {
+		return payload.Transfer{}, err
+	}
+
+	// commit transaction
+	errCommit := uc.txManager.Commit()
+	if errCommit != nil {
+		log.Printf("failed to commit transaction:%+v\n", errCommit)
+		return payload.Transfer{}, errCommit
+	}
+
+	return converter.ConvertTransferEntityToPayload(transfer), nil
+}
+
+// GetTransfer get a transfer by id
+func (uc TransferUseCaseImpl) GetTransfer(ctx context.Context, transferID valueobject.TransferID) (payload.Transfer, error) {
+	transfer, err := uc.transferRepository.GetByID(ctx, transferID)
+	if err != nil {
+		log.Printf("failed to get transfer:%d\n", transferID)
+		return payload.Transfer{}, err
+	}
+
+	if reflect.DeepEqual(transfer, entity.Transfer{}) {
+		return payload.Transfer{}, newNotFoundTransferError(transferID)
+	}
+
+	return converter.ConvertTransferEntityToPayload(transfer), nil
+}
+
+// Receive move the quantity from the in transit stock of item to its stock at the destination location
+func (uc TransferUseCaseImpl) Receive(ctx context.Context, transferID valueobject.TransferID) (payload.Transfer, error) {
+	// start transaction
+	uc.txManager.Begin()
+
+	// assign tx to repositories
+	uc.itemRepository.AssignTx(uc.txManager)
+	uc.purchaseRepository.AssignTx(uc.txManager)
+	uc.inventoryMovementRepository.AssignTx(uc.txManager)
+	uc.locationStockRepository.AssignTx(uc.txManager)
+	uc.transferRepository.AssignTx(uc.txManager)
+
+	var err error
+	defer func() {
+		if err != nil {
+			log.Printf("found error - rollback transaction:%v\n", err)
+			uc.txManager.Rollback()
+		}
+	}()
+
+	// always lock the transfer before the item so a transfer cannot be received twice
+	transfer, err := uc.transferRepository.GetByIDForUpdate(ctx, transferID)
+	if err != nil {
+		log.Printf("failed to get transfer:%d\n", transferID)
+		return payload.Transfer{}, err
+	}
+
+	if reflect.DeepEqual(transfer, entity.Transfer{}) {
+		err = newNotFoundTransferError(transferID)
+		return payload.Transfer{}, err
+	}
+
+	if !transfer.IsInTransit() {
+		msg := fmt.Sprintf("the transfer is not in transit - transfer:%d - status:%s", transferID, transfer.Status)
+		log.Println(msg)
+		err = payload.Error{
+			Code:    payload.ErrCodeTransferNotInTransit,
+			Message: msg,
+			Param:   transferID,
+			Type:    payload.ErrorTypeConflict,
+		}
+		return payload.Transfer{}, err
+	}
+
+	item, err := uc.itemRepository.GetByIDForUpdate(ctx, transfer.ItemID)
+	if err != nil {
+		log.Printf("failed to get item:%d\n", transfer.ItemID)
+		return payload.Transfer{}, err
+	}
+
+	located, err := putStock(ctx, uc.locationStockRepository, item, transfer.Quantity, transfer.ToLocationID)
+	if err != nil {
+		return payload.Transfer{}, err
+	}
+
+	// received the stock of item after the receipt, the queued backorders are fulfilled from it
+	received := item
+	received.CurrentStockValue += transfer.Quantity
+	received.LocatedStockValue = located
+	received.InTransitStockValue -= transfer.Quantity
+	updateValues := map[string]interface{}{
+		"current_stock_value":    received.CurrentStockValue,
+		"located_stock_value":    received.LocatedStockValue,
+		"in_transit_stock_value": received.InTransitStockValue,
+	}
+	err = uc.itemRepository.Updates(ctx, &item, updateValues)
+	if err != nil {
+		log.Printf("failed to update stock of item:%d\n", item.ID)
+		return payload.Transfer{}, err
+	}
+
+	err = uc.transferRepository.Updates(ctx, &transfer, map[string]interface{}{
+		"status":      valueobject.TransferStatusReceived,
+		"received_at": time.Now(),
+	})
+	if err != nil {
+		log.Printf("failed to receive transfer:%d\n", transferID)
+		return payload.Transfer{}, err
+	}
+
+	err = createInventoryMovement(
+		ctx, uc.inventoryMovementRepository,
+		item.ID, valueobject.MovementReasonTransferIn, int64(transfer.Quantity),
+	)
+	if err != nil {
+		return payload.Transfer{}, err
+	}
+
+	// the received stock is taken by the queued backorders first
+	err = fulfillQueuedBackorders(
+		ctx, uc.itemRepository, uc.purchaseRepository, uc.inventoryMovementRepository,
+		uc.locationStockRepository, uc.allocationStrategy, &item, received,
+	)
+	if err != nil {
+		return payload.Transfer{}, err
+	}
+
+	// commit transaction
+	errCommit := uc.txManager.Commit()
+	if errCommit != nil {
+		log.Printf("failed to commit transaction:%+v\n", errCommit)
+		return payload.Transfer{}, errCommit
+	}
+
+	return converter.ConvertTransferEntityToPayload(transfer), nil
+}
+
+// newNotFoundTransferError create the not found error of transfer
+func newNotFoundTransferError(transferID valueobject.TransferID) payload.Error {
+	msg := fmt.Sprintf("not found transfer:%d", transferID)
+	log.Println(msg)
+	return payload.Error{
+		Code:    payload.ErrCodeNotFoundTransfer,
+		Message: msg,
+		Param:   transferID,
+		Type:    payload.ErrorTypeNotFound,
+	}
+}
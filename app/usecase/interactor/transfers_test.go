@@ -0,0 +1,400 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tuanna7593/gosample/app/domain/entity"
+	"github.com/tuanna7593/gosample/app/domain/repository/mock"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/usecase/payload"
+)
+
+func TestTransferUseCaseImpl_Transfer(t *testing.T) {
+	t.Run("#1: Not found destination location", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationRepo := mock.NewMockLocationRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTransferRepo := mock.NewMockTransferRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := TransferUseCaseImpl{
+			itemRepository:              mItemRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationRepository:          mLocationRepo,
+			locationStockRepository:     mLocationStockRepo,
+			transferRepository:          mTransferRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.TransferRequest{
+			ItemID:         valueobject.ItemID(1),
+			FromLocationID: valueobject.LocationID(2),
+			ToLocationID:   valueobject.LocationID(3),
+			Quantity:       2,
+		}
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   5,
+			CurrentStockValue: 5,
+			LocatedStockValue: 5,
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mTransferRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mLocationRepo.EXPECT().GetByID(ctx, req.FromLocationID).Return(entity.Location{ID: req.FromLocationID, Code: "HN-01"}, nil)
+		mLocationRepo.EXPECT().GetByID(ctx, req.ToLocationID).Return(entity.Location{}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundLocation,
+			Message: "not found location:3",
+			Param:   valueobject.LocationID(3),
+			Type:    payload.ErrorTypeNotFound,
+		}
+		_, err := uc.Transfer(ctx, req)
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Transfer() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Out of stock at source location", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationRepo := mock.NewMockLocationRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTransferRepo := mock.NewMockTransferRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := TransferUseCaseImpl{
+			itemRepository:              mItemRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationRepository:          mLocationRepo,
+			locationStockRepository:     mLocationStockRepo,
+			transferRepository:          mTransferRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.TransferRequest{
+			ItemID:         valueobject.ItemID(1),
+			FromLocationID: valueobject.LocationID(2),
+			ToLocationID:   valueobject.LocationID(3),
+			Quantity:       2,
+		}
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   5,
+			CurrentStockValue: 5,
+			LocatedStockValue: 1,
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mTransferRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mLocationRepo.EXPECT().GetByID(ctx, req.FromLocationID).Return(entity.Location{ID: req.FromLocationID, Code: "HN-01"}, nil)
+		mLocationRepo.EXPECT().GetByID(ctx, req.ToLocationID).Return(entity.Location{ID: req.ToLocationID, Code: "HN-02"}, nil)
+		mLocationStockRepo.EXPECT().GetForUpdate(ctx, req.ItemID, req.FromLocationID).Return(entity.LocationStock{
+			ID:                valueobject.LocationStockID(1),
+			ItemID:            req.ItemID,
+			LocationID:        req.FromLocationID,
+			CurrentStockValue: 1,
+		}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeOutOfStock,
+			Message: "the item out of stock at location:2 - current quantity:1 - request quantity:2",
+			Param:   uint64(2),
+			Type:    payload.ErrorTypeBadRequest,
+		}
+		_, err := uc.Transfer(ctx, req)
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Transfer() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#3: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationRepo := mock.NewMockLocationRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTransferRepo := mock.NewMockTransferRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := TransferUseCaseImpl{
+			itemRepository:              mItemRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationRepository:          mLocationRepo,
+			locationStockRepository:     mLocationStockRepo,
+			transferRepository:          mTransferRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		req := payload.TransferRequest{
+			ItemID:         valueobject.ItemID(1),
+			FromLocationID: valueobject.LocationID(2),
+			ToLocationID:   valueobject.LocationID(3),
+			Quantity:       2,
+		}
+		item := entity.Item{
+			ID:                valueobject.ItemID(1),
+			TotalStockValue:   5,
+			CurrentStockValue: 5,
+			LocatedStockValue: 3,
+		}
+		stock := entity.LocationStock{
+			ID:                valueobject.LocationStockID(1),
+			ItemID:            req.ItemID,
+			LocationID:        req.FromLocationID,
+			CurrentStockValue: 3,
+		}
+		sentAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mTransferRepo.EXPECT().AssignTx(mTxManager)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, req.ItemID).Return(item, nil)
+		mLocationRepo.EXPECT().GetByID(ctx, req.FromLocationID).Return(entity.Location{ID: req.FromLocationID, Code: "HN-01"}, nil)
+		mLocationRepo.EXPECT().GetByID(ctx, req.ToLocationID).Return(entity.Location{ID: req.ToLocationID, Code: "HN-02"}, nil)
+		mLocationStockRepo.EXPECT().GetForUpdate(ctx, req.ItemID, req.FromLocationID).Return(stock, nil)
+		mLocationStockRepo.EXPECT().Updates(ctx, &stock, map[string]interface{}{
+			"current_stock_value": uint64(1),
+		}).Return(nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, map[string]interface{}{
+			"current_stock_value":    uint64(3),
+			"located_stock_value":    uint64(1),
+			"in_transit_stock_value": uint64(2),
+		}).Return(nil)
+		mTransferRepo.EXPECT().Create(ctx, &entity.Transfer{
+			ItemID:         req.ItemID,
+			FromLocationID: req.FromLocationID,
+			ToLocationID:   req.ToLocationID,
+			Quantity:       2,
+			Status:         valueobject.TransferStatusInTransit,
+		}).DoAndReturn(func(_ context.Context, transfer *entity.Transfer) error {
+			transfer.ID = valueobject.TransferID(1)
+			transfer.CreatedAt = sentAt
+			return nil
+		})
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(1),
+			Reason: valueobject.MovementReasonTransferOut,
+			Delta:  -2,
+		}).Return(nil)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.Transfer(ctx, req)
+		if err != nil {
+			t.Errorf("uc.Transfer() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Transfer{
+			ID:             valueobject.TransferID(1),
+			ItemID:         valueobject.ItemID(1),
+			FromLocationID: valueobject.LocationID(2),
+			ToLocationID:   valueobject.LocationID(3),
+			Quantity:       2,
+			Status:         valueobject.TransferStatusInTransit,
+			SentAt:         sentAt,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestTransferUseCaseImpl_GetTransfer(t *testing.T) {
+	t.Run("#1: Not found transfer", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mTransferRepo := mock.NewMockTransferRepository(mockCtrl)
+
+		uc := TransferUseCaseImpl{
+			transferRepository: mTransferRepo,
+		}
+		ctx := context.Background()
+		mTransferRepo.EXPECT().GetByID(ctx, valueobject.TransferID(1)).Return(entity.Transfer{}, nil)
+
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeNotFoundTransfer,
+			Message: "not found transfer:1",
+			Param:   valueobject.TransferID(1),
+			Type:    payload.ErrorTypeNotFound,
+		}
+		_, err := uc.GetTransfer(ctx, valueobject.TransferID(1))
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.GetTransfer() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+}
+
+func TestTransferUseCaseImpl_Receive(t *testing.T) {
+	t.Run("#1: Transfer has been received", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTransferRepo := mock.NewMockTransferRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := TransferUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			transferRepository:          mTransferRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mTransferRepo.EXPECT().AssignTx(mTxManager)
+		mTransferRepo.EXPECT().GetByIDForUpdate(ctx, valueobject.TransferID(1)).Return(entity.Transfer{
+			ID:             valueobject.TransferID(1),
+			ItemID:         valueobject.ItemID(2),
+			FromLocationID: valueobject.LocationID(3),
+			ToLocationID:   valueobject.LocationID(4),
+			Quantity:       2,
+			Status:         valueobject.TransferStatusReceived,
+		}, nil)
+		mTxManager.EXPECT().Rollback()
+
+		wannaErr := payload.Error{
+			Code:    payload.ErrCodeTransferNotInTransit,
+			Message: "the transfer is not in transit - transfer:1 - status:received",
+			Param:   valueobject.TransferID(1),
+			Type:    payload.ErrorTypeConflict,
+		}
+		_, err := uc.Receive(ctx, valueobject.TransferID(1))
+		if !errors.Is(err, wannaErr) {
+			t.Errorf("uc.Receive() return an error:%v - want:%v", err, wannaErr)
+		}
+	})
+
+	t.Run("#2: Success", func(t *testing.T) {
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mItemRepo := mock.NewMockItemRepository(mockCtrl)
+		mPurchaseRepo := mock.NewMockPurchaseRepository(mockCtrl)
+		mMovementRepo := mock.NewMockInventoryMovementRepository(mockCtrl)
+		mLocationStockRepo := mock.NewMockLocationStockRepository(mockCtrl)
+		mTransferRepo := mock.NewMockTransferRepository(mockCtrl)
+		mTxManager := mock.NewMockTransactionManager(mockCtrl)
+
+		uc := TransferUseCaseImpl{
+			itemRepository:              mItemRepo,
+			purchaseRepository:          mPurchaseRepo,
+			inventoryMovementRepository: mMovementRepo,
+			locationStockRepository:     mLocationStockRepo,
+			transferRepository:          mTransferRepo,
+			txManager:                   mTxManager,
+		}
+		ctx := context.Background()
+		sentAt := time.Date(2021, 10, 16, 10, 0, 0, 0, time.Local)
+		receivedAt := time.Date(2021, 10, 17, 10, 0, 0, 0, time.Local)
+		transfer := entity.Transfer{
+			ID:             valueobject.TransferID(1),
+			CreatedAt:      sentAt,
+			ItemID:         valueobject.ItemID(2),
+			FromLocationID: valueobject.LocationID(3),
+			ToLocationID:   valueobject.LocationID(4),
+			Quantity:       2,
+			Status:         valueobject.TransferStatusInTransit,
+		}
+		item := entity.Item{
+			ID:                  valueobject.ItemID(2),
+			TotalStockValue:     5,
+			CurrentStockValue:   3,
+			LocatedStockValue:   1,
+			InTransitStockValue: 2,
+		}
+		mTxManager.EXPECT().Begin()
+		mItemRepo.EXPECT().AssignTx(mTxManager)
+		mPurchaseRepo.EXPECT().AssignTx(mTxManager)
+		mMovementRepo.EXPECT().AssignTx(mTxManager)
+		mLocationStockRepo.EXPECT().AssignTx(mTxManager)
+		mTransferRepo.EXPECT().AssignTx(mTxManager)
+		mTransferRepo.EXPECT().GetByIDForUpdate(ctx, transfer.ID).Return(transfer, nil)
+		mItemRepo.EXPECT().GetByIDForUpdate(ctx, item.ID).Return(item, nil)
+		mLocationStockRepo.EXPECT().GetForUpdate(ctx, item.ID, transfer.ToLocationID).Return(entity.LocationStock{}, nil)
+		mLocationStockRepo.EXPECT().Create(ctx, &entity.LocationStock{
+			ItemID:            item.ID,
+			LocationID:        transfer.ToLocationID,
+			CurrentStockValue: 2,
+		}).Return(nil)
+		mItemRepo.EXPECT().Updates(ctx, &item, map[string]interface{}{
+			"current_stock_value":    uint64(5),
+			"located_stock_value":    uint64(3),
+			"in_transit_stock_value": uint64(0),
+		}).Return(nil)
+		mTransferRepo.EXPECT().Updates(ctx, &transfer, gomock.Any()).DoAndReturn(
+			func(_ context.Context, tr *entity.Transfer, _ map[string]interface{}) error {
+				tr.Status = valueobject.TransferStatusReceived
+				tr.ReceivedAt = &receivedAt
+				return nil
+			},
+		)
+		mMovementRepo.EXPECT().Create(ctx, &entity.InventoryMovement{
+			ItemID: valueobject.ItemID(2),
+			Reason: valueobject.MovementReasonTransferIn,
+			Delta:  2,
+		}).Return(nil)
+		mTxManager.EXPECT().Commit().Return(nil)
+
+		got, err := uc.Receive(ctx, transfer.ID)
+		if err != nil {
+			t.Errorf("uc.Receive() return an error:%v - want:nil", err)
+			return
+		}
+
+		want := payload.Transfer{
+			ID:             valueobject.TransferID(1),
+			ItemID:         valueobject.ItemID(2),
+			FromLocationID: valueobject.LocationID(3),
+			ToLocationID:   valueobject.LocationID(4),
+			Quantity:       2,
+			Status:         valueobject.TransferStatusReceived,
+			SentAt:         sentAt,
+			ReceivedAt:     &receivedAt,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
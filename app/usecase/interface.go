@@ -2,12 +2,118 @@ package usecase
 
 import (
 	"context"
+	"time"
 
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
 	"github.com/tuanna7593/gosample/app/usecase/payload"
 )
 
 type ItemUseCase interface {
+	// Create create an item, it is a variant of a product when the product id is set
 	Create(ctx context.Context, item payload.CreateItemRequest) (payload.Item, error)
-	List(ctx context.Context, pagination payload.PaginationRequest) ([]payload.Item, error)
+	List(ctx context.Context, filter payload.ItemFilter, pagination payload.PaginationRequest) ([]payload.Item, error)
+	Count(ctx context.Context, filter payload.ItemFilter) (int64, error)
+	GetItem(ctx context.Context, itemID valueobject.ItemID) (payload.Item, error)
+	GetItemBySKU(ctx context.Context, sku string) (payload.Item, error)
+	UpdateItem(ctx context.Context, req payload.UpdateItemRequest) (payload.Item, error)
+	DeleteItem(ctx context.Context, itemID valueobject.ItemID) error
 	BuyItem(ctx context.Context, req payload.PurchaseRequest) (payload.Purchase, error)
 }
+
+type ItemSearchUseCase interface {
+	// Search get the items matched the words of the query ordered by relevance
+	Search(ctx context.Context, req payload.ItemSearchRequest, pagination payload.PaginationRequest) ([]payload.ItemSearchHit, error)
+}
+
+type IdempotencyUseCase interface {
+	// Start return the stored response when the request is a replay, otherwise nil is returned and the key is reserved
+	Start(ctx context.Context, req payload.IdempotencyRequest) (*payload.IdempotentResponse, error)
+	Complete(ctx context.Context, req payload.IdempotencyRequest, resp payload.IdempotentResponse) error
+	// Release remove the reserved key so the request can be retried
+	Release(ctx context.Context, req payload.IdempotencyRequest) error
+	// PurgeExpired remove the expired keys, the number of removed keys is returned
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type InventoryUseCase interface {
+	Restock(ctx context.Context, req payload.RestockRequest) (payload.Item, error)
+	// Adjust correct the stock of item by a signed delta and record who made the change and why
+	Adjust(ctx context.Context, req payload.AdjustmentRequest) (payload.Item, error)
+	ListMovements(ctx context.Context, itemID valueobject.ItemID, pagination payload.PaginationRequest) ([]payload.InventoryMovement, error)
+	CountMovements(ctx context.Context, itemID valueobject.ItemID) (int64, error)
+}
+
+type ReservationUseCase interface {
+	Reserve(ctx context.Context, req payload.ReservationRequest) (payload.Reservation, error)
+	GetReservation(ctx context.Context, reservationID valueobject.ReservationID) (payload.Reservation, error)
+	// Confirm turn the held quantity into a purchase
+	Confirm(ctx context.Context, reservationID valueobject.ReservationID) (payload.Purchase, error)
+	// Cancel return the held quantity to the current stock
+	Cancel(ctx context.Context, reservationID valueobject.ReservationID) (payload.Reservation, error)
+	// ReleaseExpired return the held quantity of expired reservations to the current stock,
+	// the number of released reservations is returned
+	ReleaseExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+type OrderUseCase interface {
+	PlaceOrder(ctx context.Context, req payload.OrderRequest) (payload.Order, error)
+	GetOrder(ctx context.Context, orderID valueobject.OrderID) (payload.Order, error)
+}
+
+type BundleUseCase interface {
+	// Create create a bundle of the items which are not archived
+	Create(ctx context.Context, req payload.CreateBundleRequest) (payload.Bundle, error)
+	// GetBundle get a bundle with the quantity which can be made from the current stock of its components
+	GetBundle(ctx context.Context, bundleID valueobject.BundleID) (payload.Bundle, error)
+	// BuyBundle take the stock of all the components in one transaction and record it as an order of them
+	BuyBundle(ctx context.Context, req payload.BuyBundleRequest) (payload.Order, error)
+}
+
+type PurchaseUseCase interface {
+	List(ctx context.Context, filter payload.PurchaseFilter, pagination payload.PaginationRequest) ([]payload.Purchase, error)
+	Count(ctx context.Context, filter payload.PurchaseFilter) (int64, error)
+	GetPurchase(ctx context.Context, purchaseID valueobject.PurchaseID) (payload.Purchase, error)
+	RefundPurchase(ctx context.Context, req payload.RefundRequest) (payload.Purchase, error)
+}
+
+type LocationUseCase interface {
+	Create(ctx context.Context, req payload.CreateLocationRequest) (payload.Location, error)
+	List(ctx context.Context) ([]payload.Location, error)
+}
+
+type CategoryUseCase interface {
+	Create(ctx context.Context, req payload.CreateCategoryRequest) (payload.Category, error)
+	List(ctx context.Context) ([]payload.Category, error)
+	GetCategory(ctx context.Context, categoryID valueobject.CategoryID) (payload.Category, error)
+	// UpdateCategory rename the category and move it under another parent
+	UpdateCategory(ctx context.Context, req payload.UpdateCategoryRequest) (payload.Category, error)
+	// DeleteCategory delete a category which has no children, its items are unassigned from it
+	DeleteCategory(ctx context.Context, categoryID valueobject.CategoryID) error
+	// AssignItem replace the categories of an item
+	AssignItem(ctx context.Context, req payload.ItemCategoriesRequest) ([]payload.Category, error)
+	ListByItem(ctx context.Context, itemID valueobject.ItemID) ([]payload.Category, error)
+}
+
+type ItemAttributeUseCase interface {
+	AddTag(ctx context.Context, req payload.ItemTagRequest) error
+	RemoveTag(ctx context.Context, req payload.ItemTagRequest) error
+	ListTags(ctx context.Context, itemID valueobject.ItemID) ([]string, error)
+	// SetAttribute set the value of an attribute key of an item, the previous value is replaced
+	SetAttribute(ctx context.Context, req payload.ItemAttributeRequest) (payload.ItemAttribute, error)
+	RemoveAttribute(ctx context.Context, req payload.ItemAttributeRequest) error
+	ListAttributes(ctx context.Context, itemID valueobject.ItemID) ([]payload.ItemAttribute, error)
+}
+
+type ProductUseCase interface {
+	Create(ctx context.Context, req payload.CreateProductRequest) (payload.Product, error)
+	// GetProduct get a product with its variants and their aggregate stock
+	GetProduct(ctx context.Context, productID valueobject.ProductID) (payload.Product, error)
+}
+
+type TransferUseCase interface {
+	// Transfer take the quantity from a location and keep it in transit until the transfer is received
+	Transfer(ctx context.Context, req payload.TransferRequest) (payload.Transfer, error)
+	GetTransfer(ctx context.Context, transferID valueobject.TransferID) (payload.Transfer, error)
+	// Receive put the quantity in transit at the destination location
+	Receive(ctx context.Context, transferID valueobject.TransferID) (payload.Transfer, error)
+}
@@ -0,0 +1,33 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type CreateBundleRequest struct {
+	Name       string
+	Components []BundleComponent
+}
+
+// BundleComponent the quantity of the item in one bundle
+type BundleComponent struct {
+	ItemID   valueobject.ItemID
+	Quantity uint64
+}
+
+// Bundle AvailableQuantity is the number of bundles which can be made from the current stock of components
+type Bundle struct {
+	ID                valueobject.BundleID
+	Name              string
+	Components        []BundleComponent
+	AvailableQuantity uint64
+	CreatedAt         time.Time
+}
+
+// BuyBundleRequest the quantity of each component is multiplied by Quantity
+type BuyBundleRequest struct {
+	BundleID valueobject.BundleID
+	Quantity uint64
+}
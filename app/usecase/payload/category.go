@@ -0,0 +1,33 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// CreateCategoryRequest the category is a root category when ParentID is nil
+type CreateCategoryRequest struct {
+	Name     string
+	ParentID *valueobject.CategoryID
+}
+
+// UpdateCategoryRequest the category is moved to the root when ParentID is nil
+type UpdateCategoryRequest struct {
+	CategoryID valueobject.CategoryID
+	Name       string
+	ParentID   *valueobject.CategoryID
+}
+
+// ItemCategoriesRequest the categories of item are replaced with CategoryIDs
+type ItemCategoriesRequest struct {
+	ItemID      valueobject.ItemID
+	CategoryIDs []valueobject.CategoryID
+}
+
+type Category struct {
+	ID        valueobject.CategoryID
+	Name      string
+	ParentID  *valueobject.CategoryID
+	CreatedAt time.Time
+}
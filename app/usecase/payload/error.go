@@ -8,24 +8,126 @@ const (
 	ErrorTypeInvalidArgument ErrorType = "invalid argument"
 	ErrorTypeNotFound        ErrorType = "not found"
 	ErrorTypeBadRequest      ErrorType = "bad request"
+	// ErrorTypePreconditionFailed the resource has been changed since the client read it
+	ErrorTypePreconditionFailed ErrorType = "precondition failed"
+	ErrorTypeConflict           ErrorType = "conflict"
+	ErrorTypeUnprocessable      ErrorType = "unprocessable entity"
 )
 
 type ErrorCode string
 
 const (
 	// error code of item
-	ErrCodeInvalidItemID          ErrorCode = "ERR_INVALID_ITEM_ID"
-	ErrCodeInvalidTotalStockValue ErrorCode = "ERR_INVALID_TOTAL_STOCK_VALUE"
-	ErrCodeInvalidSellingPrice    ErrorCode = "ERR_INVALID_SELLING_PRICE"
-	ErrCodeNotFoundItem           ErrorCode = "ERR_NOT_FOUMD_ITEM"
+	ErrCodeInvalidItemID           ErrorCode = "ERR_INVALID_ITEM_ID"
+	ErrCodeInvalidTotalStockValue  ErrorCode = "ERR_INVALID_TOTAL_STOCK_VALUE"
+	ErrCodeInvalidSellingPrice     ErrorCode = "ERR_INVALID_SELLING_PRICE"
+	ErrCodeNotFoundItem            ErrorCode = "ERR_NOT_FOUMD_ITEM"
+	ErrCodeTotalStockBelowSold     ErrorCode = "ERR_TOTAL_STOCK_BELOW_SOLD"
+	ErrCodeArchivedItem            ErrorCode = "ERR_ARCHIVED_ITEM"
+	ErrCodeItemVersionMismatch     ErrorCode = "ERR_ITEM_VERSION_MISMATCH"
+	ErrCodeInvalidReorderThreshold ErrorCode = "ERR_INVALID_REORDER_THRESHOLD"
+	ErrCodeInvalidBackorderable    ErrorCode = "ERR_INVALID_BACKORDERABLE"
+	ErrCodeInvalidSKU              ErrorCode = "ERR_INVALID_SKU"
+	ErrCodeInvalidItemName         ErrorCode = "ERR_INVALID_ITEM_NAME"
+	ErrCodeInvalidItemDescription  ErrorCode = "ERR_INVALID_ITEM_DESCRIPTION"
+	ErrCodeSKUExists               ErrorCode = "ERR_SKU_EXISTS"
+	ErrCodeUnknownItemField        ErrorCode = "ERR_UNKNOWN_ITEM_FIELD"
+
+	// error code of item filter
+	ErrCodeInvalidPriceRange ErrorCode = "ERR_INVALID_PRICE_RANGE"
+	ErrCodeInvalidInStock    ErrorCode = "ERR_INVALID_IN_STOCK"
+	ErrCodeInvalidSort       ErrorCode = "ERR_INVALID_SORT"
+
+	// error code of item tag and attribute
+	ErrCodeInvalidTag            ErrorCode = "ERR_INVALID_TAG"
+	ErrCodeInvalidAttributeKey   ErrorCode = "ERR_INVALID_ATTRIBUTE_KEY"
+	ErrCodeInvalidAttributeValue ErrorCode = "ERR_INVALID_ATTRIBUTE_VALUE"
+
+	// error code of item search
+	ErrCodeInvalidSearchQuery ErrorCode = "ERR_INVALID_SEARCH_QUERY"
 
 	// error code of pagination
-	ErrCodeInvalidPage  ErrorCode = "ERR_INVALID_PAGE"
-	ErrCodeInvalidLimit ErrorCode = "ERR_INVALID_LIMIT"
+	ErrCodeInvalidPage   ErrorCode = "ERR_INVALID_PAGE"
+	ErrCodeInvalidLimit  ErrorCode = "ERR_INVALID_LIMIT"
+	ErrCodeInvalidCursor ErrorCode = "ERR_INVALID_CURSOR"
+
+	ErrCodeInvalidIncludeTotal ErrorCode = "ERR_INVALID_INCLUDE_TOTAL"
 
 	// error code of buy item
 	ErrCodeInvalidBuyQuantity ErrorCode = "ERR_INVALID_BUY_QUANTITY"
 	ErrCodeOutOfStock         ErrorCode = "ERR_OUT_OF_STOCK"
+	ErrCodeBackordersQueued   ErrorCode = "ERR_BACKORDERS_QUEUED"
+
+	// error code of purchase
+	ErrCodeInvalidPurchaseID ErrorCode = "ERR_INVALID_PURCHASE_ID"
+	ErrCodeNotFoundPurchase  ErrorCode = "ERR_NOT_FOUND_PURCHASE"
+	ErrCodeInvalidDateRange  ErrorCode = "ERR_INVALID_DATE_RANGE"
+
+	// error code of idempotency key
+	ErrCodeInvalidIdempotencyKey    ErrorCode = "ERR_INVALID_IDEMPOTENCY_KEY"
+	ErrCodeIdempotencyKeyReused     ErrorCode = "ERR_IDEMPOTENCY_KEY_REUSED"
+	ErrCodeIdempotencyKeyInProgress ErrorCode = "ERR_IDEMPOTENCY_KEY_IN_PROGRESS"
+
+	// error code of refund purchase
+	ErrCodeInvalidRefundQuantity  ErrorCode = "ERR_INVALID_REFUND_QUANTITY"
+	ErrCodeAlreadyRefunded        ErrorCode = "ERR_ALREADY_REFUNDED"
+	ErrCodeRefundQuantityExceeded ErrorCode = "ERR_REFUND_QUANTITY_EXCEEDED"
+	ErrCodePurchaseBackordered    ErrorCode = "ERR_PURCHASE_BACKORDERED"
+
+	// error code of restock
+	ErrCodeInvalidRestockQuantity ErrorCode = "ERR_INVALID_RESTOCK_QUANTITY"
+
+	// error code of stock adjustment
+	ErrCodeInvalidAdjustmentDelta      ErrorCode = "ERR_INVALID_ADJUSTMENT_DELTA"
+	ErrCodeInvalidAdjustmentReasonCode ErrorCode = "ERR_INVALID_ADJUSTMENT_REASON_CODE"
+	ErrCodeInvalidAdjustmentActor      ErrorCode = "ERR_INVALID_ADJUSTMENT_ACTOR"
+	ErrCodeInvalidAdjustmentNote       ErrorCode = "ERR_INVALID_ADJUSTMENT_NOTE"
+	ErrCodeAdjustmentBelowZero         ErrorCode = "ERR_ADJUSTMENT_BELOW_ZERO"
+
+	// error code of reservation
+	ErrCodeInvalidReservationID  ErrorCode = "ERR_INVALID_RESERVATION_ID"
+	ErrCodeInvalidReservationTTL ErrorCode = "ERR_INVALID_RESERVATION_TTL"
+	ErrCodeNotFoundReservation   ErrorCode = "ERR_NOT_FOUND_RESERVATION"
+	ErrCodeReservationNotHeld    ErrorCode = "ERR_RESERVATION_NOT_HELD"
+	ErrCodeReservationExpired    ErrorCode = "ERR_RESERVATION_EXPIRED"
+
+	// error code of location
+	ErrCodeInvalidLocationID   ErrorCode = "ERR_INVALID_LOCATION_ID"
+	ErrCodeInvalidLocationCode ErrorCode = "ERR_INVALID_LOCATION_CODE"
+	ErrCodeInvalidLocationName ErrorCode = "ERR_INVALID_LOCATION_NAME"
+	ErrCodeNotFoundLocation    ErrorCode = "ERR_NOT_FOUND_LOCATION"
+	ErrCodeLocationCodeExists  ErrorCode = "ERR_LOCATION_CODE_EXISTS"
+
+	// error code of category
+	ErrCodeInvalidCategoryID     ErrorCode = "ERR_INVALID_CATEGORY_ID"
+	ErrCodeInvalidCategoryName   ErrorCode = "ERR_INVALID_CATEGORY_NAME"
+	ErrCodeInvalidCategoryParent ErrorCode = "ERR_INVALID_CATEGORY_PARENT"
+	ErrCodeNotFoundCategory      ErrorCode = "ERR_NOT_FOUND_CATEGORY"
+	ErrCodeCategoryHasChildren   ErrorCode = "ERR_CATEGORY_HAS_CHILDREN"
+
+	// error code of product
+	ErrCodeInvalidProductID          ErrorCode = "ERR_INVALID_PRODUCT_ID"
+	ErrCodeInvalidProductName        ErrorCode = "ERR_INVALID_PRODUCT_NAME"
+	ErrCodeInvalidProductDescription ErrorCode = "ERR_INVALID_PRODUCT_DESCRIPTION"
+	ErrCodeNotFoundProduct           ErrorCode = "ERR_NOT_FOUND_PRODUCT"
+
+	// error code of transfer
+	ErrCodeInvalidTransferID       ErrorCode = "ERR_INVALID_TRANSFER_ID"
+	ErrCodeInvalidTransferQuantity ErrorCode = "ERR_INVALID_TRANSFER_QUANTITY"
+	ErrCodeSameTransferLocation    ErrorCode = "ERR_SAME_TRANSFER_LOCATION"
+	ErrCodeNotFoundTransfer        ErrorCode = "ERR_NOT_FOUND_TRANSFER"
+	ErrCodeTransferNotInTransit    ErrorCode = "ERR_TRANSFER_NOT_IN_TRANSIT"
+
+	// error code of order
+	ErrCodeInvalidOrderID    ErrorCode = "ERR_INVALID_ORDER_ID"
+	ErrCodeInvalidOrderLines ErrorCode = "ERR_INVALID_ORDER_LINES"
+	ErrCodeNotFoundOrder     ErrorCode = "ERR_NOT_FOUND_ORDER"
+
+	// error code of bundle
+	ErrCodeInvalidBundleID         ErrorCode = "ERR_INVALID_BUNDLE_ID"
+	ErrCodeInvalidBundleName       ErrorCode = "ERR_INVALID_BUNDLE_NAME"
+	ErrCodeInvalidBundleComponents ErrorCode = "ERR_INVALID_BUNDLE_COMPONENTS"
+	ErrCodeNotFoundBundle          ErrorCode = "ERR_NOT_FOUND_BUNDLE"
 )
 
 type Error struct {
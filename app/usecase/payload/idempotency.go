@@ -0,0 +1,16 @@
+package payload
+
+import "time"
+
+// IdempotencyRequest RequestHash identifies the method, path and body of request
+type IdempotencyRequest struct {
+	Key         string
+	RequestHash string
+	RequestedAt time.Time
+}
+
+type IdempotentResponse struct {
+	StatusCode int
+	Header     map[string][]string
+	Body       []byte
+}
@@ -0,0 +1,39 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type InventoryMovement struct {
+	ID         valueobject.InventoryMovementID
+	ItemID     valueobject.ItemID
+	Reason     valueobject.MovementReason
+	Delta      int64
+	ReasonCode valueobject.AdjustmentReasonCode
+	Actor      string
+	Note       string
+	MovedAt    time.Time
+}
+
+// RestockRequest the item is only restocked when its version equals ExpectedVersion if it is set,
+// the quantity is kept at LocationID if it is set, otherwise it is unassigned
+type RestockRequest struct {
+	ItemID          valueobject.ItemID
+	Quantity        uint64
+	LocationID      *valueobject.LocationID
+	ExpectedVersion *uint64
+}
+
+// AdjustmentRequest a manual correction of the stock of item,
+// a negative Delta removes stock and a positive Delta adds stock at LocationID if it is set
+type AdjustmentRequest struct {
+	ItemID          valueobject.ItemID
+	Delta           int64
+	LocationID      *valueobject.LocationID
+	ReasonCode      valueobject.AdjustmentReasonCode
+	Actor           string
+	Note            string
+	ExpectedVersion *uint64
+}
@@ -7,17 +7,86 @@ import (
 	"github.com/tuanna7593/gosample/app/domain/valueobject"
 )
 
+// CreateItemRequest the item is created as a variant of the product if ProductID is set
 type CreateItemRequest struct {
-	TotalStockValue uint64
-	SellingPrice    decimal.Decimal
+	ProductID        *valueobject.ProductID
+	SKU              string
+	Name             string
+	Description      string
+	TotalStockValue  uint64
+	ReorderThreshold uint64
+	Backorderable    bool
+	SellingPrice     decimal.Decimal
+}
+
+// UpdateItemRequest nil fields are left unchanged,
+// the item is only updated when its version equals ExpectedVersion if it is set
+type UpdateItemRequest struct {
+	ItemID           valueobject.ItemID
+	TotalStockValue  *uint64
+	ReorderThreshold *uint64
+	Backorderable    *bool
+	SellingPrice     *decimal.Decimal
+	ExpectedVersion  *uint64
 }
 
 type Item struct {
-	ID                valueobject.ItemID
-	TotalStockValue   uint64
-	CurrentStockValue uint64
-	SellingPrice      decimal.Decimal
-	PlacedAt          time.Time
+	ID                 valueobject.ItemID
+	ProductID          *valueobject.ProductID
+	SKU                string
+	Name               string
+	Description        string
+	TotalStockValue    uint64
+	CurrentStockValue  uint64
+	ReservedStockValue uint64
+	// LocatedStockValue and UnassignedStockValue split the current stock,
+	// Locations is the stock at each location which sums to LocatedStockValue
+	LocatedStockValue    uint64
+	UnassignedStockValue uint64
+	InTransitStockValue  uint64
+	// BackorderedStockValue the quantity of the backordered purchases which wait for the stock
+	BackorderedStockValue uint64
+	Backorderable         bool
+	ReorderThreshold      uint64
+	Locations             []ItemLocationStock
+	SellingPrice          decimal.Decimal
+	PlacedAt              time.Time
+	Version               uint64
 }
 
 type Items []Item
+
+// ItemFilter zero values are ignored, items are sorted by Sorts in order
+type ItemFilter struct {
+	// MinSellingPrice and MaxSellingPrice are ignored when they are nil, 0 is a bound
+	MinSellingPrice *decimal.Decimal
+	MaxSellingPrice *decimal.Decimal
+	InStockOnly     bool
+	CreatedFrom     time.Time
+	CreatedTo       time.Time
+	// ProductID only the variants of the product
+	ProductID valueobject.ProductID
+	// CategoryID only the items in the category or its descendants
+	CategoryID valueobject.CategoryID
+	// Tags and Attributes only the items which have all of them
+	Tags       []string
+	Attributes []valueobject.ItemAttribute
+	Sorts      []valueobject.ItemSort
+}
+
+type ItemSearchRequest struct {
+	Query string
+}
+
+// ItemSearchHit the more relevant item has the higher score
+type ItemSearchHit struct {
+	Item      Item
+	Score     float64
+	Highlight ItemHighlight
+}
+
+// ItemHighlight the HTML escaped name and description of item with the matched words wrapped in <em>
+type ItemHighlight struct {
+	Name        string
+	Description string
+}
@@ -0,0 +1,19 @@
+package payload
+
+import "github.com/tuanna7593/gosample/app/domain/valueobject"
+
+type ItemTagRequest struct {
+	ItemID valueobject.ItemID
+	Tag    string
+}
+
+type ItemAttributeRequest struct {
+	ItemID valueobject.ItemID
+	Key    string
+	Value  string
+}
+
+type ItemAttribute struct {
+	Key   string
+	Value string
+}
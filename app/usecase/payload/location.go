@@ -0,0 +1,25 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type CreateLocationRequest struct {
+	Code string
+	Name string
+}
+
+type Location struct {
+	ID        valueobject.LocationID
+	Code      string
+	Name      string
+	CreatedAt time.Time
+}
+
+// ItemLocationStock the current stock of an item at a location
+type ItemLocationStock struct {
+	LocationID        valueobject.LocationID
+	CurrentStockValue uint64
+}
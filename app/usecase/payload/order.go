@@ -0,0 +1,34 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type Order struct {
+	ID         valueobject.OrderID
+	PlacedAt   time.Time
+	TotalPrice decimal.Decimal
+	Lines      []OrderLine
+}
+
+type OrderLine struct {
+	ItemID     valueobject.ItemID
+	PurchaseID valueobject.PurchaseID
+	Quantity   uint64
+	UnitPrice  decimal.Decimal
+	LineTotal  decimal.Decimal
+}
+
+// OrderRequest all the lines are bought together or none of them is
+type OrderRequest struct {
+	Lines []OrderLineRequest
+}
+
+type OrderLineRequest struct {
+	ItemID   valueobject.ItemID
+	Quantity uint64
+}
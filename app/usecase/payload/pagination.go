@@ -1,6 +1,9 @@
 package payload
 
+import "github.com/tuanna7593/gosample/app/domain/valueobject"
+
 type PaginationRequest struct {
-	Page  int64
-	Limit int64
+	Page   int64
+	Limit  int64
+	Cursor *valueobject.Cursor
 }
@@ -0,0 +1,25 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type CreateProductRequest struct {
+	Name        string
+	Description string
+}
+
+// Product the stock values are the sums of the stock of Variants
+type Product struct {
+	ID                    valueobject.ProductID
+	Name                  string
+	Description           string
+	TotalStockValue       uint64
+	CurrentStockValue     uint64
+	ReservedStockValue    uint64
+	BackorderedStockValue uint64
+	Variants              []Item
+	CreatedAt             time.Time
+}
@@ -7,13 +7,34 @@ import (
 )
 
 type Purchase struct {
-	ID       valueobject.PurchaseID
-	ItemID   valueobject.ItemID
-	Quantity uint64
-	BoughtAt time.Time
+	ID               valueobject.PurchaseID
+	ItemID           valueobject.ItemID
+	Quantity         uint64
+	BoughtAt         time.Time
+	RefundedQuantity uint64
+	RefundedAt       *time.Time
+	Status           valueobject.PurchaseStatus
+	FulfilledAt      *time.Time
 }
 
+// PurchaseFilter zero values are ignored
+type PurchaseFilter struct {
+	ItemID      valueobject.ItemID
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+// PurchaseRequest the item is only bought when its version equals ExpectedVersion if it is set,
+// the stock is taken from LocationID if it is set, otherwise by the allocation strategy
 type PurchaseRequest struct {
-	ItemID   valueobject.ItemID
-	Quantity uint64
+	ItemID          valueobject.ItemID
+	Quantity        uint64
+	LocationID      *valueobject.LocationID
+	ExpectedVersion *uint64
+}
+
+// RefundRequest zero quantity refunds all the remaining quantity of purchase
+type RefundRequest struct {
+	PurchaseID valueobject.PurchaseID
+	Quantity   uint64
 }
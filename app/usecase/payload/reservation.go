@@ -0,0 +1,24 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+type Reservation struct {
+	ID         valueobject.ReservationID
+	ItemID     valueobject.ItemID
+	Quantity   uint64
+	Status     valueobject.ReservationStatus
+	ReservedAt time.Time
+	ExpiresAt  time.Time
+	PurchaseID *valueobject.PurchaseID
+}
+
+// ReservationRequest hold the quantity of item during TTL
+type ReservationRequest struct {
+	ItemID   valueobject.ItemID
+	Quantity uint64
+	TTL      time.Duration
+}
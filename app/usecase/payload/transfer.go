@@ -0,0 +1,26 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+)
+
+// TransferRequest move the quantity of item from a location to another
+type TransferRequest struct {
+	ItemID         valueobject.ItemID
+	FromLocationID valueobject.LocationID
+	ToLocationID   valueobject.LocationID
+	Quantity       uint64
+}
+
+type Transfer struct {
+	ID             valueobject.TransferID
+	ItemID         valueobject.ItemID
+	FromLocationID valueobject.LocationID
+	ToLocationID   valueobject.LocationID
+	Quantity       uint64
+	Status         valueobject.TransferStatus
+	SentAt         time.Time
+	ReceivedAt     *time.Time
+}
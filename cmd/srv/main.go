@@ -13,6 +13,8 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/tuanna7593/gosample/app/config"
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/external/notifier"
 	"github.com/tuanna7593/gosample/app/external/persistence/mysql"
 	"github.com/tuanna7593/gosample/app/external/routes"
 )
@@ -25,6 +27,22 @@ func main() {
 		return
 	}
 
+	// take the stock from the location which has the most stock when the strategy is not configured
+	if cfg.Location.AllocationStrategy == "" {
+		cfg.Location.AllocationStrategy = string(valueobject.AllocationStrategyMostStock)
+	}
+	if !valueobject.AllocationStrategy(cfg.Location.AllocationStrategy).IsValid() {
+		log.Fatalf("invalid allocation strategy: %s", cfg.Location.AllocationStrategy)
+		return
+	}
+
+	// init the notifier of low stock alerts
+	stockAlertNotifier, err := notifier.NewStockAlertNotifier(cfg.Notifier)
+	if err != nil {
+		log.Fatalf("failed to init notifier: %v", err)
+		return
+	}
+
 	// init db
 	err = mysql.InitDB(cfg.MySQL)
 	if err != nil {
@@ -43,10 +61,20 @@ func main() {
 	// Define server
 	server := &http.Server{
 		Addr:    ":" + cfg.Server.Port,
-		Handler: routes.Handler(),
+		Handler: routes.Handler(cfg, stockAlertNotifier),
 	}
 	signal.Notify(runChan, os.Interrupt, syscall.SIGTSTP)
 
+	// run the background sweepers of expired reservations and idempotency keys
+	sweeperCtx, stopSweeper := context.WithCancel(context.Background())
+	defer stopSweeper()
+	go runReservationSweeper(
+		sweeperCtx,
+		cfg.Reservation.SweepInterval*time.Second,
+		valueobject.AllocationStrategy(cfg.Location.AllocationStrategy),
+	)
+	go runIdempotencySweeper(sweeperCtx, cfg.Idempotency.SweepInterval*time.Second)
+
 	// Run the server
 	log.Printf("Server is starting on %s\n", server.Addr)
 	go func() {
@@ -63,6 +91,7 @@ func main() {
 	interrupt := <-runChan
 
 	log.Printf("Server is shutting down due to %+v\n", interrupt)
+	stopSweeper()
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatalf("Server was unable to gracefully shutdown due to err: %+v", err)
 	}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/tuanna7593/gosample/app/domain/valueobject"
+	"github.com/tuanna7593/gosample/app/external/persistence/mysql"
+	"github.com/tuanna7593/gosample/app/usecase/interactor"
+)
+
+// defaultSweepInterval used when the sweep interval is not configured
+const defaultSweepInterval = 30 * time.Second
+
+// runReservationSweeper release the expired reservations periodically until the context is done,
+// the released stock is taken by the queued backorders with the allocation strategy
+func runReservationSweeper(ctx context.Context, interval time.Duration, allocationStrategy valueobject.AllocationStrategy) {
+	if interval <= 0 {
+		interval = defaultSweepInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Reservation sweeper is stopped")
+			return
+		case now := <-ticker.C:
+			// init usecase for each run, the repositories hold the transaction of the last run
+			uc := interactor.NewReservationUseCaseInteractor(
+				mysql.NewItemRepositoryImpl(),
+				mysql.NewPurchaseRepositoryImpl(),
+				mysql.NewReservationRepositoryImpl(),
+				mysql.NewInventoryMovementRepositoryImpl(),
+				mysql.NewLocationStockRepositoryImpl(),
+				mysql.NewTransactionManagerImpl(),
+				allocationStrategy,
+			)
+
+			released, err := uc.ReleaseExpired(ctx, now)
+			if err != nil {
+				log.Printf("failed to release expired reservations: %v\n", err)
+			}
+			if released > 0 {
+				log.Printf("released %d expired reservations\n", released)
+			}
+		}
+	}
+}
+
+// runIdempotencySweeper remove the expired idempotency keys periodically until the context is done
+func runIdempotencySweeper(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultSweepInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Idempotency sweeper is stopped")
+			return
+		case now := <-ticker.C:
+			uc := interactor.NewIdempotencyUseCaseInteractor(mysql.NewIdempotencyRepositoryImpl())
+
+			purged, err := uc.PurgeExpired(ctx, now)
+			if err != nil {
+				log.Printf("failed to purge expired idempotency keys: %v\n", err)
+			}
+			if purged > 0 {
+				log.Printf("purged %d expired idempotency keys\n", purged)
+			}
+		}
+	}
+}
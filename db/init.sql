@@ -1,10 +1,108 @@
 
+CREATE TABLE IF NOT EXISTS `products`(
+  `id` INTEGER UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `name` VARCHAR(255) NOT NULL,
+  `description` VARCHAR(2000) NOT NULL DEFAULT ''
+);
+
 CREATE TABLE IF NOT EXISTS `items`(
   `id` INTEGER UNSIGNED AUTO_INCREMENT PRIMARY KEY,
   `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `product_id` INTEGER UNSIGNED NULL DEFAULT NULL,
+  `sku` VARCHAR(64) NOT NULL,
+  `name` VARCHAR(255) NOT NULL,
+  `description` VARCHAR(2000) NOT NULL DEFAULT '',
   `total_stock_value` INTEGER UNSIGNED NOT NULL,
   `current_stock_value` INTEGER UNSIGNED NOT NULL,
-  `selling_price` DECIMAL(13, 2) UNSIGNED NOT NULL
+  `reserved_stock_value` INTEGER UNSIGNED NOT NULL DEFAULT 0,
+  `located_stock_value` INTEGER UNSIGNED NOT NULL DEFAULT 0,
+  `in_transit_stock_value` INTEGER UNSIGNED NOT NULL DEFAULT 0,
+  `reorder_threshold` INTEGER UNSIGNED NOT NULL DEFAULT 0,
+  `backordered_stock_value` INTEGER UNSIGNED NOT NULL DEFAULT 0,
+  `backorderable` BOOLEAN NOT NULL DEFAULT FALSE,
+  `selling_price` DECIMAL(13, 2) UNSIGNED NOT NULL,
+  `deleted_at` TIMESTAMP NULL DEFAULT NULL,
+  `version` INTEGER UNSIGNED NOT NULL DEFAULT 1,
+
+  UNIQUE INDEX `uq_items_sku` (`sku`),
+  INDEX `idx_items_deleted_at` (`deleted_at`),
+  INDEX `idx_items_product_id` (`product_id`),
+  FULLTEXT INDEX `ft_items_name_description` (`name`, `description`),
+  CONSTRAINT `fk_item_product_id` FOREIGN KEY(`product_id`) REFERENCES products(`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `categories`(
+  `id` INTEGER UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `name` VARCHAR(255) NOT NULL,
+  `parent_id` INTEGER UNSIGNED NULL DEFAULT NULL,
+
+  CONSTRAINT `fk_category_parent_id` FOREIGN KEY(`parent_id`) REFERENCES categories(`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `item_categories`(
+  `item_id` INTEGER UNSIGNED NOT NULL,
+  `category_id` INTEGER UNSIGNED NOT NULL,
+
+  PRIMARY KEY (`item_id`, `category_id`),
+  INDEX `idx_item_categories_category_id` (`category_id`),
+  CONSTRAINT `fk_item_category_item_id` FOREIGN KEY(`item_id`) REFERENCES items(`id`),
+  CONSTRAINT `fk_item_category_category_id` FOREIGN KEY(`category_id`) REFERENCES categories(`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `item_tags`(
+  `item_id` INTEGER UNSIGNED NOT NULL,
+  `tag` VARCHAR(64) NOT NULL,
+
+  PRIMARY KEY (`item_id`, `tag`),
+  INDEX `idx_item_tags_tag` (`tag`),
+  CONSTRAINT `fk_item_tag_item_id` FOREIGN KEY(`item_id`) REFERENCES items(`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `item_attributes`(
+  `item_id` INTEGER UNSIGNED NOT NULL,
+  `key` VARCHAR(64) NOT NULL,
+  `value` VARCHAR(255) NOT NULL,
+
+  PRIMARY KEY (`item_id`, `key`),
+  INDEX `idx_item_attributes_key_value` (`key`, `value`),
+  CONSTRAINT `fk_item_attribute_item_id` FOREIGN KEY(`item_id`) REFERENCES items(`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `locations`(
+  `id` INTEGER UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `code` VARCHAR(32) NOT NULL,
+  `name` VARCHAR(255) NOT NULL,
+
+  UNIQUE INDEX `uq_locations_code` (`code`)
+);
+
+CREATE TABLE IF NOT EXISTS `location_stocks`(
+  `id` INTEGER UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  `item_id` INTEGER UNSIGNED NOT NULL,
+  `location_id` INTEGER UNSIGNED NOT NULL,
+  `current_stock_value` INTEGER UNSIGNED NOT NULL DEFAULT 0,
+
+  UNIQUE INDEX `uq_location_stocks_item_id_location_id` (`item_id`, `location_id`),
+  CONSTRAINT `fk_location_stock_item_id` FOREIGN KEY(`item_id`) REFERENCES items(`id`),
+  CONSTRAINT `fk_location_stock_location_id` FOREIGN KEY(`location_id`) REFERENCES locations(`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `transfers`(
+  `id` INTEGER UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `item_id` INTEGER UNSIGNED NOT NULL,
+  `from_location_id` INTEGER UNSIGNED NOT NULL,
+  `to_location_id` INTEGER UNSIGNED NOT NULL,
+  `quantity` INTEGER UNSIGNED NOT NULL,
+  `status` VARCHAR(16) NOT NULL,
+  `received_at` TIMESTAMP NULL DEFAULT NULL,
+
+  CONSTRAINT `fk_transfer_item_id` FOREIGN KEY(`item_id`) REFERENCES items(`id`),
+  CONSTRAINT `fk_transfer_from_location_id` FOREIGN KEY(`from_location_id`) REFERENCES locations(`id`),
+  CONSTRAINT `fk_transfer_to_location_id` FOREIGN KEY(`to_location_id`) REFERENCES locations(`id`)
 );
 
 CREATE TABLE IF NOT EXISTS `purchases`(
@@ -12,7 +110,89 @@ CREATE TABLE IF NOT EXISTS `purchases`(
   `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
   `item_id` INTEGER UNSIGNED NOT NULL,
   `quantity` INTEGER UNSIGNED NOT NULL,
+  `refunded_quantity` INTEGER UNSIGNED NOT NULL DEFAULT 0,
+  `refunded_at` TIMESTAMP NULL DEFAULT NULL,
+  `status` VARCHAR(16) NOT NULL DEFAULT 'fulfilled',
+  `fulfilled_at` TIMESTAMP NULL DEFAULT NULL,
 
+  INDEX `idx_purchases_created_at` (`created_at`),
+  INDEX `idx_purchases_item_id_status` (`item_id`, `status`),
   CONSTRAINT `fk_purchase_item_id` FOREIGN KEY(`item_id`) REFERENCES items(`id`)
-)
+);
+
+CREATE TABLE IF NOT EXISTS `inventory_movements`(
+  `id` INTEGER UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `item_id` INTEGER UNSIGNED NOT NULL,
+  `reason` VARCHAR(32) NOT NULL,
+  `delta` INTEGER NOT NULL,
+  `reason_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `actor` VARCHAR(64) NOT NULL DEFAULT '',
+  `note` VARCHAR(255) NOT NULL DEFAULT '',
+
+  INDEX `idx_inventory_movements_item_id_created_at` (`item_id`, `created_at`),
+  CONSTRAINT `fk_inventory_movement_item_id` FOREIGN KEY(`item_id`) REFERENCES items(`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `reservations`(
+  `id` INTEGER UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `item_id` INTEGER UNSIGNED NOT NULL,
+  `quantity` INTEGER UNSIGNED NOT NULL,
+  `status` VARCHAR(16) NOT NULL,
+  `expires_at` TIMESTAMP NOT NULL,
+  `purchase_id` INTEGER UNSIGNED NULL DEFAULT NULL,
+
+  INDEX `idx_reservations_status_expires_at` (`status`, `expires_at`),
+  CONSTRAINT `fk_reservation_item_id` FOREIGN KEY(`item_id`) REFERENCES items(`id`),
+  CONSTRAINT `fk_reservation_purchase_id` FOREIGN KEY(`purchase_id`) REFERENCES purchases(`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `orders`(
+  `id` INTEGER UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `total_price` DECIMAL(13, 2) UNSIGNED NOT NULL
+);
 
+CREATE TABLE IF NOT EXISTS `order_lines`(
+  `id` INTEGER UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  `order_id` INTEGER UNSIGNED NOT NULL,
+  `item_id` INTEGER UNSIGNED NOT NULL,
+  `purchase_id` INTEGER UNSIGNED NOT NULL,
+  `quantity` INTEGER UNSIGNED NOT NULL,
+  `unit_price` DECIMAL(13, 2) UNSIGNED NOT NULL,
+
+  CONSTRAINT `fk_order_line_order_id` FOREIGN KEY(`order_id`) REFERENCES orders(`id`),
+  CONSTRAINT `fk_order_line_item_id` FOREIGN KEY(`item_id`) REFERENCES items(`id`),
+  CONSTRAINT `fk_order_line_purchase_id` FOREIGN KEY(`purchase_id`) REFERENCES purchases(`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `bundles`(
+  `id` INTEGER UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `name` VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS `bundle_components`(
+  `bundle_id` INTEGER UNSIGNED NOT NULL,
+  `item_id` INTEGER UNSIGNED NOT NULL,
+  `quantity` INTEGER UNSIGNED NOT NULL,
+
+  PRIMARY KEY (`bundle_id`, `item_id`),
+  CONSTRAINT `fk_bundle_component_bundle_id` FOREIGN KEY(`bundle_id`) REFERENCES bundles(`id`),
+  CONSTRAINT `fk_bundle_component_item_id` FOREIGN KEY(`item_id`) REFERENCES items(`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `idempotency_records`(
+  `id` INTEGER UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `key` VARCHAR(255) NOT NULL,
+  `request_hash` CHAR(64) NOT NULL,
+  `response_status` SMALLINT UNSIGNED NOT NULL DEFAULT 0,
+  `response_headers` TEXT NOT NULL,
+  `response_body` MEDIUMTEXT NOT NULL,
+  `expires_at` TIMESTAMP NOT NULL,
+
+  UNIQUE KEY `uk_idempotency_records_key` (`key`),
+  INDEX `idx_idempotency_records_expires_at` (`expires_at`)
+);
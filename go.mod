@@ -7,6 +7,7 @@ require (
 	github.com/go-chi/chi/v5 v5.0.4
 	github.com/go-chi/render v1.0.1 // indirect
 	github.com/go-playground/validator/v10 v10.9.0
+	github.com/go-sql-driver/mysql v1.6.0
 	github.com/golang/mock v1.6.0
 	github.com/google/go-cmp v0.5.6
 	github.com/shopspring/decimal v1.3.0